@@ -0,0 +1,34 @@
+package gofetch
+
+import "github.com/fourth-ally/gofetch/infrastructure"
+
+// DockerSocket is the default path of the Docker Engine API socket.
+const DockerSocket = "/var/run/docker.sock"
+
+// NewDockerClient creates a client for the Docker Engine API listening on
+// the Unix socket at socket (DockerSocket when empty). Paths may carry an
+// API version prefix such as "/v1.45"; without one the daemon uses its
+// latest version. Follow progress streams with
+// Client.StreamDockerMessages, and attach to containers or exec instances
+// with Client.Upgrade and DemuxDockerStream.
+//
+// Example:
+//
+//	client := gofetch.NewDockerClient("")
+//	err := client.StreamDockerMessages(ctx, http.MethodPost, "/images/create",
+//	    map[string]interface{}{"fromImage": "alpine", "tag": "latest"}, nil,
+//	    func(msg models.DockerMessage) error { fmt.Println(msg.Status, msg.Progress); return nil })
+//
+//	conn, err := client.Upgrade(ctx, http.MethodPost, "/exec/:id/start",
+//	    map[string]interface{}{"id": execID}, map[string]bool{"Detach": false, "Tty": false}, "tcp")
+//	defer conn.Close()
+//	err = infrastructure.DemuxDockerStream(conn, os.Stdout, os.Stderr)
+func NewDockerClient(socket string) *infrastructure.Client {
+	if socket == "" {
+		socket = DockerSocket
+	}
+
+	return infrastructure.NewClient().
+		SetUnixSocket(socket).
+		SetBaseURL("http://docker")
+}
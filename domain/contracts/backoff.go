@@ -0,0 +1,19 @@
+package contracts
+
+import "time"
+
+// Backoff defines the contract for computing the delay before a retry.
+// Implementations must be safe for concurrent use.
+type Backoff interface {
+	// Delay returns how long to wait before retry attempt (zero-based),
+	// given the delay that preceded the previous retry (zero for the first).
+	Delay(attempt int, previous time.Duration) time.Duration
+}
+
+// BackoffFunc adapts an ordinary function to the Backoff contract.
+type BackoffFunc func(attempt int, previous time.Duration) time.Duration
+
+// Delay calls f(attempt, previous).
+func (f BackoffFunc) Delay(attempt int, previous time.Duration) time.Duration {
+	return f(attempt, previous)
+}
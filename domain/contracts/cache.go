@@ -0,0 +1,16 @@
+package contracts
+
+import "github.com/fourth-ally/gofetch/domain/models"
+
+// CacheStore defines the contract for storing cached HTTP responses.
+// Implementations must be safe for concurrent use.
+type CacheStore interface {
+	// Get returns the entry stored under key, if any.
+	Get(key string) (*models.CacheEntry, bool)
+
+	// Set stores an entry under key, replacing any existing one.
+	Set(key string, entry *models.CacheEntry)
+
+	// Delete removes the entry stored under key.
+	Delete(key string)
+}
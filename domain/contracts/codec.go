@@ -0,0 +1,15 @@
+package contracts
+
+// Codec defines the contract for encoding request bodies and decoding
+// response bodies in a wire format such as JSON or MessagePack.
+// Implementations must be safe for concurrent use.
+type Codec interface {
+	// ContentType returns the media type of encoded bodies.
+	ContentType() string
+
+	// Marshal encodes v.
+	Marshal(v interface{}) ([]byte, error)
+
+	// Unmarshal decodes data into v.
+	Unmarshal(data []byte, v interface{}) error
+}
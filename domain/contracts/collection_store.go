@@ -0,0 +1,13 @@
+package contracts
+
+import "github.com/fourth-ally/gofetch/domain/models"
+
+// CollectionStore persists the local snapshot maintained by
+// SyncCollection, e.g. in memory, a file or browser storage.
+type CollectionStore interface {
+	// Load returns the stored snapshot, or nil before the first sync.
+	Load() (*models.CollectionSnapshot, error)
+
+	// Save replaces the stored snapshot.
+	Save(snapshot *models.CollectionSnapshot) error
+}
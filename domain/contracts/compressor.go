@@ -0,0 +1,14 @@
+package contracts
+
+import "io"
+
+// Compressor defines the contract for a compression codec usable both to
+// encode request bodies and to decode responses sent with its
+// Content-Encoding. Implementations must be safe for concurrent use.
+type Compressor interface {
+	Decompressor
+
+	// NewWriter returns a writer that compresses into w. Closing it must
+	// flush all pending data but not close w.
+	NewWriter(w io.Writer) (io.WriteCloser, error)
+}
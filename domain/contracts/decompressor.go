@@ -0,0 +1,14 @@
+package contracts
+
+import "io"
+
+// Decompressor defines the contract for decoding response bodies sent
+// with a Content-Encoding such as "br" or "zstd".
+// Implementations must be safe for concurrent use.
+type Decompressor interface {
+	// Encoding returns the Content-Encoding token the decompressor handles.
+	Encoding() string
+
+	// NewReader returns a reader of the decompressed content of r.
+	NewReader(r io.Reader) (io.ReadCloser, error)
+}
@@ -0,0 +1,17 @@
+package contracts
+
+// IDGenerator defines the contract for generating unique identifiers such
+// as request IDs, idempotency keys and queued request IDs.
+// Implementations must be safe for concurrent use.
+type IDGenerator interface {
+	// NewID returns a new unique identifier.
+	NewID() string
+}
+
+// IDGeneratorFunc adapts an ordinary function to the IDGenerator contract.
+type IDGeneratorFunc func() string
+
+// NewID calls f().
+func (f IDGeneratorFunc) NewID() string {
+	return f()
+}
@@ -0,0 +1,12 @@
+package contracts
+
+// JSON defines the contract for a JSON implementation, so that drop-in
+// replacements for encoding/json such as jsoniter, go-json or sonic can
+// be plugged in. Implementations must be safe for concurrent use.
+type JSON interface {
+	// Marshal encodes v as JSON.
+	Marshal(v interface{}) ([]byte, error)
+
+	// Unmarshal decodes JSON data into v.
+	Unmarshal(data []byte, v interface{}) error
+}
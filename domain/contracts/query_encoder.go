@@ -0,0 +1,17 @@
+package contracts
+
+// QueryEncoder defines the contract for encoding the request params that
+// are not path parameters into a query string.
+type QueryEncoder interface {
+	// EncodeQuery returns the query string for params, without a leading
+	// "?". An empty result adds no query.
+	EncodeQuery(params map[string]interface{}) (string, error)
+}
+
+// QueryEncoderFunc adapts an ordinary function to the QueryEncoder contract.
+type QueryEncoderFunc func(params map[string]interface{}) (string, error)
+
+// EncodeQuery calls f(params).
+func (f QueryEncoderFunc) EncodeQuery(params map[string]interface{}) (string, error) {
+	return f(params)
+}
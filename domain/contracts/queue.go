@@ -0,0 +1,16 @@
+package contracts
+
+import "github.com/fourth-ally/gofetch/domain/models"
+
+// QueueStore defines the contract for durably storing offline requests.
+// Implementations must be safe for concurrent use and preserve order.
+type QueueStore interface {
+	// Append adds a request to the end of the queue.
+	Append(req *models.QueuedRequest) error
+
+	// List returns all queued requests, oldest first.
+	List() ([]*models.QueuedRequest, error)
+
+	// Remove deletes the request with the given ID.
+	Remove(id string) error
+}
@@ -0,0 +1,13 @@
+package contracts
+
+import "context"
+
+// Resolver defines the contract for resolving host names to IP addresses.
+// Implementations must be safe for concurrent use.
+type Resolver interface {
+	// Name identifies the resolver in Timings.Resolver, e.g. "system".
+	Name() string
+
+	// LookupHost returns the IP addresses of host.
+	LookupHost(ctx context.Context, host string) ([]string, error)
+}
@@ -0,0 +1,7 @@
+package contracts
+
+import "crypto/tls"
+
+// CertVerifier defines the contract for additional verification of a TLS
+// connection after the standard certificate chain checks have passed.
+type CertVerifier func(state tls.ConnectionState) error
@@ -0,0 +1,24 @@
+package errors
+
+import "fmt"
+
+// BulkheadFullError is returned when a request is rejected because the
+// client's concurrency limit was reached.
+type BulkheadFullError struct {
+	// Host is set when the per-host limit was reached, empty for the global limit.
+	Host  string
+	Limit int
+}
+
+// Error implements the error interface.
+func (e *BulkheadFullError) Error() string {
+	if e.Host != "" {
+		return fmt.Sprintf("bulkhead full: %d concurrent requests to %s", e.Limit, e.Host)
+	}
+	return fmt.Sprintf("bulkhead full: %d concurrent requests", e.Limit)
+}
+
+// Code returns CodeBulkheadFull.
+func (e *BulkheadFullError) Code() Code {
+	return CodeBulkheadFull
+}
@@ -0,0 +1,25 @@
+package errors
+
+import "fmt"
+
+// ChecksumMismatchError is returned when downloaded content doesn't match
+// its expected hash. Expected and Actual are hex-encoded.
+type ChecksumMismatchError struct {
+	Algorithm string
+	Expected  string
+	Actual    string
+
+	// Source is where the expected hash came from: "option" or the name
+	// of the response header.
+	Source string
+}
+
+// Error implements the error interface.
+func (e *ChecksumMismatchError) Error() string {
+	return fmt.Sprintf("%s checksum mismatch (%s): expected %s, got %s", e.Algorithm, e.Source, e.Expected, e.Actual)
+}
+
+// Code returns CodeChecksumMismatch.
+func (e *ChecksumMismatchError) Code() Code {
+	return CodeChecksumMismatch
+}
@@ -0,0 +1,133 @@
+package errors
+
+import (
+	"context"
+	"encoding/json"
+	stderrors "errors"
+)
+
+// Code is a stable, machine-readable error code for telemetry, so failures
+// can be aggregated consistently regardless of error messages.
+type Code string
+
+const (
+	// CodeTimeout means a deadline was exceeded while connecting or reading.
+	CodeTimeout Code = "GOFETCH_TIMEOUT"
+	// CodeCanceled means the request context was canceled.
+	CodeCanceled Code = "GOFETCH_CANCELED"
+	// CodeDNS means the host name could not be resolved.
+	CodeDNS Code = "GOFETCH_DNS"
+	// CodeConnectionRefused means the server refused the connection.
+	CodeConnectionRefused Code = "GOFETCH_CONNECTION_REFUSED"
+	// CodeConnectionReset means an established connection was reset or closed.
+	CodeConnectionReset Code = "GOFETCH_CONNECTION_RESET"
+	// CodeTLS means the TLS handshake or certificate verification failed.
+	CodeTLS Code = "GOFETCH_TLS"
+	// CodeTransport covers other failures before a response was received.
+	CodeTransport Code = "GOFETCH_TRANSPORT"
+	// CodeHTTPStatus means the status validator rejected the response.
+	CodeHTTPStatus Code = "GOFETCH_HTTP_STATUS"
+	// CodeDecode means the response body could not be unmarshaled.
+	CodeDecode Code = "GOFETCH_DECODE"
+	// CodeCircuitOpen means the circuit breaker rejected the request.
+	CodeCircuitOpen Code = "GOFETCH_CIRCUIT_OPEN"
+	// CodeBulkheadFull means the concurrency limit was reached.
+	CodeBulkheadFull Code = "GOFETCH_BULKHEAD_FULL"
+	// CodeRetryAborted means retrying stopped before attempts ran out.
+	CodeRetryAborted Code = "GOFETCH_RETRY_ABORTED"
+	// CodeConfig means the client configuration is invalid.
+	CodeConfig Code = "GOFETCH_CONFIG"
+	// CodeContentMismatch means the body didn't match its declared Content-Type.
+	CodeContentMismatch Code = "GOFETCH_CONTENT_MISMATCH"
+	// CodeCORS means the browser blocked the request.
+	CodeCORS Code = "GOFETCH_CORS"
+	// CodeHeaderLimit means the response headers exceeded a limit.
+	CodeHeaderLimit Code = "GOFETCH_HEADER_LIMIT"
+	// CodeResourceExpired means a watched resource version expired.
+	CodeResourceExpired Code = "GOFETCH_RESOURCE_EXPIRED"
+	// CodeResponseTooLarge means the body exceeded the maximum response size.
+	CodeResponseTooLarge Code = "GOFETCH_RESPONSE_TOO_LARGE"
+	// CodeRobotsDisallowed means robots.txt disallows the URL.
+	CodeRobotsDisallowed Code = "GOFETCH_ROBOTS_DISALLOWED"
+	// CodeChecksumMismatch means downloaded content failed verification.
+	CodeChecksumMismatch Code = "GOFETCH_CHECKSUM_MISMATCH"
+	// CodeInvalidURL means the request URL could not be built.
+	CodeInvalidURL Code = "GOFETCH_INVALID_URL"
+	// CodeUnknown covers errors without a more specific code.
+	CodeUnknown Code = "GOFETCH_UNKNOWN"
+)
+
+// coder is implemented by errors that carry a Code.
+type coder interface {
+	Code() Code
+}
+
+// CodeOf returns the code of the outermost error in err's chain that has
+// one. Context errors and JSON syntax and type errors are recognized too;
+// any other error is CodeUnknown. A nil error has no code.
+func CodeOf(err error) Code {
+	if err == nil {
+		return ""
+	}
+
+	var coded coder
+	if stderrors.As(err, &coded) {
+		return coded.Code()
+	}
+
+	var syntaxErr *json.SyntaxError
+	var typeErr *json.UnmarshalTypeError
+	switch {
+	case stderrors.Is(err, context.DeadlineExceeded):
+		return CodeTimeout
+	case stderrors.Is(err, context.Canceled):
+		return CodeCanceled
+	case stderrors.As(err, &syntaxErr), stderrors.As(err, &typeErr):
+		return CodeDecode
+	}
+	return CodeUnknown
+}
+
+// DecodeError is returned when a response body can't be unmarshaled into
+// the target.
+type DecodeError struct {
+	Err error
+}
+
+// Error implements the error interface.
+func (e *DecodeError) Error() string {
+	return "failed to unmarshal response: " + e.Err.Error()
+}
+
+// Unwrap returns the codec error.
+func (e *DecodeError) Unwrap() error {
+	return e.Err
+}
+
+// Code returns CodeDecode.
+func (e *DecodeError) Code() Code {
+	return CodeDecode
+}
+
+// CircuitOpenError is returned when the circuit breaker rejects a request
+// without sending it.
+type CircuitOpenError struct {
+	Endpoint string
+
+	// HalfOpen reports whether the circuit was half-open with all its
+	// trial requests in flight.
+	HalfOpen bool
+}
+
+// Error implements the error interface.
+func (e *CircuitOpenError) Error() string {
+	if e.HalfOpen {
+		return "circuit breaker: too many requests in half-open state for: " + e.Endpoint
+	}
+	return "circuit breaker is open for endpoint: " + e.Endpoint
+}
+
+// Code returns CodeCircuitOpen.
+func (e *CircuitOpenError) Code() Code {
+	return CodeCircuitOpen
+}
@@ -0,0 +1,20 @@
+package errors
+
+import "fmt"
+
+// ConfigError describes an inconsistent client configuration setting.
+type ConfigError struct {
+	// Field names the offending setting, e.g. "TLSHandshakeTimeout".
+	Field   string
+	Message string
+}
+
+// Error implements the error interface.
+func (e *ConfigError) Error() string {
+	return fmt.Sprintf("invalid configuration: %s: %s", e.Field, e.Message)
+}
+
+// Code returns CodeConfig.
+func (e *ConfigError) Code() Code {
+	return CodeConfig
+}
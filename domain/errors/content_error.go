@@ -0,0 +1,33 @@
+package errors
+
+import "fmt"
+
+// maxPreviewBytes caps the body preview included in error messages.
+const maxPreviewBytes = 128
+
+// ContentMismatchError is returned when a response body does not look like
+// its declared Content-Type, typically an HTML error page from a proxy
+// served as JSON.
+type ContentMismatchError struct {
+	Declared string
+	Detected string
+	Body     []byte
+}
+
+// Error implements the error interface.
+func (e *ContentMismatchError) Error() string {
+	return fmt.Sprintf("response declared %s but body looks like %s: %q", e.Declared, e.Detected, e.Preview())
+}
+
+// Preview returns the beginning of the body for diagnostics.
+func (e *ContentMismatchError) Preview() string {
+	if len(e.Body) > maxPreviewBytes {
+		return string(e.Body[:maxPreviewBytes]) + "..."
+	}
+	return string(e.Body)
+}
+
+// Code returns CodeContentMismatch.
+func (e *ContentMismatchError) Code() Code {
+	return CodeContentMismatch
+}
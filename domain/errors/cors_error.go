@@ -0,0 +1,36 @@
+package errors
+
+import (
+	"fmt"
+	"strings"
+)
+
+// CORSError is returned before sending a cross-origin request from the
+// browser that would need a CORS preflight or be blocked outright, so the
+// caller gets the reasons instead of the browser's opaque network error.
+type CORSError struct {
+	Method string
+	URL    string
+
+	// Reasons lists what makes the request non-simple, e.g. a header
+	// that is not CORS-safelisted.
+	Reasons []string
+
+	// Hint suggests a fix or points out a server requirement, such as an
+	// explicit Access-Control-Allow-Origin when credentials are included.
+	Hint string
+}
+
+// Error implements the error interface.
+func (e *CORSError) Error() string {
+	msg := fmt.Sprintf("CORS preflight required for %s %s: %s", e.Method, e.URL, strings.Join(e.Reasons, "; "))
+	if e.Hint != "" {
+		msg += " (" + e.Hint + ")"
+	}
+	return msg
+}
+
+// Code returns CodeCORS.
+func (e *CORSError) Code() Code {
+	return CodeCORS
+}
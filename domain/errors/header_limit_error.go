@@ -0,0 +1,47 @@
+package errors
+
+import "fmt"
+
+// HeaderLimit identifies which response header limit was exceeded.
+type HeaderLimit string
+
+const (
+	// HeaderLimitBytes means the response headers exceeded the size limit.
+	HeaderLimitBytes HeaderLimit = "bytes"
+	// HeaderLimitCount means the response carried too many header fields.
+	HeaderLimitCount HeaderLimit = "count"
+)
+
+// HeaderLimitError is returned when a response exceeds the configured
+// header size or header count limit. The response body is not read.
+type HeaderLimitError struct {
+	Limit HeaderLimit
+
+	// Max is the configured limit.
+	Max int64
+
+	// Actual is the number of header fields received. It is 0 for size
+	// limits, since the transport aborts before reading all headers.
+	Actual int64
+
+	// Err is the underlying transport error for size limits.
+	Err error
+}
+
+// Error implements the error interface.
+func (e *HeaderLimitError) Error() string {
+	if e.Limit == HeaderLimitCount {
+		return fmt.Sprintf("response header limit exceeded: %d header fields, limit %d", e.Actual, e.Max)
+	}
+	return fmt.Sprintf("response header limit exceeded: more than %d bytes", e.Max)
+}
+
+// Unwrap returns the underlying transport error.
+func (e *HeaderLimitError) Unwrap() error {
+	return e.Err
+}
+
+// Code returns CodeHeaderLimit.
+func (e *HeaderLimitError) Code() Code {
+	return CodeHeaderLimit
+}
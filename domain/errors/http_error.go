@@ -13,6 +13,27 @@ type HTTPError struct {
 	Headers      http.Header
 	Message      string
 	OriginalResp *http.Response
+
+	// BodyLength is the full length of the error body, which exceeds
+	// len(Body) when the body was truncated. It is -1 when the body was
+	// truncated and its length is unknown.
+	BodyLength int64
+
+	// Truncated reports whether Body holds only the beginning of the body.
+	Truncated bool
+
+	// BodyFile is the path of a temporary file holding the complete body
+	// when a truncated body was spilled to disk. The caller owns the file.
+	BodyFile string
+
+	// Decoded is the body decoded into the error type registered with
+	// SetError or WithError, e.g. a *APIError. It is nil when no type was
+	// registered or the body didn't decode.
+	Decoded interface{}
+
+	// Problem is the parsed body of an application/problem+json response.
+	// It is nil for other content types or bodies that didn't parse.
+	Problem *ProblemDetails
 }
 
 // Error implements the error interface.
@@ -20,6 +41,12 @@ func (e *HTTPError) Error() string {
 	if e.Message != "" {
 		return fmt.Sprintf("HTTP %d: %s", e.StatusCode, e.Message)
 	}
+	if e.Problem != nil && e.Problem.Title != "" {
+		if e.Problem.Detail != "" {
+			return fmt.Sprintf("HTTP %d: %s: %s", e.StatusCode, e.Problem.Title, e.Problem.Detail)
+		}
+		return fmt.Sprintf("HTTP %d: %s", e.StatusCode, e.Problem.Title)
+	}
 	return fmt.Sprintf("HTTP %d: %s", e.StatusCode, http.StatusText(e.StatusCode))
 }
 
@@ -31,5 +58,19 @@ func NewHTTPError(resp *http.Response, body []byte, message string) *HTTPError {
 		Headers:      resp.Header,
 		Message:      message,
 		OriginalResp: resp,
+		BodyLength:   int64(len(body)),
 	}
 }
+
+// Preview returns the beginning of the body for diagnostics.
+func (e *HTTPError) Preview() string {
+	if len(e.Body) > maxPreviewBytes || e.Truncated {
+		return string(e.Body[:min(len(e.Body), maxPreviewBytes)]) + "..."
+	}
+	return string(e.Body)
+}
+
+// Code returns CodeHTTPStatus.
+func (e *HTTPError) Code() Code {
+	return CodeHTTPStatus
+}
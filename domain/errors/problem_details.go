@@ -0,0 +1,62 @@
+package errors
+
+import (
+	"encoding/json"
+	"mime"
+	"net/http"
+)
+
+// ProblemMediaType is the media type of RFC 7807 problem details.
+const ProblemMediaType = "application/problem+json"
+
+// ProblemDetails is an RFC 7807 problem details object. Members other
+// than the standard ones are kept in Extensions.
+type ProblemDetails struct {
+	Type       string
+	Title      string
+	Status     int
+	Detail     string
+	Instance   string
+	Extensions map[string]interface{}
+}
+
+// ParseProblemDetails parses an application/problem+json body.
+func ParseProblemDetails(body []byte) (*ProblemDetails, error) {
+	var members map[string]json.RawMessage
+	if err := json.Unmarshal(body, &members); err != nil {
+		return nil, err
+	}
+
+	problem := &ProblemDetails{}
+	standard := map[string]interface{}{
+		"type":     &problem.Type,
+		"title":    &problem.Title,
+		"status":   &problem.Status,
+		"detail":   &problem.Detail,
+		"instance": &problem.Instance,
+	}
+	for name, raw := range members {
+		if target, ok := standard[name]; ok {
+			// Members of the wrong type are ignored, as RFC 7807 requires
+			json.Unmarshal(raw, target)
+			continue
+		}
+
+		var value interface{}
+		if err := json.Unmarshal(raw, &value); err != nil {
+			return nil, err
+		}
+		if problem.Extensions == nil {
+			problem.Extensions = make(map[string]interface{})
+		}
+		problem.Extensions[name] = value
+	}
+
+	return problem, nil
+}
+
+// IsProblemResponse reports whether headers declare a problem details body.
+func IsProblemResponse(headers http.Header) bool {
+	mediaType, _, err := mime.ParseMediaType(headers.Get("Content-Type"))
+	return err == nil && mediaType == ProblemMediaType
+}
@@ -0,0 +1,24 @@
+package errors
+
+import "fmt"
+
+// ResourceExpiredError is returned by a Kubernetes watch when the server
+// no longer has the requested resource version (410 Gone). The caller
+// must list the resource again and watch from the new version.
+type ResourceExpiredError struct {
+	ResourceVersion string
+	Message         string
+}
+
+// Error implements the error interface.
+func (e *ResourceExpiredError) Error() string {
+	if e.Message != "" {
+		return fmt.Sprintf("resource version %q expired: %s", e.ResourceVersion, e.Message)
+	}
+	return fmt.Sprintf("resource version %q expired", e.ResourceVersion)
+}
+
+// Code returns CodeResourceExpired.
+func (e *ResourceExpiredError) Code() Code {
+	return CodeResourceExpired
+}
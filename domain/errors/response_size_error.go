@@ -0,0 +1,27 @@
+package errors
+
+import "fmt"
+
+// ResponseTooLargeError is returned when a response body exceeds the
+// client's maximum response size. Reading stops at the limit.
+type ResponseTooLargeError struct {
+	// Limit is the configured maximum size in bytes.
+	Limit int64
+
+	// ContentLength is the declared length of the body, or -1 when the
+	// limit was hit while reading a body of unknown length.
+	ContentLength int64
+}
+
+// Error implements the error interface.
+func (e *ResponseTooLargeError) Error() string {
+	if e.ContentLength >= 0 {
+		return fmt.Sprintf("response body of %d bytes exceeds the %d byte limit", e.ContentLength, e.Limit)
+	}
+	return fmt.Sprintf("response body exceeds the %d byte limit", e.Limit)
+}
+
+// Code returns CodeResponseTooLarge.
+func (e *ResponseTooLargeError) Code() Code {
+	return CodeResponseTooLarge
+}
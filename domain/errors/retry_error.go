@@ -0,0 +1,45 @@
+package errors
+
+import (
+	"fmt"
+	"time"
+)
+
+// RetryAbortedError is returned when the retry loop gives up before its
+// attempts are exhausted because the next retry could not finish in time.
+type RetryAbortedError struct {
+	// Reason is why retrying stopped, e.g. "deadline".
+	Reason string
+
+	// Attempts is the number of attempts made before aborting.
+	Attempts int
+
+	// Delay is the backoff that would have preceded the next attempt.
+	Delay time.Duration
+
+	// Remaining is the time left before the context deadline.
+	Remaining time.Duration
+
+	// Err is the error of the last attempt.
+	Err error
+}
+
+// Error implements the error interface.
+func (e *RetryAbortedError) Error() string {
+	msg := fmt.Sprintf("retry aborted: %s after %d attempts (next backoff %v, %v remaining)",
+		e.Reason, e.Attempts, e.Delay, e.Remaining)
+	if e.Err != nil {
+		msg += ": " + e.Err.Error()
+	}
+	return msg
+}
+
+// Unwrap returns the error of the last attempt.
+func (e *RetryAbortedError) Unwrap() error {
+	return e.Err
+}
+
+// Code returns CodeRetryAborted.
+func (e *RetryAbortedError) Code() Code {
+	return CodeRetryAborted
+}
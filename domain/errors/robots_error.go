@@ -0,0 +1,20 @@
+package errors
+
+import "fmt"
+
+// RobotsDisallowedError is returned when a crawl policy rejects a request
+// because the host's robots.txt disallows the URL.
+type RobotsDisallowedError struct {
+	URL       string
+	UserAgent string
+}
+
+// Error implements the error interface.
+func (e *RobotsDisallowedError) Error() string {
+	return fmt.Sprintf("robots.txt disallows %s for %q", e.URL, e.UserAgent)
+}
+
+// Code returns CodeRobotsDisallowed.
+func (e *RobotsDisallowedError) Code() Code {
+	return CodeRobotsDisallowed
+}
@@ -0,0 +1,129 @@
+package errors
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	stderrors "errors"
+	"fmt"
+	"io"
+	"net"
+	"syscall"
+)
+
+// TransportErrorKind categorizes a transport-level failure.
+type TransportErrorKind string
+
+const (
+	// TransportDNS means the host name could not be resolved.
+	TransportDNS TransportErrorKind = "dns"
+	// TransportConnectionRefused means the server actively refused the connection.
+	TransportConnectionRefused TransportErrorKind = "connection_refused"
+	// TransportTimeout means a dial, handshake or read deadline was exceeded.
+	TransportTimeout TransportErrorKind = "timeout"
+	// TransportTLS means the TLS handshake or certificate verification failed.
+	TransportTLS TransportErrorKind = "tls"
+	// TransportConnectionReset means an established connection was reset or closed.
+	TransportConnectionReset TransportErrorKind = "connection_reset"
+	// TransportUnknown covers all other transport failures.
+	TransportUnknown TransportErrorKind = "unknown"
+)
+
+// TransportError is a failure that occurred before an HTTP response was
+// received, classified by Kind so callers and retry policies can tell
+// retryable conditions from fatal ones.
+type TransportError struct {
+	Kind TransportErrorKind
+	Err  error
+}
+
+// Error implements the error interface.
+func (e *TransportError) Error() string {
+	return fmt.Sprintf("transport error (%s): %v", e.Kind, e.Err)
+}
+
+// Unwrap returns the underlying error.
+func (e *TransportError) Unwrap() error {
+	return e.Err
+}
+
+// Code returns the code for the kind of failure.
+func (e *TransportError) Code() Code {
+	switch e.Kind {
+	case TransportDNS:
+		return CodeDNS
+	case TransportConnectionRefused:
+		return CodeConnectionRefused
+	case TransportTimeout:
+		return CodeTimeout
+	case TransportTLS:
+		return CodeTLS
+	case TransportConnectionReset:
+		return CodeConnectionReset
+	default:
+		return CodeTransport
+	}
+}
+
+// ClassifyTransportError wraps err in a TransportError describing its
+// category. Context cancellation is returned unchanged since it is not a
+// transport failure.
+func ClassifyTransportError(err error) error {
+	if err == nil || stderrors.Is(err, context.Canceled) {
+		return err
+	}
+
+	var transportErr *TransportError
+	if stderrors.As(err, &transportErr) {
+		return err
+	}
+
+	return &TransportError{Kind: transportErrorKind(err), Err: err}
+}
+
+// transportErrorKind determines the category of err.
+func transportErrorKind(err error) TransportErrorKind {
+	var dnsErr *net.DNSError
+	if stderrors.As(err, &dnsErr) {
+		if dnsErr.IsTimeout {
+			return TransportTimeout
+		}
+		return TransportDNS
+	}
+
+	if stderrors.Is(err, context.DeadlineExceeded) {
+		return TransportTimeout
+	}
+
+	var netErr net.Error
+	if stderrors.As(err, &netErr) && netErr.Timeout() {
+		return TransportTimeout
+	}
+
+	var recordErr tls.RecordHeaderError
+	var certErr *tls.CertificateVerificationError
+	var unknownAuthority x509.UnknownAuthorityError
+	var hostnameErr x509.HostnameError
+	var invalidErr x509.CertificateInvalidError
+	if stderrors.As(err, &recordErr) || stderrors.As(err, &certErr) || stderrors.As(err, &unknownAuthority) ||
+		stderrors.As(err, &hostnameErr) || stderrors.As(err, &invalidErr) {
+		return TransportTLS
+	}
+
+	var alertErr tls.AlertError
+	if stderrors.As(err, &alertErr) {
+		return TransportTLS
+	}
+
+	if stderrors.Is(err, syscall.ECONNREFUSED) {
+		return TransportConnectionRefused
+	}
+
+	if stderrors.Is(err, syscall.ECONNRESET) || stderrors.Is(err, syscall.ECONNABORTED) ||
+		stderrors.Is(err, syscall.EPIPE) || stderrors.Is(err, net.ErrClosed) ||
+		stderrors.Is(err, io.EOF) || stderrors.Is(err, io.ErrUnexpectedEOF) {
+		return TransportConnectionReset
+	}
+
+	return TransportUnknown
+}
@@ -0,0 +1,30 @@
+package errors
+
+import "fmt"
+
+// URLError reports a request URL that could not be built, e.g. from an
+// invalid base URL or a path parameter that would escape its segment.
+type URLError struct {
+	// URL is the base URL or path that was being built.
+	URL    string
+	Reason string
+	Err    error
+}
+
+// Error implements the error interface.
+func (e *URLError) Error() string {
+	if e.Err != nil {
+		return fmt.Sprintf("invalid request URL %q: %s: %v", e.URL, e.Reason, e.Err)
+	}
+	return fmt.Sprintf("invalid request URL %q: %s", e.URL, e.Reason)
+}
+
+// Unwrap returns the underlying parse error, if any.
+func (e *URLError) Unwrap() error {
+	return e.Err
+}
+
+// Code returns CodeInvalidURL.
+func (e *URLError) Code() Code {
+	return CodeInvalidURL
+}
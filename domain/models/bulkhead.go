@@ -0,0 +1,17 @@
+package models
+
+import "time"
+
+// BulkheadOptions caps the number of requests a client has in flight.
+type BulkheadOptions struct {
+	// MaxConcurrent caps in-flight requests across all hosts. Zero means no limit.
+	MaxConcurrent int
+
+	// MaxPerHost caps in-flight requests to a single host. Zero means no limit.
+	MaxPerHost int
+
+	// MaxWait is how long a request may queue for a free slot. Zero queues
+	// until the request context is done; a negative value rejects excess
+	// requests immediately.
+	MaxWait time.Duration
+}
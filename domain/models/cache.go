@@ -0,0 +1,123 @@
+package models
+
+import (
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// CacheOptions configures the HTTP response cache.
+type CacheOptions struct {
+	// Shared makes the cache behave as a shared cache: responses marked
+	// private are not stored and s-maxage takes precedence over max-age.
+	Shared bool
+
+	// DefaultTTL is the freshness lifetime applied to cacheable responses
+	// without explicit freshness information. Zero disables heuristic caching.
+	DefaultTTL time.Duration
+
+	// StaleWhileRevalidate is how long past expiry a stale entry may still be
+	// served while it is refreshed in the background. The response's own
+	// stale-while-revalidate directive is used if it allows a longer window.
+	StaleWhileRevalidate time.Duration
+
+	// OnRevalidate is called with the outcome of every background refresh.
+	OnRevalidate func(key string, resp *Response, err error)
+}
+
+// NewCacheOptions creates default cache options for a private cache.
+func NewCacheOptions() *CacheOptions {
+	return &CacheOptions{}
+}
+
+// CacheEntry is a stored response together with the metadata needed to
+// compute its freshness and match it against later requests.
+type CacheEntry struct {
+	StatusCode int
+	Headers    http.Header
+	Body       []byte
+
+	// StoredAt is when the response was received or last revalidated.
+	StoredAt time.Time
+
+	// VaryHeaders holds the request header values selected by the
+	// response's Vary header at the time it was stored.
+	VaryHeaders map[string]string
+}
+
+// CacheControl holds parsed Cache-Control directives keyed by lowercase name.
+// Directives without a value map to an empty string.
+type CacheControl map[string]string
+
+// ParseCacheControl parses a Cache-Control header value.
+func ParseCacheControl(value string) CacheControl {
+	cc := CacheControl{}
+	for _, part := range strings.Split(value, ",") {
+		part = strings.TrimSpace(part)
+		if part == "" {
+			continue
+		}
+
+		name, arg, _ := strings.Cut(part, "=")
+		cc[strings.ToLower(strings.TrimSpace(name))] = strings.Trim(strings.TrimSpace(arg), `"`)
+	}
+	return cc
+}
+
+// Has reports whether the directive is present.
+func (cc CacheControl) Has(directive string) bool {
+	_, ok := cc[directive]
+	return ok
+}
+
+// Duration returns a delta-seconds directive such as max-age as a duration.
+func (cc CacheControl) Duration(directive string) (time.Duration, bool) {
+	value, ok := cc[directive]
+	if !ok {
+		return 0, false
+	}
+
+	seconds, err := strconv.ParseInt(value, 10, 64)
+	if err != nil || seconds < 0 {
+		return 0, false
+	}
+	return time.Duration(seconds) * time.Second, true
+}
+
+// CacheDirective is a request Cache-Control directive, passed to
+// WithCacheControl to choose how a single request uses the response cache.
+type CacheDirective string
+
+const (
+	// CacheNoCache revalidates a stored response with the server before
+	// using it.
+	CacheNoCache CacheDirective = "no-cache"
+
+	// CacheNoStore bypasses the cache: no response is read from or written
+	// to it.
+	CacheNoStore CacheDirective = "no-store"
+
+	// CacheOnlyIfCached serves a stored response without contacting the
+	// server, failing with 504 Gateway Timeout if none is usable.
+	CacheOnlyIfCached CacheDirective = "only-if-cached"
+)
+
+// CacheMaxStale accepts a stored response up to d past its freshness
+// lifetime. A negative d accepts stale responses of any age.
+func CacheMaxStale(d time.Duration) CacheDirective {
+	if d < 0 {
+		return "max-stale"
+	}
+	return CacheDirective("max-stale=" + strconv.Itoa(int(d.Seconds())))
+}
+
+// CacheMaxAge rejects stored responses older than d.
+func CacheMaxAge(d time.Duration) CacheDirective {
+	return CacheDirective("max-age=" + strconv.Itoa(int(d.Seconds())))
+}
+
+// CacheMinFresh rejects stored responses that will be stale within d.
+func CacheMinFresh(d time.Duration) CacheDirective {
+	return CacheDirective("min-fresh=" + strconv.Itoa(int(d.Seconds())))
+}
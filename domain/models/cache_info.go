@@ -0,0 +1,113 @@
+package models
+
+import (
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// CacheInfo holds the caching metadata of a response, from standard
+// headers and the cache status headers of common CDNs.
+type CacheInfo struct {
+	CacheControl CacheControl
+
+	// Age is how long the response sat in caches before it was received.
+	Age time.Duration
+
+	// Date is when the origin generated the response. Zero if unknown.
+	Date time.Time
+
+	// Expires is when the response becomes stale, per the Expires header.
+	// Zero if absent; invalid values are in the past, as RFC 9111 requires.
+	Expires time.Time
+
+	// CDNStatus is the normalized cache status reported by a CDN, e.g.
+	// "HIT", "MISS", "EXPIRED" or "STALE", taken from CF-Cache-Status,
+	// X-Cache or X-Cache-Status. Empty if none was sent.
+	CDNStatus string
+}
+
+// ParseCacheInfo extracts the caching metadata from headers.
+func ParseCacheInfo(headers http.Header) *CacheInfo {
+	info := &CacheInfo{CacheControl: ParseCacheControl(headers.Get("Cache-Control"))}
+
+	if seconds, err := strconv.ParseInt(strings.TrimSpace(headers.Get("Age")), 10, 64); err == nil && seconds > 0 {
+		info.Age = time.Duration(seconds) * time.Second
+	}
+	if date, err := http.ParseTime(headers.Get("Date")); err == nil {
+		info.Date = date
+	}
+	if value := headers.Get("Expires"); value != "" {
+		info.Expires = time.Unix(0, 0)
+		if expires, err := http.ParseTime(value); err == nil {
+			info.Expires = expires
+		}
+	}
+
+	info.CDNStatus = cdnCacheStatus(headers)
+	return info
+}
+
+// FreshnessLifetime returns how long the response stays fresh after Date
+// (RFC 9111 section 4.2.1): s-maxage for shared caches, then max-age, then
+// Expires. It reports false when the response doesn't say.
+func (i *CacheInfo) FreshnessLifetime(shared bool) (time.Duration, bool) {
+	if shared {
+		if lifetime, ok := i.CacheControl.Duration("s-maxage"); ok {
+			return lifetime, true
+		}
+	}
+	if lifetime, ok := i.CacheControl.Duration("max-age"); ok {
+		return lifetime, true
+	}
+	if !i.Expires.IsZero() && !i.Date.IsZero() {
+		return max(i.Expires.Sub(i.Date), 0), true
+	}
+	return 0, false
+}
+
+// TTL returns how much longer the response stays fresh for a private
+// cache, accounting for its Age. It is zero or negative once stale and
+// false when the response doesn't say.
+func (i *CacheInfo) TTL() (time.Duration, bool) {
+	lifetime, ok := i.FreshnessLifetime(false)
+	if !ok {
+		return 0, false
+	}
+	return lifetime - i.Age, true
+}
+
+// CDNHit reports whether a CDN served the response from its cache.
+func (i *CacheInfo) CDNHit() bool {
+	switch i.CDNStatus {
+	case "HIT", "STALE", "UPDATING", "REVALIDATED":
+		return true
+	}
+	return strings.Contains(i.CDNStatus, "HIT")
+}
+
+// cdnCacheStatus normalizes CDN cache status headers. X-Cache may list one
+// status per cache tier, e.g. "MISS, HIT", or use forms such as "Hit from
+// cloudfront" and "TCP_HIT"; the last tier, closest to the client, wins.
+func cdnCacheStatus(headers http.Header) string {
+	for _, name := range []string{"CF-Cache-Status", "X-Cache", "X-Cache-Status"} {
+		value := headers.Get(name)
+		if value == "" {
+			continue
+		}
+
+		tiers := strings.Split(value, ",")
+		status := strings.Fields(tiers[len(tiers)-1])
+		if len(status) == 0 {
+			continue
+		}
+		return strings.TrimPrefix(strings.ToUpper(status[0]), "TCP_")
+	}
+	return ""
+}
+
+// CacheInfo returns the caching metadata of the response.
+func (r *Response) CacheInfo() *CacheInfo {
+	return ParseCacheInfo(r.Headers)
+}
@@ -0,0 +1,32 @@
+package models
+
+import "time"
+
+// TargetStats holds request counters for a single routing target.
+type TargetStats struct {
+	Requests     int64
+	Errors       int64
+	TotalLatency time.Duration
+}
+
+// AverageLatency returns the mean latency of requests sent to the target.
+func (s TargetStats) AverageLatency() time.Duration {
+	if s.Requests == 0 {
+		return 0
+	}
+	return s.TotalLatency / time.Duration(s.Requests)
+}
+
+// ErrorRate returns the fraction of requests to the target that failed.
+func (s TargetStats) ErrorRate() float64 {
+	if s.Requests == 0 {
+		return 0
+	}
+	return float64(s.Errors) / float64(s.Requests)
+}
+
+// CanaryStats compares the primary and canary targets during a rollout.
+type CanaryStats struct {
+	Primary TargetStats
+	Canary  TargetStats
+}
@@ -0,0 +1,92 @@
+package models
+
+import (
+	"net"
+	"path"
+	"strings"
+)
+
+// CaptureFilter scopes capture features such as the request journal and
+// debug logging to matching requests. Host patterns are matched with
+// path.Match against the host with and without port, e.g.
+// "*.example.com". Path patterns are matched with path.Match against the
+// URL path; a trailing "/**" also matches everything below the prefix,
+// e.g. "/admin/**". Empty lists don't restrict; excludes win over includes.
+type CaptureFilter struct {
+	IncludeHosts []string
+	ExcludeHosts []string
+	IncludePaths []string
+	ExcludePaths []string
+
+	// StatusClasses limits capture to responses of the given classes,
+	// e.g. []int{4, 5} for 4xx and 5xx. Requests that failed without a
+	// response always pass.
+	StatusClasses []int
+}
+
+// Match reports whether a request to host and urlPath that got status
+// (zero if none) should be captured. A nil filter matches everything.
+func (f *CaptureFilter) Match(host, urlPath string, status int) bool {
+	if f == nil {
+		return true
+	}
+
+	hostname := host
+	if h, _, err := net.SplitHostPort(host); err == nil {
+		hostname = h
+	}
+	matchHost := func(pattern string) bool {
+		return globMatch(pattern, host) || globMatch(pattern, hostname)
+	}
+
+	if anyMatch(f.ExcludeHosts, matchHost) || anyMatch(f.ExcludePaths, func(p string) bool { return pathGlobMatch(p, urlPath) }) {
+		return false
+	}
+	if len(f.IncludeHosts) > 0 && !anyMatch(f.IncludeHosts, matchHost) {
+		return false
+	}
+	if len(f.IncludePaths) > 0 && !anyMatch(f.IncludePaths, func(p string) bool { return pathGlobMatch(p, urlPath) }) {
+		return false
+	}
+
+	if status == 0 || len(f.StatusClasses) == 0 {
+		return true
+	}
+	for _, class := range f.StatusClasses {
+		if status/100 == class {
+			return true
+		}
+	}
+	return false
+}
+
+// anyMatch reports whether match holds for any of patterns.
+func anyMatch(patterns []string, match func(string) bool) bool {
+	for _, pattern := range patterns {
+		if match(pattern) {
+			return true
+		}
+	}
+	return false
+}
+
+// globMatch is path.Match without the error.
+func globMatch(pattern, name string) bool {
+	matched, _ := path.Match(pattern, name)
+	return matched
+}
+
+// pathGlobMatch matches a URL path, treating a trailing "/**" as "this
+// path and everything below it".
+func pathGlobMatch(pattern, urlPath string) bool {
+	prefix, ok := strings.CutSuffix(pattern, "/**")
+	if !ok {
+		return globMatch(pattern, urlPath)
+	}
+	for i := 1; i <= len(urlPath); i++ {
+		if (i == len(urlPath) || urlPath[i] == '/') && globMatch(prefix, urlPath[:i]) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,74 @@
+package models
+
+import (
+	"encoding/json"
+	"time"
+)
+
+// CollectionEventType is the kind of change SyncCollection reports.
+type CollectionEventType string
+
+const (
+	// CollectionItemAdded means the item is new to the local snapshot.
+	CollectionItemAdded CollectionEventType = "added"
+	// CollectionItemUpdated means the item changed on the server.
+	CollectionItemUpdated CollectionEventType = "updated"
+	// CollectionItemDeleted means the item was removed on the server.
+	CollectionItemDeleted CollectionEventType = "deleted"
+)
+
+// CollectionEvent is one change to a synced collection.
+type CollectionEvent struct {
+	Type CollectionEventType
+	ID   string
+
+	// Item is the JSON of the item as now stored. It is the last known
+	// version for deletions.
+	Item json.RawMessage
+}
+
+// CollectionSnapshot is the local copy of a collection kept by
+// SyncCollection, with what it needs to ask the server for changes only.
+type CollectionSnapshot struct {
+	// Items holds the compacted JSON of every item by ID.
+	Items map[string]json.RawMessage `json:"items"`
+
+	// ETag validates the last full listing.
+	ETag string `json:"etag,omitempty"`
+
+	// DeltaToken asks a delta endpoint for changes since the last sync.
+	DeltaToken string `json:"deltaToken,omitempty"`
+
+	SyncedAt time.Time `json:"syncedAt"`
+}
+
+// SyncOptions describes the shape of a collection endpoint.
+type SyncOptions struct {
+	// ItemsField names the array of items in an envelope object, e.g.
+	// "items". Empty means the body is the array itself.
+	ItemsField string
+
+	// IDField names the identifying field of an item.
+	IDField string
+
+	// DeltaParam is the query parameter sending the delta token, e.g.
+	// "syncToken". Empty disables delta requests.
+	DeltaParam string
+
+	// DeltaTokenField names the envelope field carrying the token for the
+	// next delta request, e.g. "nextSyncToken".
+	DeltaTokenField string
+
+	// DeletedField marks removed items in delta responses, e.g.
+	// "deleted" or "@removed". Any value but false or null counts.
+	DeletedField string
+}
+
+// NewSyncOptions creates sync options for a plain JSON array of items
+// identified by "id".
+func NewSyncOptions() *SyncOptions {
+	return &SyncOptions{
+		IDField:      "id",
+		DeletedField: "deleted",
+	}
+}
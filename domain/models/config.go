@@ -1,15 +1,65 @@
 package models
 
-import "time"
+import (
+	"io"
+	"net/http/httptrace"
+	"time"
+
+	"github.com/fourth-ally/gofetch/domain/errors"
+)
 
 // Config represents the configuration for the HTTP client.
 // This is the domain model for client configuration.
 type Config struct {
 	BaseURL         string
+	BaseURLs        []string
 	Timeout         time.Duration
 	Headers         map[string]string
 	StatusValidator func(int) bool
 	RetryOptions    *RetryOptions
+	HedgingOptions  *HedgingOptions
+
+	// AcceptEncoding overrides the Accept-Encoding header. When empty the
+	// transport negotiates gzip and decompresses transparently.
+	AcceptEncoding string
+
+	// Priority orders the request when the concurrency limit is reached.
+	// Nil uses PriorityNormal; a set priority, even PriorityNormal,
+	// overrides the one of the config it is merged into.
+	Priority *Priority
+
+	// ContentType overrides the media type set for encoded request bodies,
+	// e.g. a vendor type such as "application/vnd.company+json".
+	ContentType string
+
+	// BodyTee receives a copy of the response body as it is read, for
+	// responses accepted by the status validator.
+	BodyTee []io.Writer
+
+	// RawResponse hands accepted response bodies to the caller unread as
+	// Response.Body instead of buffering and decoding them.
+	RawResponse bool
+
+	// ClientTrace receives net/http/httptrace callbacks for the request,
+	// alongside the client's own timing collection.
+	ClientTrace *httptrace.ClientTrace
+
+	// ErrorTarget receives the decoded body of a response rejected by the
+	// status validator, which is then attached to the HTTPError.
+	ErrorTarget interface{}
+
+	// SampleRate is the fraction of requests, from 0 to 1, for which
+	// observability features run: debug logging, the request journal and
+	// ClientTrace hooks. Nil samples every request.
+	SampleRate *float64
+
+	// HostOverride replaces the Host header, and the authority of HTTP/2
+	// requests, while the connection still goes to the URL's host.
+	HostOverride string
+
+	// ServerName is the TLS server name (SNI) presented and verified for
+	// the request instead of the URL's host.
+	ServerName string
 }
 
 // NewConfig creates a new Config with default values.
@@ -40,15 +90,42 @@ func (c *Config) Clone() *Config {
 			retryOptsCopy.RetryOnStatusCodes = make([]int, len(c.RetryOptions.RetryOnStatusCodes))
 			copy(retryOptsCopy.RetryOnStatusCodes, c.RetryOptions.RetryOnStatusCodes)
 		}
+		if len(c.RetryOptions.RetryOnTransportErrors) > 0 {
+			retryOptsCopy.RetryOnTransportErrors = append([]errors.TransportErrorKind(nil), c.RetryOptions.RetryOnTransportErrors...)
+		}
 		retryOpts = &retryOptsCopy
 	}
 
+	var hedgingOpts *HedgingOptions
+	if c.HedgingOptions != nil {
+		hedgingOptsCopy := *c.HedgingOptions
+		hedgingOpts = &hedgingOptsCopy
+	}
+
+	var baseURLs []string
+	if len(c.BaseURLs) > 0 {
+		baseURLs = make([]string, len(c.BaseURLs))
+		copy(baseURLs, c.BaseURLs)
+	}
+
 	return &Config{
 		BaseURL:         c.BaseURL,
+		BaseURLs:        baseURLs,
 		Timeout:         c.Timeout,
 		Headers:         headers,
 		StatusValidator: c.StatusValidator,
 		RetryOptions:    retryOpts,
+		HedgingOptions:  hedgingOpts,
+		AcceptEncoding:  c.AcceptEncoding,
+		Priority:        c.Priority,
+		ContentType:     c.ContentType,
+		BodyTee:         append([]io.Writer(nil), c.BodyTee...),
+		RawResponse:     c.RawResponse,
+		ClientTrace:     c.ClientTrace,
+		ErrorTarget:     c.ErrorTarget,
+		SampleRate:      c.SampleRate,
+		HostOverride:    c.HostOverride,
+		ServerName:      c.ServerName,
 	}
 }
 
@@ -60,6 +137,10 @@ func (c *Config) Merge(other *Config) *Config {
 		merged.BaseURL = other.BaseURL
 	}
 
+	if len(other.BaseURLs) > 0 {
+		merged.BaseURLs = other.BaseURLs
+	}
+
 	if other.Timeout != 0 {
 		merged.Timeout = other.Timeout
 	}
@@ -76,5 +157,49 @@ func (c *Config) Merge(other *Config) *Config {
 		merged.RetryOptions = other.RetryOptions
 	}
 
+	if other.HedgingOptions != nil {
+		merged.HedgingOptions = other.HedgingOptions
+	}
+
+	if other.AcceptEncoding != "" {
+		merged.AcceptEncoding = other.AcceptEncoding
+	}
+
+	if other.Priority != nil {
+		merged.Priority = other.Priority
+	}
+
+	if other.ContentType != "" {
+		merged.ContentType = other.ContentType
+	}
+
+	if len(other.BodyTee) > 0 {
+		merged.BodyTee = other.BodyTee
+	}
+
+	if other.RawResponse {
+		merged.RawResponse = true
+	}
+
+	if other.ClientTrace != nil {
+		merged.ClientTrace = other.ClientTrace
+	}
+
+	if other.ErrorTarget != nil {
+		merged.ErrorTarget = other.ErrorTarget
+	}
+
+	if other.SampleRate != nil {
+		merged.SampleRate = other.SampleRate
+	}
+
+	if other.HostOverride != "" {
+		merged.HostOverride = other.HostOverride
+	}
+
+	if other.ServerName != "" {
+		merged.ServerName = other.ServerName
+	}
+
 	return merged
 }
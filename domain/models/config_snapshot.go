@@ -0,0 +1,163 @@
+package models
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+)
+
+// redactedValue replaces secret values in snapshots.
+const redactedValue = "[REDACTED]"
+
+// ConfigSnapshot is a point-in-time view of the settings a request would
+// use, after merging client defaults with per-request overrides. Secret
+// header values are redacted, so snapshots are safe to log.
+type ConfigSnapshot struct {
+	BaseURL  string
+	BaseURLs []string
+	Headers  map[string]string
+
+	Timeout               time.Duration
+	DialTimeout           time.Duration
+	TLSHandshakeTimeout   time.Duration
+	ResponseHeaderTimeout time.Duration
+	IdleReadTimeout       time.Duration
+
+	RetryOptions   *RetryOptions
+	HedgingOptions *HedgingOptions
+	AcceptEncoding string
+	ContentType    string
+	Priority       Priority
+	MinTLSVersion  uint16
+
+	// Features lists the optional subsystems enabled on the client, e.g.
+	// "cache" or "rate-limit", in alphabetical order.
+	Features []string
+
+	// Overrides lists the settings changed by per-request options.
+	Overrides []string
+}
+
+// String renders the snapshot as a human-readable, multi-line dump.
+func (s ConfigSnapshot) String() string {
+	var b strings.Builder
+	for _, line := range s.lines() {
+		b.WriteString(line)
+		b.WriteByte('\n')
+	}
+	return b.String()
+}
+
+// Diff lists the settings that differ between s and other, one line per
+// setting in the form "name: old -> new".
+func (s ConfigSnapshot) Diff(other ConfigSnapshot) []string {
+	before := s.fields()
+	after := other.fields()
+
+	var diff []string
+	for _, name := range snapshotFieldOrder(before, after) {
+		if before[name] != after[name] {
+			diff = append(diff, fmt.Sprintf("%s: %s -> %s", name, before[name], after[name]))
+		}
+	}
+	return diff
+}
+
+// lines renders each setting as "name: value".
+func (s ConfigSnapshot) lines() []string {
+	fields := s.fields()
+
+	lines := make([]string, 0, len(fields)+1)
+	for _, name := range snapshotFieldOrder(fields, nil) {
+		lines = append(lines, name+": "+fields[name])
+	}
+	if len(s.Overrides) > 0 {
+		lines = append(lines, "overrides: "+strings.Join(s.Overrides, ", "))
+	}
+	return lines
+}
+
+// fields flattens the snapshot into named, formatted values.
+func (s ConfigSnapshot) fields() map[string]string {
+	fields := map[string]string{
+		"baseURL":               s.BaseURL,
+		"baseURLs":              strings.Join(s.BaseURLs, ", "),
+		"timeout":               s.Timeout.String(),
+		"dialTimeout":           s.DialTimeout.String(),
+		"tlsHandshakeTimeout":   s.TLSHandshakeTimeout.String(),
+		"responseHeaderTimeout": s.ResponseHeaderTimeout.String(),
+		"idleReadTimeout":       s.IdleReadTimeout.String(),
+		"acceptEncoding":        s.AcceptEncoding,
+		"contentType":           s.ContentType,
+		"priority":              fmt.Sprint(int(s.Priority)),
+		"minTLSVersion":         fmt.Sprintf("0x%04x", s.MinTLSVersion),
+		"features":              strings.Join(s.Features, ", "),
+		"retry":                 "off",
+		"hedging":               "off",
+	}
+
+	if r := s.RetryOptions; r != nil {
+		fields["retry"] = fmt.Sprintf("maxRetries=%d backoff=%s initialDelay=%v maxDelay=%v",
+			r.MaxRetries, r.Backoff, r.InitialDelay, r.MaxDelay)
+	}
+
+	if h := s.HedgingOptions; h != nil {
+		fields["hedging"] = fmt.Sprintf("delay=%v maxHedges=%d", h.Delay, h.MaxHedges)
+	}
+
+	for key, value := range s.Headers {
+		fields["header "+key] = value
+	}
+
+	return fields
+}
+
+// snapshotFieldOrder returns the union of field names in a stable order.
+func snapshotFieldOrder(a, b map[string]string) []string {
+	seen := make(map[string]bool, len(a)+len(b))
+	var names []string
+	for _, fields := range []map[string]string{a, b} {
+		for name := range fields {
+			if !seen[name] {
+				seen[name] = true
+				names = append(names, name)
+			}
+		}
+	}
+	sort.Strings(names)
+	return names
+}
+
+// RedactHeaders returns a copy of headers with credential values replaced.
+func RedactHeaders(headers map[string]string) map[string]string {
+	redacted := make(map[string]string, len(headers))
+	for key, value := range headers {
+		if isSecretHeader(key) {
+			value = redactedValue
+		}
+		redacted[key] = value
+	}
+	return redacted
+}
+
+// IsRedacted reports whether value is a placeholder set by RedactHeaders.
+func IsRedacted(value string) bool {
+	return value == redactedValue
+}
+
+// isSecretHeader reports whether a header name suggests a credential.
+func isSecretHeader(name string) bool {
+	lower := strings.ToLower(name)
+	switch lower {
+	case "authorization", "proxy-authorization", "cookie", "set-cookie":
+		return true
+	}
+
+	for _, marker := range []string{"token", "secret", "password", "api-key", "apikey", "signature"} {
+		if strings.Contains(lower, marker) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,39 @@
+package models
+
+import "time"
+
+// CrawlOptions configures polite crawling: spacing and concurrency limits
+// per host and robots.txt compliance.
+type CrawlOptions struct {
+	// UserAgent identifies the crawler. It is sent as the User-Agent
+	// header and its product token (the part before any "/") selects the
+	// robots.txt group that applies. Empty selects the "*" group only.
+	UserAgent string
+
+	// Delay is the minimum time between the starts of two requests to
+	// the same host. A larger robots.txt Crawl-delay takes precedence.
+	Delay time.Duration
+
+	// MaxPerHost caps concurrent requests per host. Zero means no cap.
+	MaxPerHost int
+
+	// RespectRobots fetches each host's robots.txt and rejects requests
+	// to disallowed paths with *errors.RobotsDisallowedError.
+	RespectRobots bool
+
+	// RobotsTTL is how long a fetched robots.txt is cached per host.
+	// Non-positive values use 24 hours.
+	RobotsTTL time.Duration
+}
+
+// NewCrawlOptions creates default crawl options: one request at a time
+// per host, one second apart, honoring robots.txt.
+func NewCrawlOptions(userAgent string) *CrawlOptions {
+	return &CrawlOptions{
+		UserAgent:     userAgent,
+		Delay:         time.Second,
+		MaxPerHost:    1,
+		RespectRobots: true,
+		RobotsTTL:     24 * time.Hour,
+	}
+}
@@ -0,0 +1,13 @@
+package models
+
+// DigestAlgorithm identifies a request body hash sent to the server.
+type DigestAlgorithm string
+
+const (
+	// DigestMD5 sets the legacy Content-MD5 header.
+	DigestMD5 DigestAlgorithm = "md5"
+	// DigestSHA256 adds sha-256 to the RFC 9530 Content-Digest header.
+	DigestSHA256 DigestAlgorithm = "sha-256"
+	// DigestSHA512 adds sha-512 to the RFC 9530 Content-Digest header.
+	DigestSHA512 DigestAlgorithm = "sha-512"
+)
@@ -0,0 +1,30 @@
+package models
+
+import "encoding/json"
+
+// DockerMessage is one message of a Docker Engine JSON progress stream,
+// as sent while pulling or pushing images and building.
+type DockerMessage struct {
+	Status         string               `json:"status,omitempty"`
+	ID             string               `json:"id,omitempty"`
+	Progress       string               `json:"progress,omitempty"`
+	ProgressDetail DockerProgressDetail `json:"progressDetail"`
+	Stream         string               `json:"stream,omitempty"`
+	Error          string               `json:"error,omitempty"`
+	ErrorDetail    *DockerErrorDetail   `json:"errorDetail,omitempty"`
+
+	// Aux carries operation-specific results, e.g. the ID of a built image.
+	Aux json.RawMessage `json:"aux,omitempty"`
+}
+
+// DockerProgressDetail reports the progress of a single layer or step.
+type DockerProgressDetail struct {
+	Current int64 `json:"current,omitempty"`
+	Total   int64 `json:"total,omitempty"`
+}
+
+// DockerErrorDetail describes an error reported inside a progress stream.
+type DockerErrorDetail struct {
+	Code    int    `json:"code,omitempty"`
+	Message string `json:"message"`
+}
@@ -0,0 +1,52 @@
+package models
+
+import "os"
+
+// DownloadFileOptions configures DownloadFile.
+type DownloadFileOptions struct {
+	// UseContentDisposition treats the destination as a directory and
+	// names the file after the Content-Disposition filename of the
+	// response, falling back to the last segment of the URL path.
+	UseContentDisposition bool
+
+	// Resume keeps the partial file of a failed download, as the
+	// destination path plus ".part", and continues it with a Range request
+	// on the next call instead of starting over. The server must send a
+	// strong ETag or Last-Modified date, which is sent back as If-Range
+	// so a changed file is downloaded again in full.
+	Resume bool
+
+	// Parallel splits the download into that many byte ranges fetched
+	// concurrently, when the server supports Range requests; otherwise
+	// the body is downloaded in one stream. Checksums are then verified
+	// by reading the assembled file back. Resume does not apply.
+	Parallel int
+
+	// Perm is the permission of the downloaded file. Zero uses 0644.
+	Perm os.FileMode
+
+	// Checksum is the expected hash of the body. A mismatch fails the
+	// download with *errors.ChecksumMismatchError.
+	Checksum *Checksum
+
+	// VerifyDigest checks the body against the digests the server sent
+	// in Content-Digest, Digest or Content-MD5 headers, if any. Bodies
+	// transparently decompressed by the transport can't be checked.
+	VerifyDigest bool
+}
+
+// Checksum is an expected content hash.
+type Checksum struct {
+	// Algorithm is DigestSHA256, DigestSHA512 or DigestMD5.
+	Algorithm DigestAlgorithm
+
+	// Value is the hex-encoded hash, as printed by sha256sum.
+	Value string
+}
+
+// NewDownloadFileOptions creates default download file options.
+func NewDownloadFileOptions() *DownloadFileOptions {
+	return &DownloadFileOptions{
+		Perm: 0o644,
+	}
+}
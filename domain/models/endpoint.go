@@ -0,0 +1,54 @@
+package models
+
+import "time"
+
+// BalanceStrategy defines how requests are spread across an endpoint pool.
+type BalanceStrategy string
+
+const (
+	// BalanceRoundRobin cycles through healthy endpoints in order.
+	BalanceRoundRobin BalanceStrategy = "round-robin"
+	// BalanceWeightedRandom picks healthy endpoints randomly in proportion to their weight.
+	BalanceWeightedRandom BalanceStrategy = "weighted-random"
+)
+
+// Endpoint is an upstream host in an endpoint pool.
+type Endpoint struct {
+	// URL is the base URL of the endpoint.
+	URL string
+
+	// Weight is the relative share of traffic for weighted strategies.
+	// Non-positive weights are treated as 1.
+	Weight int
+}
+
+// EndpointPoolOptions configures client-side load balancing.
+type EndpointPoolOptions struct {
+	// Strategy selects the balancing algorithm.
+	Strategy BalanceStrategy
+
+	// FailureThreshold is the number of consecutive failures after which
+	// an endpoint is marked down.
+	FailureThreshold int
+
+	// Cooldown is how long a failed endpoint stays down before it is tried again.
+	Cooldown time.Duration
+}
+
+// NewEndpointPoolOptions creates default endpoint pool options.
+func NewEndpointPoolOptions() *EndpointPoolOptions {
+	return &EndpointPoolOptions{
+		Strategy:         BalanceRoundRobin,
+		FailureThreshold: 3,
+		Cooldown:         10 * time.Second,
+	}
+}
+
+// EndpointStatus reports the health and traffic of a pool endpoint.
+type EndpointStatus struct {
+	URL      string
+	Weight   int
+	Healthy  bool
+	Requests int64
+	Failures int64
+}
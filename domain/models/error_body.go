@@ -0,0 +1,22 @@
+package models
+
+// ErrorBodyOptions limits how much of an error response body is kept.
+type ErrorBodyOptions struct {
+	// MaxBytes caps the body stored in HTTPError.Body. Non-positive values
+	// use the default of 64 KiB.
+	MaxBytes int64
+
+	// SpillToFile writes the complete body of truncated error responses
+	// to a temporary file, reported in HTTPError.BodyFile.
+	SpillToFile bool
+
+	// SpillDir is the directory for spilled bodies. Empty uses os.TempDir.
+	SpillDir string
+}
+
+// NewErrorBodyOptions creates default error body options.
+func NewErrorBodyOptions() *ErrorBodyOptions {
+	return &ErrorBodyOptions{
+		MaxBytes: 64 << 10,
+	}
+}
@@ -0,0 +1,21 @@
+package models
+
+import "time"
+
+// FaultOptions configures artificial faults injected into requests for
+// resilience testing. Each rate is a probability between 0 and 1 evaluated
+// independently per request.
+type FaultOptions struct {
+	// LatencyRate is the probability of delaying a request by Latency.
+	LatencyRate float64
+	Latency     time.Duration
+
+	// DropRate is the probability of failing a request as if the
+	// connection had been dropped, without contacting the server.
+	DropRate float64
+
+	// ErrorRate is the probability of answering a request with ErrorStatus
+	// instead of contacting the server. Default status is 503.
+	ErrorRate   float64
+	ErrorStatus int
+}
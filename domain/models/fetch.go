@@ -0,0 +1,31 @@
+package models
+
+// FetchOptions controls the browser Fetch API when running as WebAssembly.
+// Empty fields leave the browser default in place. On other platforms only
+// SimpleHeadersOnly and ValidateCORS have an effect.
+type FetchOptions struct {
+	// Mode is the request mode: "cors", "no-cors" or "same-origin".
+	Mode string
+
+	// Credentials controls cookies: "omit", "same-origin" or "include".
+	Credentials string
+
+	// Cache is the HTTP cache mode, e.g. "default", "no-store", "reload",
+	// "no-cache", "force-cache" or "only-if-cached".
+	Cache string
+
+	// Redirect controls redirect handling: "follow", "error" or "manual".
+	Redirect string
+
+	// SimpleHeadersOnly drops headers that are not CORS-safelisted and
+	// downgrades non-simple Content-Types to text/plain, so cross-origin
+	// requests qualify as simple requests and skip the preflight.
+	SimpleHeadersOnly bool
+
+	// ValidateCORS checks cross-origin requests against the CORS
+	// simple-request rules before sending them and fails with
+	// *errors.CORSError naming what would trigger a preflight, instead of
+	// the browser's opaque network error. Outside the browser every
+	// request counts as cross-origin.
+	ValidateCORS bool
+}
@@ -0,0 +1,13 @@
+package models
+
+// FileBody is a request body read from a file when the request is sent,
+// so uploads don't have to be loaded into memory. The file size is sent
+// as Content-Length and reported as the upload progress total, and the
+// file is reopened for retries and redirects.
+type FileBody struct {
+	Path string
+
+	// ContentType overrides the type derived from the file extension,
+	// which falls back to application/octet-stream.
+	ContentType string
+}
@@ -0,0 +1,28 @@
+package models
+
+import "time"
+
+// HealthCheckOptions configures active health checking of base URLs.
+type HealthCheckOptions struct {
+	// Path is probed with a GET request on each base URL. A 2xx or 3xx
+	// response marks the endpoint up; anything else marks it down.
+	Path string
+
+	// Interval is the time between probe rounds.
+	Interval time.Duration
+
+	// Timeout bounds each individual probe.
+	Timeout time.Duration
+
+	// OnStateChange is called when an endpoint transitions between up and down.
+	OnStateChange func(baseURL string, healthy bool)
+}
+
+// NewHealthCheckOptions creates default health check options.
+func NewHealthCheckOptions() *HealthCheckOptions {
+	return &HealthCheckOptions{
+		Path:     "/health",
+		Interval: 10 * time.Second,
+		Timeout:  2 * time.Second,
+	}
+}
@@ -0,0 +1,23 @@
+package models
+
+import "time"
+
+// HedgingOptions configures hedged requests for idempotent methods.
+// When enabled, the client fires an additional request if the previous
+// one has not completed within Delay, and returns whichever succeeds first.
+type HedgingOptions struct {
+	// Delay is how long to wait for an in-flight request before firing a hedge.
+	Delay time.Duration
+
+	// MaxHedges is the maximum number of additional requests per call.
+	// Default is 1.
+	MaxHedges int
+}
+
+// NewHedgingOptions creates default hedging options.
+func NewHedgingOptions() *HedgingOptions {
+	return &HedgingOptions{
+		Delay:     100 * time.Millisecond,
+		MaxHedges: 1,
+	}
+}
@@ -0,0 +1,62 @@
+package models
+
+import (
+	"context"
+	"encoding/json"
+)
+
+// Kubernetes watch event types.
+const (
+	KubeEventAdded    = "ADDED"
+	KubeEventModified = "MODIFIED"
+	KubeEventDeleted  = "DELETED"
+	KubeEventBookmark = "BOOKMARK"
+	KubeEventError    = "ERROR"
+)
+
+// KubeWatchEvent is one event of a Kubernetes watch stream.
+type KubeWatchEvent struct {
+	// Type is the event type, e.g. KubeEventAdded.
+	Type string `json:"type"`
+
+	// Object is the raw JSON of the object the event applies to.
+	Object json.RawMessage `json:"object"`
+}
+
+// KubeWatchOptions configures a Kubernetes watch.
+type KubeWatchOptions struct {
+	// LabelSelector restricts the watch to objects with matching labels,
+	// e.g. "app=web,tier!=cache". See FormatSelector for building one.
+	LabelSelector string
+
+	// FieldSelector restricts the watch to objects with matching fields,
+	// e.g. "status.phase=Running".
+	FieldSelector string
+
+	// ResourceVersion is the version to start watching from, typically
+	// taken from a preceding list. Empty starts from the most recent.
+	ResourceVersion string
+
+	// AllowBookmarks asks the server for BOOKMARK events, which advance
+	// the resource version without a change so reconnects stay cheap.
+	// Bookmarks are consumed by the watch and not passed to the handler.
+	AllowBookmarks bool
+
+	// TimeoutSeconds asks the server to end each watch stream after this
+	// many seconds, after which the watch reconnects. Zero uses the
+	// server default.
+	TimeoutSeconds int
+
+	// Relist is called when the resource version has expired (410 Gone).
+	// It should list the resource again, sync the caller's state and
+	// return the list's resource version to resume watching from. When
+	// nil, the watch returns a *errors.ResourceExpiredError instead.
+	Relist func(ctx context.Context) (string, error)
+}
+
+// NewKubeWatchOptions creates default watch options with bookmarks enabled.
+func NewKubeWatchOptions() *KubeWatchOptions {
+	return &KubeWatchOptions{
+		AllowBookmarks: true,
+	}
+}
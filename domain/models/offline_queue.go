@@ -0,0 +1,30 @@
+package models
+
+import "time"
+
+// QueuedRequest is a mutating request held in the offline queue until
+// connectivity returns.
+type QueuedRequest struct {
+	ID         string                 `json:"id"`
+	Method     string                 `json:"method"`
+	Path       string                 `json:"path"`
+	Params     map[string]interface{} `json:"params,omitempty"`
+	Headers    map[string]string      `json:"headers,omitempty"`
+	EnqueuedAt time.Time              `json:"enqueuedAt"`
+
+	// Body is the encoded request body, replayed byte for byte with
+	// ContentType.
+	Body        []byte `json:"body,omitempty"`
+	ContentType string `json:"contentType,omitempty"`
+}
+
+// OfflineQueueOptions configures the offline request queue.
+type OfflineQueueOptions struct {
+	// OnEnqueued is called when a request is queued because the network is down.
+	OnEnqueued func(req *QueuedRequest)
+
+	// OnFlushed is called for every queued request once it has been sent.
+	// err is non-nil when the server rejected it; the request is dropped
+	// from the queue either way.
+	OnFlushed func(req *QueuedRequest, resp *Response, err error)
+}
@@ -0,0 +1,8 @@
+package models
+
+// PipeResult is the outcome of a request whose body is written through a
+// pipe, delivered once the request completes.
+type PipeResult struct {
+	Response *Response
+	Err      error
+}
@@ -0,0 +1,20 @@
+package models
+
+import "net/http"
+
+// RequestPlan describes a request exactly as it would be sent, without
+// sending it.
+type RequestPlan struct {
+	Method string
+	URL    string
+
+	// Headers are the final request headers, after interceptors and
+	// signing. Unlike Config, they are not redacted.
+	Headers http.Header
+
+	// Body is the encoded request body, nil for requests without one.
+	Body []byte
+
+	// Config is the effective configuration the request would use.
+	Config ConfigSnapshot
+}
@@ -0,0 +1,34 @@
+package models
+
+import (
+	"log/slog"
+	"time"
+)
+
+// Policy bundles client settings so an organization can standardize
+// behavior across services. Zero-valued fields are left unchanged when the
+// policy is applied.
+type Policy struct {
+	// Name identifies the policy in logs and diagnostics.
+	Name string
+
+	Timeout             time.Duration
+	DialTimeout         time.Duration
+	TLSHandshakeTimeout time.Duration
+
+	// RetryOptions replaces the client's retry configuration.
+	RetryOptions *RetryOptions
+
+	// TLSPolicy replaces the client's TLS versions, cipher suites and curves.
+	TLSPolicy *TLSPolicy
+
+	// MinTLSVersion is the minimum accepted TLS version, e.g. tls.VersionTLS13.
+	// It takes precedence over TLSPolicy.MinVersion.
+	MinTLSVersion uint16
+
+	// Logger receives the client's debug output.
+	Logger *slog.Logger
+
+	// Headers are added to every request.
+	Headers map[string]string
+}
@@ -0,0 +1,14 @@
+package models
+
+// Priority orders requests waiting for a concurrency slot. Higher
+// priorities are admitted first; equal priorities are served in FIFO order.
+type Priority int
+
+const (
+	// PriorityLow is for background traffic that may wait.
+	PriorityLow Priority = -1
+	// PriorityNormal is the default priority.
+	PriorityNormal Priority = 0
+	// PriorityHigh is for interactive traffic that should jump the queue.
+	PriorityHigh Priority = 1
+)
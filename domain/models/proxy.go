@@ -0,0 +1,24 @@
+package models
+
+import "time"
+
+// ProxyDirect stands for a direct connection in a proxy fallback list.
+const ProxyDirect = "direct"
+
+// ProxyOptions configures proxy failure detection and fallback.
+type ProxyOptions struct {
+	// FailureThreshold is the number of consecutive connection failures
+	// after which a proxy is bypassed.
+	FailureThreshold int
+
+	// Cooldown is how long a failed proxy is bypassed before it is retried.
+	Cooldown time.Duration
+}
+
+// NewProxyOptions creates default proxy options.
+func NewProxyOptions() *ProxyOptions {
+	return &ProxyOptions{
+		FailureThreshold: 3,
+		Cooldown:         30 * time.Second,
+	}
+}
@@ -0,0 +1,16 @@
+package models
+
+// ArrayFormat selects how slice query parameters are encoded.
+type ArrayFormat int
+
+const (
+	// ArrayRepeat repeats the key for every element: tag=a&tag=b.
+	ArrayRepeat ArrayFormat = iota
+
+	// ArrayComma joins the elements with commas: tag=a,b.
+	ArrayComma
+
+	// ArrayBrackets repeats the key with a [] suffix: tag[]=a&tag[]=b, as
+	// expected by PHP and Rails backends.
+	ArrayBrackets
+)
@@ -0,0 +1,10 @@
+package models
+
+// RateLimit describes a token bucket: requests are allowed at
+// RequestsPerSecond on average, with bursts of up to Burst requests.
+type RateLimit struct {
+	RequestsPerSecond float64
+
+	// Burst is the bucket capacity. Values below 1 are treated as 1.
+	Burst int
+}
@@ -0,0 +1,13 @@
+package models
+
+// Redirect describes one hop of a followed redirect chain.
+type Redirect struct {
+	// URL is the URL that answered with the redirect.
+	URL string
+
+	// StatusCode is the redirect status, e.g. 301 or 307.
+	StatusCode int
+
+	// Location is the raw Location header of the redirect response.
+	Location string
+}
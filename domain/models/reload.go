@@ -0,0 +1,33 @@
+package models
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"time"
+)
+
+// ReloadOptions describes settings swapped into a running client by
+// Client.Reload. Zero values leave the current setting unchanged.
+type ReloadOptions struct {
+	// BaseURL replaces the base URL and any failover list.
+	BaseURL string
+
+	// BaseURLs replaces the failover list of base URLs.
+	BaseURLs []string
+
+	// Headers replaces all default headers when non-nil.
+	Headers map[string]string
+
+	// Timeout replaces the overall request timeout.
+	Timeout time.Duration
+
+	// Certificates replaces the client certificates presented to servers.
+	Certificates []tls.Certificate
+
+	// RootCAs replaces the pool of trusted server certificate authorities.
+	RootCAs *x509.CertPool
+
+	// Policy is applied on top of the other settings. Its logger and dial
+	// timeout are ignored; use ApplyPolicy for those.
+	Policy *Policy
+}
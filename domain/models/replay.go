@@ -0,0 +1,82 @@
+package models
+
+import "time"
+
+// RecordedExchange is a request and the response it received, as written
+// to a journal by Client.SetJournal or read from a HAR file.
+type RecordedExchange struct {
+	Method string `json:"method"`
+	URL    string `json:"url"`
+
+	// Headers are the request headers. Credentials are redacted and not
+	// replayed; the replaying client supplies its own.
+	Headers map[string]string `json:"headers,omitempty"`
+	Body    []byte            `json:"body,omitempty"`
+
+	// Status is the recorded status code, or zero if unknown.
+	Status int `json:"status,omitempty"`
+
+	// ResponseBody is the recorded response body, or nil if it was not
+	// captured.
+	ResponseBody []byte `json:"responseBody,omitempty"`
+
+	RecordedAt time.Time `json:"recordedAt"`
+}
+
+// ReplayOptions configures Client.Replay.
+type ReplayOptions struct {
+	// Concurrency is the number of exchanges replayed at once. Values
+	// below 1 replay them one after the other, in order.
+	Concurrency int
+
+	// Normalize prepares a body for comparison (e.g. strips timestamps).
+	// When nil, JSON bodies are compared structurally and other bodies byte-for-byte.
+	Normalize func(body []byte) []byte
+
+	// OnResult is called as each exchange completes. It may be nil.
+	OnResult func(result *ReplayResult)
+}
+
+// NewReplayOptions creates default replay options.
+func NewReplayOptions() *ReplayOptions {
+	return &ReplayOptions{Concurrency: 1}
+}
+
+// ReplayResult is the outcome of replaying one recorded exchange.
+type ReplayResult struct {
+	Exchange *RecordedExchange
+
+	// Status and Body are what the target returned.
+	Status int
+	Body   []byte
+
+	// StatusMismatch reports whether the status differs from the recorded
+	// one. It is false when no status was recorded.
+	StatusMismatch bool
+
+	// BodyMismatch reports whether the normalized bodies differ. It is
+	// false when no response body was recorded.
+	BodyMismatch bool
+
+	// Err is set when the request failed without a response.
+	Err error
+
+	Duration time.Duration
+}
+
+// Mismatch reports whether the replayed response differs from the recording.
+func (r *ReplayResult) Mismatch() bool {
+	return r.StatusMismatch || r.BodyMismatch
+}
+
+// ReplayReport summarizes a replay run.
+type ReplayReport struct {
+	// Results holds one result per exchange, in the order given.
+	Results []ReplayResult
+
+	// Mismatches counts results whose status or body differ.
+	Mismatches int
+
+	// Failures counts requests that failed without a response.
+	Failures int
+}
@@ -1,6 +1,11 @@
 package models
 
-import "net/http"
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"text/template"
+)
 
 // Response represents the HTTP response wrapper that GoFetch returns.
 // This domain model encapsulates all response information.
@@ -9,6 +14,79 @@ type Response struct {
 	Headers    http.Header
 	Data       interface{}
 	RawBody    []byte
+
+	// BaseURL is the base URL of the host that served the response.
+	BaseURL string
+
+	// Hedged reports whether the response was served by a hedge request
+	// rather than the original one.
+	Hedged bool
+
+	// FromCache reports whether the response was served from the response cache.
+	FromCache bool
+
+	// Stale reports whether a cached response was served past its freshness
+	// lifetime while being revalidated in the background.
+	Stale bool
+
+	// Decompressed reports whether the transport transparently decompressed
+	// the body. Headers no longer describe the original encoding and length.
+	Decompressed bool
+
+	// Redirects lists the redirect hops followed before the final response,
+	// in order. It is nil when no redirect was followed.
+	Redirects []Redirect
+
+	// File is the path DownloadFile saved the body to. It is empty for
+	// other requests.
+	File string
+
+	// Timings holds per-phase durations of the request that produced the response.
+	Timings *Timings
+
+	// Body is the unread response body of requests made with the raw
+	// response option. The caller owns it and must close it; RawBody and
+	// Data are empty. It is nil otherwise.
+	Body io.ReadCloser `json:"-"`
+
+	// Decoder unmarshals RawBody for Decode. The client sets it to the
+	// codec registered for the response Content-Type; nil means JSON.
+	Decoder func(data []byte, v interface{}) error `json:"-"`
+}
+
+// Decode unmarshals RawBody into v, typically after the client was told
+// not to decode responses automatically. An empty body leaves v unchanged.
+func (r *Response) Decode(v interface{}) error {
+	if len(r.RawBody) == 0 {
+		return nil
+	}
+
+	decode := r.Decoder
+	if decode == nil {
+		decode = json.Unmarshal
+	}
+	return decode(r.RawBody, v)
+}
+
+// Text returns RawBody as text.
+func (r *Response) Text() Text {
+	return Text(r.RawBody)
+}
+
+// Lines returns the lines of RawBody, as Text.Lines does.
+func (r *Response) Lines() []string {
+	return r.Text().Lines()
+}
+
+// RenderInto executes tmpl with the response as data and writes the
+// result to w, so textual endpoints can feed reports directly. Templates
+// can use .StatusCode, .Headers, .Data, .Text and .Lines:
+//
+//	{{.StatusCode}} from {{.Headers.Get "Server"}}
+//	{{range .Lines}}- {{.}}
+//	{{end}}
+func (r *Response) RenderInto(tmpl *template.Template, w io.Writer) error {
+	return tmpl.Execute(w, r)
 }
 
 // NewResponse creates a new Response instance.
@@ -1,6 +1,11 @@
 package models
 
-import "time"
+import (
+	stderrors "errors"
+	"time"
+
+	"github.com/fourth-ally/gofetch/domain/errors"
+)
 
 // BackoffStrategy defines the strategy for calculating retry delays.
 type BackoffStrategy string
@@ -39,6 +44,17 @@ type RetryOptions struct {
 	// By default, only 5xx errors are retried.
 	RetryOnStatusCodes []int
 
+	// RetryOnTransportErrors limits retries of transport failures to the
+	// given categories, e.g. timeouts and connection resets but not DNS
+	// or TLS errors. By default, all transport failures are retried.
+	RetryOnTransportErrors []errors.TransportErrorKind
+
+	// RespectRateLimits waits as long as a failed response asks before
+	// retrying, using its Retry-After header or, when X-RateLimit-Remaining
+	// is 0, its X-RateLimit-Reset time. Waits longer than MaxDelay are not
+	// retried.
+	RespectRateLimits bool
+
 	// CircuitBreaker enables circuit breaker functionality.
 	CircuitBreaker bool
 
@@ -88,6 +104,27 @@ func (r *RetryOptions) ShouldRetryStatus(statusCode int) bool {
 	return false
 }
 
+// ShouldRetryError checks if a failed attempt should be retried based on
+// the category of its transport error.
+func (r *RetryOptions) ShouldRetryError(err error) bool {
+	if len(r.RetryOnTransportErrors) == 0 {
+		return true
+	}
+
+	var transportErr *errors.TransportError
+	if !stderrors.As(err, &transportErr) {
+		return true
+	}
+
+	for _, kind := range r.RetryOnTransportErrors {
+		if transportErr.Kind == kind {
+			return true
+		}
+	}
+
+	return false
+}
+
 // CircuitBreakerState represents the state of a circuit breaker.
 type CircuitBreakerState string
 
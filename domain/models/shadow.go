@@ -0,0 +1,44 @@
+package models
+
+// ShadowOptions configures traffic mirroring to a shadow backend.
+// Mirrored requests run in the background after the primary request and
+// their responses are never returned to the caller.
+type ShadowOptions struct {
+	// BaseURL is the base URL of the shadow backend.
+	BaseURL string
+
+	// Compare enables diffing primary and shadow responses.
+	Compare bool
+
+	// Normalize prepares a body for comparison (e.g. strips timestamps).
+	// When nil, JSON bodies are compared structurally and other bodies byte-for-byte.
+	Normalize func(body []byte) []byte
+
+	// OnMismatch is called when primary and shadow responses differ.
+	OnMismatch func(mismatch *ShadowMismatch)
+
+	// MirrorUnsafe also mirrors POST, PATCH, PUT, DELETE and other unsafe
+	// methods. By default only GET, HEAD, OPTIONS and TRACE requests are
+	// mirrored, since replaying a mutation may change the shadow backend's
+	// data or trigger side effects.
+	MirrorUnsafe bool
+}
+
+// ShadowMismatch describes a difference between a primary and a shadow response.
+type ShadowMismatch struct {
+	Method        string
+	Path          string
+	PrimaryStatus int
+	ShadowStatus  int
+	PrimaryBody   []byte
+	ShadowBody    []byte
+
+	// StatusMismatch reports whether the status codes differ.
+	StatusMismatch bool
+
+	// BodyMismatch reports whether the normalized bodies differ.
+	BodyMismatch bool
+
+	// ShadowErr is set when the shadow request failed at transport level.
+	ShadowErr error
+}
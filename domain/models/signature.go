@@ -0,0 +1,31 @@
+package models
+
+import "crypto"
+
+// SignatureOptions configures HTTP message signing following the
+// draft-cavage HTTP Signatures scheme used across the fediverse.
+type SignatureOptions struct {
+	// KeyID identifies the key to the verifier, e.g. an actor's key URL.
+	KeyID string
+
+	// Key signs the request. RSA keys produce rsa-sha256 signatures,
+	// Ed25519 keys produce ed25519 signatures.
+	Key crypto.Signer
+
+	// Headers lists the signed components in order. The pseudo-header
+	// "(request-target)" covers the method and path. A "digest" entry adds
+	// a SHA-256 Digest header and is skipped for requests without a body.
+	Headers []string
+}
+
+// NewMastodonSignatureOptions returns signing options matching the
+// Mastodon HTTP signature profile: rsa-sha256 over (request-target), host,
+// date and, for requests with a body, digest. keyID is usually the actor
+// URL followed by "#main-key".
+func NewMastodonSignatureOptions(keyID string, key crypto.Signer) *SignatureOptions {
+	return &SignatureOptions{
+		KeyID:   keyID,
+		Key:     key,
+		Headers: []string{"(request-target)", "host", "date", "digest"},
+	}
+}
@@ -0,0 +1,44 @@
+package models
+
+import "time"
+
+// SSEEvent is one event of a text/event-stream response.
+type SSEEvent struct {
+	// ID is the event's id field, or the last id seen on the stream.
+	ID string `json:"id,omitempty"`
+
+	// Event is the event type. Empty means "message".
+	Event string `json:"event,omitempty"`
+
+	// Data is the event payload, with multiple data lines joined by "\n".
+	Data string `json:"data"`
+
+	// Retry is the reconnection delay requested by the server, or zero.
+	Retry time.Duration `json:"retry,omitempty"`
+}
+
+// SSEOptions configures a server-sent events subscription.
+type SSEOptions struct {
+	// LastEventID resumes the stream after this event id. It is sent as
+	// the Last-Event-ID header and updated as events arrive.
+	LastEventID string
+
+	// ReconnectDelay is the wait before reconnecting after the stream
+	// ends or fails, until the server overrides it with a retry field.
+	ReconnectDelay time.Duration
+
+	// MaxReconnects limits consecutive reconnects without receiving an
+	// event. Zero reconnects indefinitely; negative never reconnects.
+	MaxReconnects int
+
+	// OnError is called with every connection error that is followed by
+	// a reconnect. It may be nil.
+	OnError func(err error)
+}
+
+// NewSSEOptions creates default SSE options.
+func NewSSEOptions() *SSEOptions {
+	return &SSEOptions{
+		ReconnectDelay: 3 * time.Second,
+	}
+}
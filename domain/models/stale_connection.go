@@ -0,0 +1,24 @@
+package models
+
+import "time"
+
+// StaleConnectionOptions configures protection of non-idempotent requests
+// against connections the server closed while they sat idle in the pool.
+type StaleConnectionOptions struct {
+	// IdleThreshold is how long a host may go without requests before its
+	// pooled connections are suspected to be stale.
+	IdleThreshold time.Duration
+
+	// Redial closes idle connections so the request dials a new one,
+	// instead of checking liveness with a ping. It is the most reliable
+	// choice but also drops idle connections to other hosts.
+	Redial bool
+}
+
+// NewStaleConnectionOptions creates default options: a liveness ping
+// before non-idempotent requests to hosts idle for 30 seconds or more.
+func NewStaleConnectionOptions() *StaleConnectionOptions {
+	return &StaleConnectionOptions{
+		IdleThreshold: 30 * time.Second,
+	}
+}
@@ -0,0 +1,25 @@
+package models
+
+import "strings"
+
+// Text is a response target decoded as plain text whatever the response
+// Content-Type, for endpoints such as metrics or status pages:
+//
+//	var status models.Text
+//	resp, err := client.Get(ctx, "/status", nil, &status)
+type Text string
+
+// String returns the text.
+func (t Text) String() string {
+	return string(t)
+}
+
+// Lines splits the text into lines without their line endings. A final
+// line ending doesn't start another line.
+func (t Text) Lines() []string {
+	text := strings.TrimSuffix(strings.ReplaceAll(string(t), "\r\n", "\n"), "\n")
+	if text == "" {
+		return nil
+	}
+	return strings.Split(text, "\n")
+}
@@ -0,0 +1,44 @@
+package models
+
+import "time"
+
+// Timings records how long each phase of a request took.
+// Phases that did not happen (e.g. DNS on a reused connection) are zero.
+type Timings struct {
+	DNSLookup       time.Duration
+	Connect         time.Duration
+	TLSHandshake    time.Duration
+	TimeToFirstByte time.Duration
+	Total           time.Duration
+
+	// ConnReused reports whether an idle keep-alive connection was reused.
+	ConnReused bool
+
+	// TLSResumed reports whether the TLS handshake resumed a previous session.
+	// There is no false-start counterpart: crypto/tls implements neither
+	// TLS 1.2 False Start nor TLS 1.3 early data, so the request is always
+	// sent after the full handshake and TLSHandshake covers all of it.
+	TLSResumed bool
+
+	// TLSVersion is the negotiated TLS version (e.g. tls.VersionTLS13).
+	TLSVersion uint16
+
+	// Resolver names the resolver that resolved the host when fallback
+	// resolvers are configured, e.g. "system", "doh" or "hosts". It is
+	// empty otherwise and for reused connections.
+	Resolver string
+}
+
+// TLSStats aggregates TLS handshake counters across requests of a client.
+type TLSStats struct {
+	Handshakes int64
+	Resumed    int64
+}
+
+// ResumptionRate returns the fraction of handshakes that resumed a session.
+func (s TLSStats) ResumptionRate() float64 {
+	if s.Handshakes == 0 {
+		return 0
+	}
+	return float64(s.Resumed) / float64(s.Handshakes)
+}
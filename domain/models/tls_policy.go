@@ -0,0 +1,50 @@
+package models
+
+import "crypto/tls"
+
+// TLSPolicy sets the TLS versions, cipher suites and key exchange curves a
+// client accepts. Use TLSPolicyModern or TLSPolicyIntermediate for the
+// common baselines, or fill in a custom policy. Zero-valued fields keep Go's
+// defaults.
+type TLSPolicy struct {
+	// Name identifies the policy in logs and diagnostics.
+	Name string
+
+	MinVersion uint16
+	MaxVersion uint16
+
+	// CipherSuites lists the TLS 1.0-1.2 cipher suites in order of
+	// preference. TLS 1.3 suites are not configurable.
+	CipherSuites []uint16
+
+	// CurvePreferences lists the key exchange curves in order of preference.
+	CurvePreferences []tls.CurveID
+}
+
+// TLSPolicyModern returns a policy that only accepts TLS 1.3, for services
+// whose clients are all recent.
+func TLSPolicyModern() *TLSPolicy {
+	return &TLSPolicy{
+		Name:             "modern",
+		MinVersion:       tls.VersionTLS13,
+		CurvePreferences: []tls.CurveID{tls.X25519, tls.CurveP256, tls.CurveP384},
+	}
+}
+
+// TLSPolicyIntermediate returns a policy accepting TLS 1.2 with forward
+// secret AEAD cipher suites, and TLS 1.3.
+func TLSPolicyIntermediate() *TLSPolicy {
+	return &TLSPolicy{
+		Name:       "intermediate",
+		MinVersion: tls.VersionTLS12,
+		CipherSuites: []uint16{
+			tls.TLS_ECDHE_ECDSA_WITH_AES_128_GCM_SHA256,
+			tls.TLS_ECDHE_RSA_WITH_AES_128_GCM_SHA256,
+			tls.TLS_ECDHE_ECDSA_WITH_AES_256_GCM_SHA384,
+			tls.TLS_ECDHE_RSA_WITH_AES_256_GCM_SHA384,
+			tls.TLS_ECDHE_ECDSA_WITH_CHACHA20_POLY1305_SHA256,
+			tls.TLS_ECDHE_RSA_WITH_CHACHA20_POLY1305_SHA256,
+		},
+		CurvePreferences: []tls.CurveID{tls.X25519, tls.CurveP256, tls.CurveP384},
+	}
+}
@@ -0,0 +1,18 @@
+package models
+
+// URLJoinPolicy selects how a request path is combined with the base URL.
+type URLJoinPolicy int
+
+const (
+	// JoinAppend appends the path to the base URL path, so with a base URL
+	// of https://host/api/v2 both "/users" and "users" become
+	// https://host/api/v2/users. A query in the base URL is kept and
+	// combined with the query of the path.
+	JoinAppend URLJoinPolicy = iota
+
+	// JoinResolve resolves the path against the base URL as a browser
+	// resolves links (RFC 3986 section 5.2): "/users" replaces the base
+	// path, and "users" replaces its last segment unless the base URL ends
+	// with a slash.
+	JoinResolve
+)
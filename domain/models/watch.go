@@ -0,0 +1,32 @@
+package models
+
+import "time"
+
+// WatchOptions configures adaptive conditional polling.
+type WatchOptions struct {
+	// MinInterval is the shortest time between polls, used while the
+	// resource changes frequently. Non-positive values use the default.
+	MinInterval time.Duration
+
+	// MaxInterval is the longest time between polls, reached while the
+	// resource stays unchanged or the server keeps failing.
+	MaxInterval time.Duration
+
+	// Growth multiplies the interval after each unchanged poll. A change
+	// halves it. Values below 1 are treated as 1.5.
+	Growth float64
+
+	// Jitter randomizes each interval by up to this fraction (0.0 - 1.0)
+	// in either direction, so fleets of pollers don't synchronize.
+	Jitter float64
+}
+
+// NewWatchOptions creates default watch options.
+func NewWatchOptions() *WatchOptions {
+	return &WatchOptions{
+		MinInterval: time.Second,
+		MaxInterval: time.Minute,
+		Growth:      1.5,
+		Jitter:      0.2,
+	}
+}
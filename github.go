@@ -0,0 +1,60 @@
+package gofetch
+
+import (
+	"crypto/tls"
+	"time"
+
+	"github.com/fourth-ally/gofetch/domain/models"
+	"github.com/fourth-ally/gofetch/infrastructure"
+)
+
+// GitHubAPIURL is the base URL of the GitHub REST API.
+const GitHubAPIURL = "https://api.github.com"
+
+// PolicyGitHub suits the GitHub REST API: versioned JSON media types and
+// retries that back off as instructed by GitHub's primary and secondary
+// (abuse detection) rate limits.
+var PolicyGitHub = &models.Policy{
+	Name:                "github",
+	Timeout:             30 * time.Second,
+	DialTimeout:         10 * time.Second,
+	TLSHandshakeTimeout: 10 * time.Second,
+	MinTLSVersion:       tls.VersionTLS12,
+	RetryOptions: &models.RetryOptions{
+		MaxRetries:         3,
+		InitialDelay:       time.Second,
+		MaxDelay:           time.Minute,
+		Backoff:            models.BackoffExponential,
+		Jitter:             true,
+		JitterFraction:     0.3,
+		RetryOnStatusCodes: []int{403, 429},
+		RespectRateLimits:  true,
+	},
+	Headers: map[string]string{
+		"Accept":               "application/vnd.github+json",
+		"X-GitHub-Api-Version": "2022-11-28",
+	},
+}
+
+// NewGitHubClient creates a client for the GitHub REST API authenticated
+// with token (omitted when empty). It applies PolicyGitHub and enables
+// conditional requests, whose 304 responses don't count against the rate
+// limit. Walk paginated endpoints with Client.Paginate.
+//
+// Example:
+//
+//	client := gofetch.NewGitHubClient(os.Getenv("GITHUB_TOKEN"))
+//	err := client.Paginate(ctx, "/repos/:owner/:repo/issues",
+//	    map[string]interface{}{"owner": "golang", "repo": "go", "per_page": 100},
+//	    &issues, func(*models.Response) error { all = append(all, issues...); return nil })
+func NewGitHubClient(token string) *infrastructure.Client {
+	client := infrastructure.NewClient().
+		ApplyPolicy(PolicyGitHub).
+		SetBaseURL(GitHubAPIURL).
+		SetConditionalRequests(infrastructure.NewMemoryCacheStore(1000))
+
+	if token != "" {
+		client.SetHeader("Authorization", "Bearer "+token)
+	}
+	return client
+}
@@ -4,6 +4,11 @@
 package gofetch
 
 import (
+	"context"
+	"time"
+
+	"github.com/fourth-ally/gofetch/domain/errors"
+	"github.com/fourth-ally/gofetch/domain/models"
 	"github.com/fourth-ally/gofetch/infrastructure"
 )
 
@@ -19,3 +24,44 @@ import (
 func NewClient() *infrastructure.Client {
 	return infrastructure.NewClient()
 }
+
+// WithHeaderContext returns a context that sets a header on every request
+// made with it, for code that can only pass a context through.
+//
+// Example:
+//
+//	ctx = gofetch.WithHeaderContext(ctx, "X-Tenant-ID", tenant)
+//	client.Get(ctx, "/orders", nil, &orders)
+func WithHeaderContext(ctx context.Context, key, value string) context.Context {
+	return infrastructure.WithHeaderContext(ctx, key, value)
+}
+
+// WithTimeoutContext returns a context that replaces the client timeout
+// for every request made with it.
+func WithTimeoutContext(ctx context.Context, timeout time.Duration) context.Context {
+	return infrastructure.WithTimeoutContext(ctx, timeout)
+}
+
+// FileBody returns a request body streamed from the file at path when the
+// request is sent, with its size as Content-Length, instead of reading
+// the file into memory. The Content-Type is derived from the extension.
+//
+// Example:
+//
+//	client.Put(ctx, "/objects/backup.tar", nil, gofetch.FileBody("backup.tar"), nil)
+func FileBody(path string) *models.FileBody {
+	return &models.FileBody{Path: path}
+}
+
+// CodeOf returns the stable error code of err, e.g. errors.CodeTimeout
+// ("GOFETCH_TIMEOUT"), for metrics and alerting. It returns
+// errors.CodeUnknown for errors without a code and "" for nil.
+//
+// Example:
+//
+//	if _, err := client.Get(ctx, "/orders", nil, &orders); err != nil {
+//	    failures.WithLabelValues(string(gofetch.CodeOf(err))).Inc()
+//	}
+func CodeOf(err error) errors.Code {
+	return errors.CodeOf(err)
+}
@@ -0,0 +1,76 @@
+package infrastructure
+
+import (
+	"math"
+	"math/rand"
+	"time"
+
+	"github.com/fourth-ally/gofetch/domain/contracts"
+)
+
+// NewConstantBackoff waits the same delay before every retry.
+func NewConstantBackoff(delay time.Duration) contracts.Backoff {
+	return contracts.BackoffFunc(func(int, time.Duration) time.Duration {
+		return delay
+	})
+}
+
+// NewExponentialBackoff doubles the delay after each retry, starting at
+// initial and capped at max.
+func NewExponentialBackoff(initial, max time.Duration) contracts.Backoff {
+	return contracts.BackoffFunc(func(attempt int, _ time.Duration) time.Duration {
+		return exponentialDelay(initial, max, attempt)
+	})
+}
+
+// NewFullJitterBackoff waits a random delay between zero and the capped
+// exponential delay, spreading retries of many clients evenly over time.
+func NewFullJitterBackoff(base, max time.Duration) contracts.Backoff {
+	return contracts.BackoffFunc(func(attempt int, _ time.Duration) time.Duration {
+		return randomBetween(0, exponentialDelay(base, max, attempt))
+	})
+}
+
+// NewDecorrelatedJitterBackoff waits a random delay between base and three
+// times the previous delay, capped at max. Delays grow on average but are
+// not tied to the attempt number.
+func NewDecorrelatedJitterBackoff(base, max time.Duration) contracts.Backoff {
+	return contracts.BackoffFunc(func(_ int, previous time.Duration) time.Duration {
+		if previous < base {
+			previous = base
+		}
+
+		delay := randomBetween(base, 3*previous)
+		if max > 0 && delay > max {
+			delay = max
+		}
+		return delay
+	})
+}
+
+// SetRetryBackoff replaces the built-in backoff of the retry options with
+// a custom strategy. Pass nil to go back to RetryOptions.Backoff.
+func (c *Client) SetRetryBackoff(backoff contracts.Backoff) *Client {
+	c.retryBackoff = backoff
+	if c.state.Load().retryManager != nil {
+		c.state.Load().retryManager.backoff = backoff
+	}
+	return c
+}
+
+// exponentialDelay returns initial * 2^attempt capped at max.
+func exponentialDelay(initial, max time.Duration, attempt int) time.Duration {
+	delay := float64(initial) * math.Pow(2, float64(attempt))
+	if max > 0 && delay > float64(max) {
+		return max
+	}
+	return time.Duration(delay)
+}
+
+// randomBetween returns a random duration in [low, high].
+func randomBetween(low, high time.Duration) time.Duration {
+	if high <= low {
+		return low
+	}
+	return low + time.Duration(rand.Int63n(int64(high-low)+1))
+}
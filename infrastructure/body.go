@@ -0,0 +1,266 @@
+package infrastructure
+
+import (
+	"bytes"
+	"context"
+	stderrors "errors"
+	"fmt"
+	"io"
+	"mime"
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync/atomic"
+
+	"github.com/fourth-ally/gofetch/domain/models"
+)
+
+const (
+	// jsonContentType is the Content-Type of JSON bodies.
+	jsonContentType = "application/json; charset=utf-8"
+
+	// formContentType is the Content-Type of URL-encoded form bodies.
+	formContentType = "application/x-www-form-urlencoded"
+
+	// binaryContentType is the Content-Type of raw byte and reader bodies.
+	binaryContentType = "application/octet-stream"
+
+	// textContentType is the Content-Type of raw string bodies.
+	textContentType = "text/plain; charset=utf-8"
+)
+
+// SetContentType overrides the media type of encoded request bodies, e.g.
+// "application/vnd.company+json". Without parameters of its own, the
+// override inherits the charset of the codec's default Content-Type.
+// An explicit Content-Type header still takes precedence.
+func (c *Client) SetContentType(contentType string) *Client {
+	c.state.Load().config.ContentType = contentType
+	return c
+}
+
+// PostForm performs a POST request with form as an
+// application/x-www-form-urlencoded body, as expected by OAuth token
+// endpoints and many legacy APIs. Passing url.Values as the body of any
+// other request has the same effect.
+func (c *Client) PostForm(ctx context.Context, path string, form url.Values, target interface{}) (*models.Response, error) {
+	return c.execute(ctx, http.MethodPost, path, nil, form, target, nil)
+}
+
+// encodeBody serializes a request body and returns its default Content-Type.
+// url.Values are form-encoded; []byte, string and io.Reader bodies are sent
+// as-is (see prepareBody for how readers are streamed); everything else
+// uses the client codec. Set WithContentType or a
+// Content-Type header to describe raw bodies.
+func (c *Client) encodeBody(body interface{}) ([]byte, string, error) {
+	switch b := body.(type) {
+	case url.Values:
+		return []byte(b.Encode()), formContentType, nil
+	case []byte:
+		return b, binaryContentType, nil
+	case string:
+		return []byte(b), textContentType, nil
+	case io.Reader:
+		data, err := io.ReadAll(b)
+		return data, binaryContentType, err
+	case *models.FileBody:
+		data, err := os.ReadFile(b.Path)
+		return data, fileContentType(b), err
+	default:
+		codec := c.requestCodec()
+		data, err := codec.Marshal(body)
+		return data, codec.ContentType(), err
+	}
+}
+
+// errBodyConsumed is returned when a streamed body that can't be rewound
+// would have to be sent again.
+var errBodyConsumed = stderrors.New("streamed request body was already sent and can't be rewound")
+
+// streamBody is a request body streamed from a reader instead of being
+// buffered in memory. Seekable readers are rewound to their starting
+// offset for every send; other readers can only be sent once. File
+// bodies are reopened for every send instead.
+type streamBody struct {
+	reader      io.Reader
+	seeker      io.Seeker
+	start       int64
+	size        int64
+	used        atomic.Bool
+	reopen      func() io.Reader
+	contentType string
+}
+
+// prepareBody decides how a request body is sent. In-memory readers are
+// buffered so retries, hedges and redirects can resend them; other
+// readers, such as files and pipes, are streamed. Other bodies are
+// returned unchanged.
+func prepareBody(body interface{}) (interface{}, error) {
+	switch reader := body.(type) {
+	case *bytes.Buffer, *bytes.Reader, *strings.Reader:
+		return io.ReadAll(reader.(io.Reader))
+	case io.Reader:
+		return newStreamBody(reader), nil
+	case *models.FileBody:
+		return newFileStreamBody(reader)
+	default:
+		return body, nil
+	}
+}
+
+// newFileStreamBody streams file from disk, measuring its size up front.
+func newFileStreamBody(file *models.FileBody) (*streamBody, error) {
+	info, err := os.Stat(file.Path)
+	if err != nil {
+		return nil, err
+	}
+
+	return &streamBody{
+		size:        info.Size(),
+		reopen:      func() io.Reader { return &lazyFile{path: file.Path} },
+		contentType: fileContentType(file),
+	}, nil
+}
+
+// fileContentType returns the Content-Type of a file body.
+func fileContentType(file *models.FileBody) string {
+	if file.ContentType != "" {
+		return file.ContentType
+	}
+	if contentType := mime.TypeByExtension(filepath.Ext(file.Path)); contentType != "" {
+		return contentType
+	}
+	return binaryContentType
+}
+
+// lazyFile opens a file on first read and closes it once it has been read
+// to the end or failed, so a request that is never sent holds no file.
+type lazyFile struct {
+	path string
+	file *os.File
+	done bool
+}
+
+// Read implements io.Reader.
+func (f *lazyFile) Read(p []byte) (int, error) {
+	if f.done {
+		return 0, io.EOF
+	}
+	if f.file == nil {
+		file, err := os.Open(f.path)
+		if err != nil {
+			f.done = true
+			return 0, err
+		}
+		f.file = file
+	}
+
+	n, err := f.file.Read(p)
+	if err != nil {
+		f.Close()
+	}
+	return n, err
+}
+
+// Close closes the file if it was opened.
+func (f *lazyFile) Close() error {
+	f.done = true
+	if f.file == nil {
+		return nil
+	}
+	err := f.file.Close()
+	f.file = nil
+	return err
+}
+
+// newStreamBody wraps reader, measuring its size if it is seekable.
+func newStreamBody(reader io.Reader) *streamBody {
+	stream := &streamBody{reader: reader, size: -1}
+
+	seeker, ok := reader.(io.Seeker)
+	if !ok {
+		return stream
+	}
+
+	start, err := seeker.Seek(0, io.SeekCurrent)
+	if err != nil {
+		return stream
+	}
+	end, err := seeker.Seek(0, io.SeekEnd)
+	if err != nil {
+		return stream
+	}
+	if _, err := seeker.Seek(start, io.SeekStart); err != nil {
+		return stream
+	}
+
+	stream.seeker = seeker
+	stream.start = start
+	stream.size = end - start
+	return stream
+}
+
+// replayable reports whether the body can be sent more than once.
+func (b *streamBody) replayable() bool {
+	return b.seeker != nil || b.reopen != nil
+}
+
+// open returns the body positioned at its start for another send. The
+// transport closes request bodies, so the reader is wrapped to leave
+// closing it, e.g. a file, to the caller.
+func (b *streamBody) open() (io.Reader, error) {
+	if b.reopen != nil {
+		return b.reopen(), nil
+	}
+	if b.seeker != nil {
+		if _, err := b.seeker.Seek(b.start, io.SeekStart); err != nil {
+			return nil, fmt.Errorf("failed to rewind request body: %w", err)
+		}
+		return io.NopCloser(b.reader), nil
+	}
+
+	if b.used.Swap(true) {
+		return nil, errBodyConsumed
+	}
+	return io.NopCloser(b.reader), nil
+}
+
+// readCloser returns reader as an io.ReadCloser, keeping its own Close
+// method, e.g. that of a lazily opened file, if it has one.
+func readCloser(reader io.Reader) io.ReadCloser {
+	if closer, ok := reader.(io.ReadCloser); ok {
+		return closer
+	}
+	return io.NopCloser(reader)
+}
+
+// closeReader closes reader if it is an io.Closer.
+func closeReader(reader io.Reader) error {
+	if closer, ok := reader.(io.Closer); ok {
+		return closer.Close()
+	}
+	return nil
+}
+
+// isStreamBody reports whether body is streamed rather than buffered.
+func isStreamBody(body interface{}) bool {
+	_, ok := body.(*streamBody)
+	return ok
+}
+
+// resolveContentType applies a configured override to the codec default.
+func resolveContentType(override, codecDefault string) string {
+	if override == "" {
+		return codecDefault
+	}
+
+	if _, params, err := mime.ParseMediaType(override); err == nil && len(params) > 0 {
+		return override
+	}
+
+	if _, params, err := mime.ParseMediaType(codecDefault); err == nil && params["charset"] != "" {
+		return mime.FormatMediaType(override, map[string]string{"charset": params["charset"]})
+	}
+	return override
+}
@@ -0,0 +1,203 @@
+package infrastructure
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/fourth-ally/gofetch/domain/errors"
+	"github.com/fourth-ally/gofetch/domain/models"
+)
+
+// bulkhead limits in-flight requests globally and per host.
+type bulkhead struct {
+	options *models.BulkheadOptions
+	global  *prioritySemaphore
+
+	mu    sync.Mutex
+	hosts map[string]*prioritySemaphore
+}
+
+// prioritySemaphore is a counting semaphore whose waiters are admitted by
+// priority, then in arrival order.
+type prioritySemaphore struct {
+	mu       sync.Mutex
+	capacity int
+	inUse    int
+	waiters  map[models.Priority][]chan struct{}
+}
+
+// SetMaxConcurrentRequests caps the number of in-flight requests. Excess
+// requests queue until a slot is free or their context is done.
+// Zero removes the limit.
+func (c *Client) SetMaxConcurrentRequests(n int) *Client {
+	return c.SetBulkhead(&models.BulkheadOptions{MaxConcurrent: n})
+}
+
+// SetBulkhead caps in-flight requests globally and per host, queueing or
+// rejecting excess requests with *errors.BulkheadFullError. Queued requests
+// are admitted by priority (see WithPriority). Pass nil to remove all limits.
+func (c *Client) SetBulkhead(options *models.BulkheadOptions) *Client {
+	if options == nil || (options.MaxConcurrent <= 0 && options.MaxPerHost <= 0) {
+		c.bulkhead = nil
+		return c
+	}
+
+	b := &bulkhead{
+		options: options,
+		hosts:   make(map[string]*prioritySemaphore),
+	}
+	if options.MaxConcurrent > 0 {
+		b.global = newPrioritySemaphore(options.MaxConcurrent)
+	}
+
+	c.bulkhead = b
+	return c
+}
+
+// SetPriority sets the default scheduling priority of the client's
+// requests, e.g. PriorityLow for a derived client used by background jobs.
+func (c *Client) SetPriority(priority models.Priority) *Client {
+	c.state.Load().config.Priority = &priority
+	return c
+}
+
+// requestPriority returns the priority of config, PriorityNormal if unset.
+func requestPriority(config *models.Config) models.Priority {
+	if config.Priority == nil {
+		return models.PriorityNormal
+	}
+	return *config.Priority
+}
+
+// acquire reserves a per-host and a global slot. The host slot is taken
+// first, so requests waiting on a saturated host don't hold global slots
+// other hosts could use. The returned function releases them.
+func (b *bulkhead) acquire(ctx context.Context, host string, priority models.Priority) (func(), error) {
+	if b == nil {
+		return func() {}, nil
+	}
+
+	var deadline <-chan time.Time
+	if b.options.MaxWait > 0 {
+		timer := time.NewTimer(b.options.MaxWait)
+		defer timer.Stop()
+		deadline = timer.C
+	}
+	reject := b.options.MaxWait < 0
+
+	hostSlots := b.hostSlots(host)
+	if hostSlots != nil {
+		if err := hostSlots.acquire(ctx, priority, deadline, reject, &errors.BulkheadFullError{Host: host, Limit: b.options.MaxPerHost}); err != nil {
+			return nil, err
+		}
+	}
+
+	if b.global != nil {
+		if err := b.global.acquire(ctx, priority, deadline, reject, &errors.BulkheadFullError{Limit: b.options.MaxConcurrent}); err != nil {
+			if hostSlots != nil {
+				hostSlots.release()
+			}
+			return nil, err
+		}
+	}
+
+	return func() {
+		if hostSlots != nil {
+			hostSlots.release()
+		}
+		if b.global != nil {
+			b.global.release()
+		}
+	}, nil
+}
+
+// hostSlots returns the semaphore for host, or nil without a per-host limit.
+func (b *bulkhead) hostSlots(host string) *prioritySemaphore {
+	if b.options.MaxPerHost <= 0 {
+		return nil
+	}
+
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	slots, ok := b.hosts[host]
+	if !ok {
+		slots = newPrioritySemaphore(b.options.MaxPerHost)
+		b.hosts[host] = slots
+	}
+	return slots
+}
+
+// newPrioritySemaphore creates a semaphore with capacity slots.
+func newPrioritySemaphore(capacity int) *prioritySemaphore {
+	return &prioritySemaphore{
+		capacity: capacity,
+		waiters:  make(map[models.Priority][]chan struct{}),
+	}
+}
+
+// acquire takes a slot, queueing by priority unless reject is set.
+func (s *prioritySemaphore) acquire(ctx context.Context, priority models.Priority, deadline <-chan time.Time, reject bool, full error) error {
+	priority = min(max(priority, models.PriorityLow), models.PriorityHigh)
+
+	s.mu.Lock()
+	if s.inUse < s.capacity {
+		s.inUse++
+		s.mu.Unlock()
+		return nil
+	}
+
+	if reject {
+		s.mu.Unlock()
+		return full
+	}
+
+	ready := make(chan struct{})
+	s.waiters[priority] = append(s.waiters[priority], ready)
+	s.mu.Unlock()
+
+	var err error
+	select {
+	case <-ready:
+		return nil
+	case <-deadline:
+		err = full
+	case <-ctx.Done():
+		err = ctx.Err()
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	queue := s.waiters[priority]
+	for i, waiter := range queue {
+		if waiter == ready {
+			s.waiters[priority] = append(queue[:i], queue[i+1:]...)
+			return err
+		}
+	}
+
+	// The slot was handed over while giving up; pass it on
+	s.releaseLocked()
+	return err
+}
+
+// release frees a slot, handing it to the highest-priority waiter.
+func (s *prioritySemaphore) release() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.releaseLocked()
+}
+
+// releaseLocked implements release with s.mu held.
+func (s *prioritySemaphore) releaseLocked() {
+	for _, priority := range []models.Priority{models.PriorityHigh, models.PriorityNormal, models.PriorityLow} {
+		if queue := s.waiters[priority]; len(queue) > 0 {
+			s.waiters[priority] = queue[1:]
+			close(queue[0])
+			return
+		}
+	}
+	s.inUse--
+}
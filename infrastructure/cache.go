@@ -0,0 +1,411 @@
+package infrastructure
+
+import (
+	"context"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/fourth-ally/gofetch/domain/contracts"
+	"github.com/fourth-ally/gofetch/domain/errors"
+	"github.com/fourth-ally/gofetch/domain/models"
+)
+
+// responseCache implements RFC 9111 caching semantics on top of a CacheStore.
+type responseCache struct {
+	store   contracts.CacheStore
+	options *models.CacheOptions
+
+	mu         sync.Mutex
+	refreshing map[string]bool
+}
+
+// SetCache enables the HTTP response cache for GET requests. Fresh entries
+// are served without contacting the server and stale entries with validators
+// are revalidated. Pass a nil store to disable caching.
+func (c *Client) SetCache(store contracts.CacheStore, options *models.CacheOptions) *Client {
+	if store == nil {
+		c.cache = nil
+		return c
+	}
+
+	if options == nil {
+		options = models.NewCacheOptions()
+	}
+
+	c.cache = &responseCache{
+		store:      store,
+		options:    options,
+		refreshing: make(map[string]bool),
+	}
+	return c
+}
+
+// executeCached serves a GET request from the cache when possible.
+func (c *Client) executeCached(ctx context.Context, method, path string, params map[string]interface{}, target interface{}, requestConfig *models.Config) (*models.Response, error) {
+	config := c.mergedConfig(requestConfig)
+	fullURL, err := c.buildURL(config.BaseURL, path, params)
+	if err != nil {
+		return c.executeUncached(ctx, method, path, params, nil, target, requestConfig)
+	}
+
+	// Partial responses share the key of the full resource, so range
+	// requests neither use nor fill the cache
+	requestCC := models.ParseCacheControl(headerValue(config.Headers, "Cache-Control"))
+	if requestCC.Has("no-store") || headerValue(config.Headers, "Range") != "" {
+		return c.executeUncached(ctx, method, path, params, nil, target, requestConfig)
+	}
+
+	key := cacheKey(fullURL)
+	entry, found := c.cache.lookup(key, config.Headers)
+	if found {
+		if usable, stale := c.cache.satisfies(entry, requestCC, time.Now()); usable {
+			resp, err := c.cachedResponse(entry, target)
+			if resp != nil {
+				resp.Stale = stale
+			}
+			return resp, err
+		}
+	}
+
+	// Within the stale-while-revalidate window the stale entry is served
+	// immediately while a background request refreshes it
+	onlyIfCached := requestCC.Has("only-if-cached")
+	if found && !requestCC.Has("no-cache") && c.cache.canServeStale(entry, time.Now()) {
+		if !onlyIfCached {
+			c.revalidateInBackground(method, path, params, requestConfig, config, key, entry)
+		}
+
+		resp, err := c.cachedResponse(entry, target)
+		if resp != nil {
+			resp.Stale = true
+		}
+		return resp, err
+	}
+
+	if onlyIfCached {
+		return nil, &errors.HTTPError{
+			StatusCode: http.StatusGatewayTimeout,
+			Message:    "no cached response satisfies only-if-cached",
+		}
+	}
+
+	if !found {
+		entry = nil
+	}
+
+	resp, err := c.fetchAndStore(ctx, method, path, params, requestConfig, config, key, entry)
+	if err != nil {
+		return resp, err
+	}
+
+	if err := c.unmarshalTarget(resp.RawBody, resp.Headers, target); err != nil {
+		return nil, err
+	}
+	resp.Data = target
+
+	return resp, nil
+}
+
+// fetchAndStore fetches a response from the network, revalidating entry if
+// it carries validators, and updates the cache with the result.
+func (c *Client) fetchAndStore(ctx context.Context, method, path string, params map[string]interface{}, requestConfig *models.Config, config *models.Config, key string, entry *models.CacheEntry) (*models.Response, error) {
+	// Stale entries with validators are revalidated with a conditional request
+	networkConfig := requestConfig
+	revalidating := entry != nil && hasValidators(entry.Headers)
+	if revalidating {
+		networkConfig = conditionalConfig(requestConfig, config.StatusValidator, entry.Headers)
+	}
+
+	resp, err := c.executeUncached(ctx, method, path, params, nil, nil, networkConfig)
+	if err != nil {
+		return resp, err
+	}
+
+	if revalidating && resp.StatusCode == http.StatusNotModified {
+		return c.cachedResponse(c.cache.refresh(key, entry, resp.Headers), nil)
+	}
+
+	c.cache.save(key, config.Headers, resp)
+	return resp, nil
+}
+
+// revalidateInBackground refreshes a stale entry without blocking the caller.
+// Concurrent refreshes of the same entry are collapsed into one.
+func (c *Client) revalidateInBackground(method, path string, params map[string]interface{}, requestConfig *models.Config, config *models.Config, key string, entry *models.CacheEntry) {
+	if !c.cache.startRefresh(key) {
+		return
+	}
+
+	go func() {
+		defer c.cache.finishRefresh(key)
+
+		resp, err := c.fetchAndStore(context.Background(), method, path, params, requestConfig, config, key, entry)
+		if c.cache.options.OnRevalidate != nil {
+			c.cache.options.OnRevalidate(key, resp, err)
+		}
+	}()
+}
+
+// invalidateCache drops the cached entry for a URL after a successful unsafe request.
+func (c *Client) invalidateCache(path string, params map[string]interface{}, requestConfig *models.Config) {
+	fullURL, err := c.buildURL(c.mergedConfig(requestConfig).BaseURL, path, params)
+	if err == nil {
+		c.cache.store.Delete(cacheKey(fullURL))
+	}
+}
+
+// lookup returns the entry for key if its Vary headers match the request.
+func (rc *responseCache) lookup(key string, requestHeaders map[string]string) (*models.CacheEntry, bool) {
+	entry, ok := rc.store.Get(key)
+	if !ok {
+		return nil, false
+	}
+
+	for name, value := range entry.VaryHeaders {
+		if headerValue(requestHeaders, name) != value {
+			return nil, false
+		}
+	}
+
+	return entry, true
+}
+
+// save stores a response if it is cacheable.
+func (rc *responseCache) save(key string, requestHeaders map[string]string, resp *models.Response) {
+	if !rc.storable(resp) {
+		return
+	}
+
+	entry := &models.CacheEntry{
+		StatusCode:  resp.StatusCode,
+		Headers:     resp.Headers.Clone(),
+		Body:        resp.RawBody,
+		StoredAt:    time.Now(),
+		VaryHeaders: make(map[string]string),
+	}
+
+	for _, name := range strings.Split(resp.Headers.Get("Vary"), ",") {
+		name = http.CanonicalHeaderKey(strings.TrimSpace(name))
+		if name != "" {
+			entry.VaryHeaders[name] = headerValue(requestHeaders, name)
+		}
+	}
+
+	rc.store.Set(key, entry)
+}
+
+// refresh updates a stored entry with the headers of a 304 response.
+func (rc *responseCache) refresh(key string, entry *models.CacheEntry, headers http.Header) *models.CacheEntry {
+	refreshed := *entry
+	refreshed.Headers = entry.Headers.Clone()
+	for name, values := range headers {
+		// Content-Length of a 304 describes an empty body, not the stored one
+		if name == "Content-Length" {
+			continue
+		}
+		refreshed.Headers[name] = values
+	}
+	refreshed.Headers.Del("Age")
+	refreshed.StoredAt = time.Now()
+
+	rc.store.Set(key, &refreshed)
+	return &refreshed
+}
+
+// storable reports whether a response may be stored per RFC 9111 section 3.
+func (rc *responseCache) storable(resp *models.Response) bool {
+	if !heuristicallyCacheable(resp.StatusCode) {
+		return false
+	}
+
+	cc := models.ParseCacheControl(resp.Headers.Get("Cache-Control"))
+	if cc.Has("no-store") || resp.Headers.Get("Vary") == "*" {
+		return false
+	}
+
+	if rc.options.Shared && cc.Has("private") {
+		return false
+	}
+
+	return cc.Has("max-age") ||
+		(rc.options.Shared && cc.Has("s-maxage")) ||
+		cc.Has("public") ||
+		resp.Headers.Get("Expires") != "" ||
+		hasValidators(resp.Headers) ||
+		rc.options.DefaultTTL > 0
+}
+
+// isFresh reports whether an entry can be served without revalidation.
+func (rc *responseCache) isFresh(entry *models.CacheEntry, now time.Time) bool {
+	cc := models.ParseCacheControl(entry.Headers.Get("Cache-Control"))
+	if cc.Has("no-cache") {
+		return false
+	}
+
+	return rc.freshnessLifetime(entry) > currentAge(entry, now)
+}
+
+// satisfies reports whether entry can be served without revalidation to a
+// request with the Cache-Control directives requestCC (RFC 9111 section
+// 5.2.1), and whether it is served stale under max-stale.
+func (rc *responseCache) satisfies(entry *models.CacheEntry, requestCC models.CacheControl, now time.Time) (usable, stale bool) {
+	if requestCC.Has("no-cache") {
+		return false, false
+	}
+
+	age := currentAge(entry, now)
+	lifetime := rc.freshnessLifetime(entry)
+	if maxAge, ok := requestCC.Duration("max-age"); ok && age > maxAge {
+		return false, false
+	}
+	if minFresh, ok := requestCC.Duration("min-fresh"); ok && lifetime-age < minFresh {
+		return false, false
+	}
+
+	if rc.isFresh(entry, now) {
+		return true, false
+	}
+
+	cc := models.ParseCacheControl(entry.Headers.Get("Cache-Control"))
+	if !requestCC.Has("max-stale") || cc.Has("no-cache") || cc.Has("must-revalidate") ||
+		(rc.options.Shared && cc.Has("proxy-revalidate")) {
+		return false, false
+	}
+
+	// max-stale without a value accepts any staleness
+	maxStale, bounded := requestCC.Duration("max-stale")
+	return !bounded || age-lifetime <= maxStale, true
+}
+
+// canServeStale reports whether a stale entry is still within its
+// stale-while-revalidate window, taken as the larger of the configured
+// option and the response's own directive.
+func (rc *responseCache) canServeStale(entry *models.CacheEntry, now time.Time) bool {
+	window := rc.options.StaleWhileRevalidate
+	cc := models.ParseCacheControl(entry.Headers.Get("Cache-Control"))
+	if directive, ok := cc.Duration("stale-while-revalidate"); ok && directive > window {
+		window = directive
+	}
+
+	if window <= 0 || cc.Has("no-cache") || cc.Has("must-revalidate") {
+		return false
+	}
+
+	staleness := currentAge(entry, now) - rc.freshnessLifetime(entry)
+	return staleness <= window
+}
+
+// startRefresh marks key as being refreshed, reporting false if a refresh
+// is already running.
+func (rc *responseCache) startRefresh(key string) bool {
+	rc.mu.Lock()
+	defer rc.mu.Unlock()
+
+	if rc.refreshing[key] {
+		return false
+	}
+	rc.refreshing[key] = true
+	return true
+}
+
+// finishRefresh clears the refresh marker for key.
+func (rc *responseCache) finishRefresh(key string) {
+	rc.mu.Lock()
+	defer rc.mu.Unlock()
+	delete(rc.refreshing, key)
+}
+
+// freshnessLifetime computes how long an entry stays fresh (RFC 9111 section 4.2.1).
+func (rc *responseCache) freshnessLifetime(entry *models.CacheEntry) time.Duration {
+	info := models.ParseCacheInfo(entry.Headers)
+	if info.Date.IsZero() {
+		info.Date = entry.StoredAt
+	}
+
+	if lifetime, ok := info.FreshnessLifetime(rc.options.Shared); ok {
+		return lifetime
+	}
+	return rc.options.DefaultTTL
+}
+
+// currentAge estimates the age of an entry (RFC 9111 section 4.2.3).
+func currentAge(entry *models.CacheEntry, now time.Time) time.Duration {
+	age := now.Sub(entry.StoredAt)
+	if seconds, err := strconv.Atoi(entry.Headers.Get("Age")); err == nil && seconds > 0 {
+		age += time.Duration(seconds) * time.Second
+	}
+	return age
+}
+
+// cachedResponse builds a Response from a cache entry and decodes it into target.
+func (c *Client) cachedResponse(entry *models.CacheEntry, target interface{}) (*models.Response, error) {
+	if err := c.unmarshalTarget(entry.Body, entry.Headers, target); err != nil {
+		return nil, err
+	}
+
+	headers := entry.Headers.Clone()
+	headers.Set("Age", strconv.Itoa(int(currentAge(entry, time.Now()).Seconds())))
+
+	resp := models.NewResponse(entry.StatusCode, headers, target, entry.Body)
+	resp.FromCache = true
+	resp.Decoder = c.responseCodec(headers).Unmarshal
+	return resp, nil
+}
+
+// conditionalConfig adds validators from a stored response to the request
+// and accepts 304 Not Modified in addition to the configured status codes.
+func conditionalConfig(requestConfig *models.Config, validator func(int) bool, stored http.Header) *models.Config {
+	headers := make(map[string]string)
+	if etag := stored.Get("ETag"); etag != "" {
+		headers["If-None-Match"] = etag
+	}
+	if lastModified := stored.Get("Last-Modified"); lastModified != "" {
+		headers["If-Modified-Since"] = lastModified
+	}
+
+	return overrideConfig(requestConfig, &models.Config{
+		Headers: headers,
+		StatusValidator: func(statusCode int) bool {
+			return statusCode == http.StatusNotModified || validator(statusCode)
+		},
+	})
+}
+
+// hasValidators reports whether a response carries ETag or Last-Modified.
+func hasValidators(headers http.Header) bool {
+	return headers.Get("ETag") != "" || headers.Get("Last-Modified") != ""
+}
+
+// heuristicallyCacheable lists status codes cacheable by default (RFC 9110
+// section 15.1), except 206, since the cache doesn't combine partial
+// responses.
+func heuristicallyCacheable(statusCode int) bool {
+	switch statusCode {
+	case 200, 203, 204, 300, 301, 308, 404, 405, 410, 414, 501:
+		return true
+	default:
+		return false
+	}
+}
+
+// cacheKey builds the primary cache key for a GET request.
+func cacheKey(fullURL string) string {
+	return http.MethodGet + " " + fullURL
+}
+
+// headerValue looks up a header case-insensitively in a config header map.
+func headerValue(headers map[string]string, name string) string {
+	if value, ok := headers[name]; ok {
+		return value
+	}
+
+	for key, value := range headers {
+		if strings.EqualFold(key, name) {
+			return value
+		}
+	}
+	return ""
+}
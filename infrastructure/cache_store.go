@@ -0,0 +1,85 @@
+package infrastructure
+
+import (
+	"container/list"
+	"sync"
+
+	"github.com/fourth-ally/gofetch/domain/models"
+)
+
+// MemoryCacheStore is an in-memory LRU implementation of contracts.CacheStore.
+type MemoryCacheStore struct {
+	mu sync.Mutex
+
+	maxEntries int
+	order      *list.List
+	entries    map[string]*list.Element
+}
+
+// memoryCacheItem is the value stored in the LRU list.
+type memoryCacheItem struct {
+	key   string
+	entry *models.CacheEntry
+}
+
+// NewMemoryCacheStore creates an LRU cache store holding at most maxEntries
+// responses. A non-positive maxEntries means no limit.
+func NewMemoryCacheStore(maxEntries int) *MemoryCacheStore {
+	return &MemoryCacheStore{
+		maxEntries: maxEntries,
+		order:      list.New(),
+		entries:    make(map[string]*list.Element),
+	}
+}
+
+// Get returns the entry stored under key and marks it as recently used.
+func (s *MemoryCacheStore) Get(key string) (*models.CacheEntry, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	element, ok := s.entries[key]
+	if !ok {
+		return nil, false
+	}
+
+	s.order.MoveToFront(element)
+	return element.Value.(*memoryCacheItem).entry, true
+}
+
+// Set stores an entry, evicting the least recently used one if full.
+func (s *MemoryCacheStore) Set(key string, entry *models.CacheEntry) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if element, ok := s.entries[key]; ok {
+		element.Value.(*memoryCacheItem).entry = entry
+		s.order.MoveToFront(element)
+		return
+	}
+
+	s.entries[key] = s.order.PushFront(&memoryCacheItem{key: key, entry: entry})
+
+	if s.maxEntries > 0 && s.order.Len() > s.maxEntries {
+		oldest := s.order.Back()
+		s.order.Remove(oldest)
+		delete(s.entries, oldest.Value.(*memoryCacheItem).key)
+	}
+}
+
+// Delete removes the entry stored under key.
+func (s *MemoryCacheStore) Delete(key string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if element, ok := s.entries[key]; ok {
+		s.order.Remove(element)
+		delete(s.entries, key)
+	}
+}
+
+// Len returns the number of stored entries.
+func (s *MemoryCacheStore) Len() int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.order.Len()
+}
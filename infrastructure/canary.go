@@ -0,0 +1,103 @@
+package infrastructure
+
+import (
+	"context"
+	"math/rand"
+	"sync"
+	"time"
+
+	"github.com/fourth-ally/gofetch/domain/models"
+)
+
+// canaryRouter routes a slice of requests to a canary base URL.
+type canaryRouter struct {
+	mu sync.Mutex
+
+	baseURL     string
+	percent     float64
+	headerMatch map[string]string
+	rng         *rand.Rand
+
+	stats models.CanaryStats
+}
+
+// SetCanary routes requests to targetBaseURL when all headerMatch headers
+// are present with the given values, and otherwise sends percent (0-100)
+// of requests there. Pass an empty targetBaseURL to disable canary routing.
+func (c *Client) SetCanary(targetBaseURL string, percent float64, headerMatch map[string]string) *Client {
+	if targetBaseURL == "" {
+		c.canary = nil
+		return c
+	}
+
+	c.canary = &canaryRouter{
+		baseURL:     targetBaseURL,
+		percent:     percent,
+		headerMatch: headerMatch,
+		rng:         rand.New(rand.NewSource(time.Now().UnixNano())),
+	}
+	return c
+}
+
+// CanaryStats returns per-target counters for the current canary rollout.
+func (c *Client) CanaryStats() models.CanaryStats {
+	if c.canary == nil {
+		return models.CanaryStats{}
+	}
+
+	c.canary.mu.Lock()
+	defer c.canary.mu.Unlock()
+	return c.canary.stats
+}
+
+// executeCanary picks the primary or canary target and records the outcome.
+func (c *Client) executeCanary(ctx context.Context, method, path string, params map[string]interface{}, body interface{}, target interface{}, requestConfig *models.Config) (*models.Response, error) {
+	router := c.canary
+	toCanary := router.route(c.mergedConfig(requestConfig).Headers)
+	if toCanary {
+		requestConfig = overrideConfig(requestConfig, &models.Config{BaseURL: router.baseURL})
+	}
+
+	start := time.Now()
+	resp, err := c.executeRouted(ctx, method, path, params, body, target, requestConfig)
+	router.record(toCanary, time.Since(start), err)
+
+	return resp, err
+}
+
+// route decides whether a request with the given headers goes to the canary.
+func (cr *canaryRouter) route(headers map[string]string) bool {
+	if len(cr.headerMatch) > 0 {
+		matched := true
+		for key, value := range cr.headerMatch {
+			if headers[key] != value {
+				matched = false
+				break
+			}
+		}
+		if matched {
+			return true
+		}
+	}
+
+	cr.mu.Lock()
+	defer cr.mu.Unlock()
+	return cr.rng.Float64()*100 < cr.percent
+}
+
+// record updates the counters for the target that served a request.
+func (cr *canaryRouter) record(canary bool, latency time.Duration, err error) {
+	cr.mu.Lock()
+	defer cr.mu.Unlock()
+
+	stats := &cr.stats.Primary
+	if canary {
+		stats = &cr.stats.Canary
+	}
+
+	stats.Requests++
+	stats.TotalLatency += latency
+	if err != nil {
+		stats.Errors++
+	}
+}
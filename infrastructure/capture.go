@@ -0,0 +1,23 @@
+package infrastructure
+
+import (
+	"net/http"
+
+	"github.com/fourth-ally/gofetch/domain/models"
+)
+
+// SetCaptureFilter limits what the capture features record: the request
+// journal (SetJournal) and debug request logging. Requests that don't
+// match the filter are still sent as usual, they just leave no trace, so
+// endpoints handling secrets can be excluded entirely. Pass nil to
+// capture everything again.
+func (c *Client) SetCaptureFilter(filter *models.CaptureFilter) *Client {
+	c.captureFilter = filter
+	return c
+}
+
+// captures reports whether the exchange of req, answered with status
+// (zero if it failed), was sampled and passes the capture filter.
+func (c *Client) captures(req *http.Request, status int) bool {
+	return sampled(req.Context()) && c.captureFilter.Match(req.URL.Host, req.URL.Path, status)
+}
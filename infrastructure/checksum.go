@@ -0,0 +1,116 @@
+package infrastructure
+
+import (
+	"bytes"
+	"encoding/base64"
+	"encoding/hex"
+	"hash"
+	"io"
+	"net/http"
+	"strings"
+
+	"github.com/fourth-ally/gofetch/domain/errors"
+	"github.com/fourth-ally/gofetch/domain/models"
+)
+
+// expectedChecksum is a hash the downloaded content must match.
+type expectedChecksum struct {
+	algorithm models.DigestAlgorithm
+	sum       []byte
+	source    string
+}
+
+// checksumVerifier hashes content as it is written and compares it to
+// the expected checksums afterwards.
+type checksumVerifier struct {
+	expected []expectedChecksum
+	hashes   map[models.DigestAlgorithm]hash.Hash
+}
+
+// optionChecksum decodes the checksum given in options, if any.
+func optionChecksum(options *models.DownloadFileOptions) ([]expectedChecksum, error) {
+	if options.Checksum == nil {
+		return nil, nil
+	}
+
+	sum, err := hex.DecodeString(options.Checksum.Value)
+	if err != nil || newDigestHash(options.Checksum.Algorithm) == nil {
+		return nil, &errors.ConfigError{Field: "Checksum", Message: "expected a hex-encoded md5, sha-256 or sha-512 hash"}
+	}
+	return []expectedChecksum{{algorithm: options.Checksum.Algorithm, sum: sum, source: "option"}}, nil
+}
+
+// headerChecksums returns the digests the server sent for the body of
+// resp. Decompressed bodies no longer match them and return none.
+func headerChecksums(resp *http.Response) []expectedChecksum {
+	if resp.Uncompressed {
+		return nil
+	}
+
+	var checksums []expectedChecksum
+	add := func(header, algorithm, value string) {
+		algorithm = strings.ToLower(strings.TrimSpace(algorithm))
+		sum, err := base64.StdEncoding.DecodeString(strings.TrimSpace(value))
+		if err != nil || newDigestHash(models.DigestAlgorithm(algorithm)) == nil {
+			return
+		}
+		checksums = append(checksums, expectedChecksum{algorithm: models.DigestAlgorithm(algorithm), sum: sum, source: header})
+	}
+
+	// RFC 9530: sha-256=:base64:, sha-512=:base64:
+	for _, field := range strings.Split(resp.Header.Get("Content-Digest"), ",") {
+		if algorithm, value, ok := strings.Cut(field, "="); ok {
+			add("Content-Digest", algorithm, strings.Trim(strings.TrimSpace(value), ":"))
+		}
+	}
+	// RFC 3230: SHA-256=base64, MD5=base64
+	for _, field := range strings.Split(resp.Header.Get("Digest"), ",") {
+		if algorithm, value, ok := strings.Cut(field, "="); ok {
+			add("Digest", algorithm, value)
+		}
+	}
+	if value := resp.Header.Get("Content-MD5"); value != "" {
+		add("Content-MD5", string(models.DigestMD5), value)
+	}
+
+	return checksums
+}
+
+// newChecksumVerifier creates a verifier for expected.
+func newChecksumVerifier(expected []expectedChecksum) *checksumVerifier {
+	v := &checksumVerifier{expected: expected, hashes: make(map[models.DigestAlgorithm]hash.Hash)}
+	for _, checksum := range expected {
+		if _, ok := v.hashes[checksum.algorithm]; !ok {
+			v.hashes[checksum.algorithm] = newDigestHash(checksum.algorithm)
+		}
+	}
+	return v
+}
+
+// writer returns w extended to hash everything written to it.
+func (v *checksumVerifier) writer(w io.Writer) io.Writer {
+	if len(v.hashes) == 0 {
+		return w
+	}
+	writers := []io.Writer{w}
+	for _, h := range v.hashes {
+		writers = append(writers, h)
+	}
+	return io.MultiWriter(writers...)
+}
+
+// verify compares the content written so far to every expected checksum.
+func (v *checksumVerifier) verify() error {
+	for _, checksum := range v.expected {
+		actual := v.hashes[checksum.algorithm].Sum(nil)
+		if !bytes.Equal(actual, checksum.sum) {
+			return &errors.ChecksumMismatchError{
+				Algorithm: string(checksum.algorithm),
+				Expected:  hex.EncodeToString(checksum.sum),
+				Actual:    hex.EncodeToString(actual),
+				Source:    checksum.source,
+			}
+		}
+	}
+	return nil
+}
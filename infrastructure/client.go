@@ -3,12 +3,16 @@ package infrastructure
 import (
 	"bytes"
 	"context"
-	"encoding/json"
+	stderrors "errors"
 	"fmt"
 	"io"
+	"log/slog"
 	"net/http"
-	"net/url"
+	"net/http/httptrace"
+	"reflect"
 	"strings"
+	"sync"
+	"sync/atomic"
 	"time"
 
 	"github.com/fourth-ally/gofetch/domain/contracts"
@@ -18,49 +22,108 @@ import (
 
 // Client is the main HTTP client implementation.
 type Client struct {
-	httpClient           *http.Client
-	config               *models.Config
+	state                atomic.Pointer[clientState]
 	requestInterceptors  []contracts.RequestInterceptor
 	responseInterceptors []contracts.ResponseInterceptor
 	dataTransformer      contracts.DataTransformer
 	uploadProgress       contracts.ProgressCallback
 	downloadProgress     contracts.ProgressCallback
-	retryManager         *RetryManager
+	retryBackoff         contracts.Backoff
 	circuitBreaker       *CircuitBreaker
+	shadow               *models.ShadowOptions
+	canary               *canaryRouter
+	deduplicator         *requestGroup
+	cache                *responseCache
+	tlsCounters          *tlsCounters
+	validatorStore       contracts.CacheStore
+	maxBodyResumes       int
+	pool                 *endpointPool
+	health               *healthChecker
+	bulkhead             *bulkhead
+	rateLimiter          *rateLimiter
+	logger               *slog.Logger
+	idempotencyKeys      bool
+	faults               *faultInjector
+	contentSniffing      bool
+	dialTimeout          time.Duration
+	idleReadTimeout      time.Duration
+	proxies              *proxyFailover
+	fetchOptions         *models.FetchOptions
+	digestAlgorithms     []models.DigestAlgorithm
+	signature            *models.SignatureOptions
+	offlineQueue         *offlineQueue
+	errorBody            *models.ErrorBodyOptions
+	idGenerator          contracts.IDGenerator
+	requestIDHeader      string
+	codec                contracts.Codec
+	codecs               map[string]contracts.Codec
+	unixSocket           string
+	maxHeaderCount       int
+	manualDecode         bool
+	decompressors        []contracts.Decompressor
+	maxResponseSize      int64
+	requestEncoding      string
+	crawler              *crawler
+	jsonImpl             contracts.JSON
+	accept               []string
+	journal              *journal
+	staleGuard           *staleGuard
+	errorType            reflect.Type
+	captureFilter        *models.CaptureFilter
+	queryArrayFormat     models.ArrayFormat
+	legacyURLs           bool
+	urlJoinPolicy        models.URLJoinPolicy
+	resolvers            []contracts.Resolver
+	queryEncoder         contracts.QueryEncoder
+	hostMapping          map[string]string
+	timeLayout           string
+	sniTransports        sync.Map
+	reloadMu             sync.Mutex
+
+	// Names of the interceptors, parallel to the slices above. Shorter
+	// when trailing interceptors were added without a name.
+	requestInterceptorNames  []string
+	responseInterceptorNames []string
 }
 
 // NewClient creates a new GoFetch client instance.
 func NewClient() *Client {
-	return &Client{
-		httpClient:           &http.Client{Timeout: 30 * time.Second},
-		config:               models.NewConfig(),
+	c := &Client{
 		requestInterceptors:  make([]contracts.RequestInterceptor, 0),
 		responseInterceptors: make([]contracts.ResponseInterceptor, 0),
+		tlsCounters:          &tlsCounters{},
 	}
+	c.state.Store(&clientState{
+		config:     models.NewConfig(),
+		httpClient: &http.Client{Timeout: 30 * time.Second, CheckRedirect: checkRedirect},
+	})
+	return c
 }
 
 // SetBaseURL sets the base URL for all requests.
+// It replaces any failover list configured with SetBaseURLs.
 func (c *Client) SetBaseURL(baseURL string) *Client {
-	c.config.BaseURL = baseURL
+	c.state.Load().config.BaseURL = baseURL
+	c.state.Load().config.BaseURLs = nil
 	return c
 }
 
 // SetTimeout sets the timeout for requests.
 func (c *Client) SetTimeout(timeout time.Duration) *Client {
-	c.config.Timeout = timeout
-	c.httpClient.Timeout = timeout
+	c.state.Load().config.Timeout = timeout
+	c.state.Load().httpClient.Timeout = timeout
 	return c
 }
 
 // SetHeader sets a default header for all requests.
 func (c *Client) SetHeader(key, value string) *Client {
-	c.config.Headers[key] = value
+	c.state.Load().config.Headers[key] = value
 	return c
 }
 
 // SetStatusValidator sets a custom status validator function.
 func (c *Client) SetStatusValidator(validator func(int) bool) *Client {
-	c.config.StatusValidator = validator
+	c.state.Load().config.StatusValidator = validator
 	return c
 }
 
@@ -96,8 +159,12 @@ func (c *Client) SetDownloadProgress(callback contracts.ProgressCallback) *Clien
 
 // SetRetryOptions configures retry behavior for the client.
 func (c *Client) SetRetryOptions(options *models.RetryOptions) *Client {
-	c.config.RetryOptions = options
-	c.retryManager = NewRetryManager(options)
+	c.state.Load().config.RetryOptions = options
+	retryManager := NewRetryManager(options)
+	retryManager.backoff = c.retryBackoff
+	state := *c.state.Load()
+	state.retryManager = retryManager
+	c.state.Store(&state)
 
 	// Initialize circuit breaker if enabled
 	if options != nil && options.CircuitBreaker {
@@ -113,76 +180,286 @@ func (c *Client) SetRetryOptions(options *models.RetryOptions) *Client {
 	return c
 }
 
+// SetHedging enables hedged requests for idempotent methods.
+// Pass nil to disable hedging.
+func (c *Client) SetHedging(options *models.HedgingOptions) *Client {
+	c.state.Load().config.HedgingOptions = options
+	return c
+}
+
 // NewInstance creates a new client instance inheriting all settings from the current client.
 func (c *Client) NewInstance() *Client {
 	newClient := &Client{
-		httpClient:           &http.Client{Timeout: c.config.Timeout},
-		config:               c.config.Clone(),
 		requestInterceptors:  make([]contracts.RequestInterceptor, len(c.requestInterceptors)),
 		responseInterceptors: make([]contracts.ResponseInterceptor, len(c.responseInterceptors)),
 		dataTransformer:      c.dataTransformer,
 		uploadProgress:       c.uploadProgress,
 		downloadProgress:     c.downloadProgress,
-		retryManager:         c.retryManager,
+		retryBackoff:         c.retryBackoff,
 		circuitBreaker:       c.circuitBreaker,
+		shadow:               c.shadow,
+		canary:               c.canary,
+		deduplicator:         c.deduplicator,
+		cache:                c.cache,
+		tlsCounters:          c.tlsCounters,
+		validatorStore:       c.validatorStore,
+		maxBodyResumes:       c.maxBodyResumes,
+		pool:                 c.pool,
+		health:               c.health,
+		bulkhead:             c.bulkhead,
+		rateLimiter:          c.rateLimiter,
+		logger:               c.logger,
+		idempotencyKeys:      c.idempotencyKeys,
+		faults:               c.faults,
+		contentSniffing:      c.contentSniffing,
+		dialTimeout:          c.dialTimeout,
+		idleReadTimeout:      c.idleReadTimeout,
+		proxies:              c.proxies,
+		fetchOptions:         c.fetchOptions,
+		digestAlgorithms:     c.digestAlgorithms,
+		signature:            c.signature,
+		offlineQueue:         c.offlineQueue,
+		errorBody:            c.errorBody,
+		idGenerator:          c.idGenerator,
+		requestIDHeader:      c.requestIDHeader,
+		codec:                c.codec,
+		codecs:               c.codecs,
+		unixSocket:           c.unixSocket,
+		maxHeaderCount:       c.maxHeaderCount,
+		manualDecode:         c.manualDecode,
+		decompressors:        c.decompressors,
+		maxResponseSize:      c.maxResponseSize,
+		requestEncoding:      c.requestEncoding,
+		crawler:              c.crawler,
+		jsonImpl:             c.jsonImpl,
+		accept:               c.accept,
+		journal:              c.journal,
+		staleGuard:           c.staleGuard,
+		errorType:            c.errorType,
+		captureFilter:        c.captureFilter,
+		queryArrayFormat:     c.queryArrayFormat,
+		legacyURLs:           c.legacyURLs,
+		urlJoinPolicy:        c.urlJoinPolicy,
+		resolvers:            c.resolvers,
+		queryEncoder:         c.queryEncoder,
+		hostMapping:          c.hostMapping,
+		timeLayout:           c.timeLayout,
 	}
 
+	state := c.state.Load()
+	newClient.state.Store(&clientState{
+		config:       state.config.Clone(),
+		httpClient:   &http.Client{Timeout: state.config.Timeout, Transport: cloneTransport(state.httpClient.Transport), CheckRedirect: state.httpClient.CheckRedirect},
+		retryManager: state.retryManager,
+	})
+
 	copy(newClient.requestInterceptors, c.requestInterceptors)
 	copy(newClient.responseInterceptors, c.responseInterceptors)
+	newClient.requestInterceptorNames = append([]string(nil), c.requestInterceptorNames...)
+	newClient.responseInterceptorNames = append([]string(nil), c.responseInterceptorNames...)
 
 	return newClient
 }
 
+// mergedConfig returns the client configuration with per-request overrides applied.
+func (c *Client) mergedConfig(requestConfig *models.Config) *models.Config {
+	return c.state.Load().merged(requestConfig)
+}
+
+// httpClientFor returns the HTTP client of state to use, honouring a
+// per-request timeout and TLS server name.
+func (c *Client) httpClientFor(state *clientState, requestConfig *models.Config) *http.Client {
+	base := state.httpClient
+	if requestConfig == nil {
+		return base
+	}
+
+	timeout := requestConfig.Timeout > 0 && requestConfig.Timeout != base.Timeout
+	if !timeout && requestConfig.ServerName == "" {
+		return base
+	}
+
+	httpClient := *base
+	if timeout {
+		httpClient.Timeout = requestConfig.Timeout
+	}
+	if requestConfig.ServerName != "" {
+		httpClient.Transport = c.sniTransport(base.Transport, requestConfig.ServerName)
+	}
+	return &httpClient
+}
+
+// overrideConfig layers override on top of an optional per-request config.
+func overrideConfig(requestConfig *models.Config, override *models.Config) *models.Config {
+	if requestConfig == nil {
+		return override
+	}
+	return requestConfig.Merge(override)
+}
+
 // buildURL constructs the full URL from base URL, path, and parameters.
-func (c *Client) buildURL(path string, params map[string]interface{}) (string, error) {
-	// Start with base URL or empty string
-	fullURL := c.config.BaseURL
+// Path parameter values are escaped so they stay within their segment.
+func (c *Client) buildURL(baseURL, path string, params map[string]interface{}) (string, error) {
+	if c.legacyURLs {
+		return c.buildLegacyURL(baseURL, path, params), nil
+	}
 
-	// Handle path parameters (e.g., /users/:id)
+	// Expand URI templates (e.g., /users/{id}/posts{?page,limit})
 	processedPath := path
-	queryParams := url.Values{}
-
-	if params != nil {
-		for key, value := range params {
-			placeholder := ":" + key
-			if strings.Contains(processedPath, placeholder) {
-				// Replace path parameter
-				processedPath = strings.Replace(processedPath, placeholder, fmt.Sprintf("%v", value), -1)
-			} else {
-				// Add to query string
-				queryParams.Add(key, fmt.Sprintf("%v", value))
-			}
+	var templateVars map[string]bool
+	if strings.Contains(path, "{") {
+		expanded, used, err := expandURITemplate(path, params, c.timeLayout)
+		if err != nil {
+			return "", &errors.URLError{URL: path, Reason: "invalid URI template", Err: err}
 		}
+		processedPath, templateVars = expanded, used
 	}
 
-	// Combine base URL and path
-	if fullURL != "" && processedPath != "" {
-		fullURL = strings.TrimRight(fullURL, "/") + "/" + strings.TrimLeft(processedPath, "/")
-	} else if processedPath != "" {
-		fullURL = processedPath
+	// Handle path parameters (e.g., /users/:id), longest names first so
+	// :id doesn't replace the start of :identifier
+	queryParams := make(map[string]interface{})
+	for _, key := range sortedParamKeys(params) {
+		if templateVars[key] {
+			continue
+		}
+
+		placeholder := ":" + key
+		if !strings.Contains(processedPath, placeholder) {
+			queryParams[key] = params[key]
+			continue
+		}
+
+		var err error
+		processedPath, err = substitutePathParam(processedPath, key, formatParam(params[key], c.timeLayout))
+		if err != nil {
+			return "", err
+		}
 	}
 
-	// Add query parameters
-	if len(queryParams) > 0 {
-		fullURL += "?" + queryParams.Encode()
+	fullURL, err := joinURL(baseURL, processedPath, c.urlJoinPolicy)
+	if err != nil {
+		return "", err
+	}
+
+	// Add query parameters, after any query the path already has
+	query, err := c.encodeQuery(queryParams)
+	if err != nil {
+		return "", &errors.URLError{URL: path, Reason: "failed to encode query", Err: err}
+	}
+	if query != "" {
+		separator := "?"
+		if strings.Contains(fullURL, "?") {
+			separator = "&"
+		}
+		fullURL += separator + query
 	}
 
 	return fullURL, nil
 }
 
+// execute is the entry point for all HTTP methods. It serves cached responses
+// or issues conditional requests, deduplicates and routes the request, runs it through the retry pipeline and
+// mirrors it to the shadow backend if configured.
+func (c *Client) execute(ctx context.Context, method, path string, params map[string]interface{}, body interface{}, target interface{}, requestConfig *models.Config) (*models.Response, error) {
+	requestConfig = withContextOverrides(ctx, requestConfig)
+
+	// Use the same settings for every stage, even if the client is reloaded
+	ctx = c.withState(ctx)
+
+	// Unread bodies can't be cached
+	if isRawResponse(requestConfig) {
+		return c.executeUncached(ctx, method, path, params, body, target, requestConfig)
+	}
+
+	if c.cache == nil {
+		if c.validatorStore != nil && method == http.MethodGet {
+			return c.executeConditional(ctx, method, path, params, target, requestConfig)
+		}
+		return c.executeUncached(ctx, method, path, params, body, target, requestConfig)
+	}
+
+	if method == http.MethodGet {
+		return c.executeCached(ctx, method, path, params, target, requestConfig)
+	}
+
+	resp, err := c.executeUncached(ctx, method, path, params, body, target, requestConfig)
+	if err == nil && method != http.MethodHead && method != http.MethodOptions {
+		c.invalidateCache(path, params, requestConfig)
+	}
+	return resp, err
+}
+
+// executeUncached runs a request that bypasses the response cache.
+func (c *Client) executeUncached(ctx context.Context, method, path string, params map[string]interface{}, body interface{}, target interface{}, requestConfig *models.Config) (*models.Response, error) {
+	body, err := prepareBody(body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read request body: %w", err)
+	}
+
+	if c.deduplicator != nil && method == http.MethodGet && !isRawResponse(requestConfig) {
+		return c.executeDeduplicated(ctx, method, path, params, target, requestConfig)
+	}
+
+	requestConfig = c.withRequestID(requestConfig)
+
+	requestConfig = c.withIdempotencyKey(method, requestConfig)
+
+	if c.offlineQueue == nil {
+		return c.dispatch(ctx, method, path, params, body, target, requestConfig)
+	}
+
+	if isQueueable(method) && !isStreamBody(body) {
+		return c.executeQueueable(ctx, method, path, params, body, target, requestConfig)
+	}
+
+	resp, err := c.dispatch(ctx, method, path, params, body, target, requestConfig)
+	if err == nil {
+		c.flushOfflineQueueInBackground()
+	}
+	return resp, err
+}
+
+// dispatch picks the target for a request, applying canary routing if configured.
+func (c *Client) dispatch(ctx context.Context, method, path string, params map[string]interface{}, body interface{}, target interface{}, requestConfig *models.Config) (*models.Response, error) {
+	if c.canary != nil {
+		return c.executeCanary(ctx, method, path, params, body, target, requestConfig)
+	}
+
+	return c.executeRouted(ctx, method, path, params, body, target, requestConfig)
+}
+
+// executeRouted runs a request whose target has already been decided.
+func (c *Client) executeRouted(ctx context.Context, method, path string, params map[string]interface{}, body interface{}, target interface{}, requestConfig *models.Config) (*models.Response, error) {
+	resp, err := c.executeWithFailover(ctx, method, path, params, body, target, requestConfig)
+
+	if c.shadow != nil {
+		c.mirror(ctx, method, path, params, body, requestConfig, resp, err)
+	}
+
+	return resp, err
+}
+
 // executeRequestWithRetry wraps executeRequest with retry logic and circuit breaker.
 func (c *Client) executeRequestWithRetry(ctx context.Context, method, path string, params map[string]interface{}, body interface{}, target interface{}, requestConfig *models.Config) (*models.Response, error) {
 	// Check if retries or circuit breaker are configured
-	hasRetries := c.retryManager != nil && c.config.RetryOptions != nil && c.config.RetryOptions.MaxRetries > 0
+	state := c.stateFor(ctx)
+	retryManager := state.retryManager
+	retryOptions := state.config.RetryOptions
+	if requestConfig != nil && requestConfig.RetryOptions != nil {
+		retryOptions = requestConfig.RetryOptions
+		retryManager = NewRetryManager(retryOptions)
+		retryManager.backoff = c.retryBackoff
+	}
+	hasRetries := retryManager != nil && retryOptions != nil && retryOptions.MaxRetries > 0
 	hasCircuitBreaker := c.circuitBreaker != nil
 
 	// If neither retry nor circuit breaker is configured, execute directly
 	if !hasRetries && !hasCircuitBreaker {
-		return c.executeRequest(ctx, method, path, params, body, target, requestConfig)
+		return c.executeAttempt(ctx, method, path, params, body, target, requestConfig)
 	}
 
 	// Build URL for circuit breaker endpoint tracking
-	fullURL, err := c.buildURL(path, params)
+	fullURL, err := c.buildURL(state.merged(requestConfig).BaseURL, path, params)
 	if err != nil {
 		return nil, fmt.Errorf("failed to build URL: %w", err)
 	}
@@ -190,28 +467,31 @@ func (c *Client) executeRequestWithRetry(ctx context.Context, method, path strin
 	// Check circuit breaker before attempting
 	if hasCircuitBreaker {
 		if c.circuitBreaker.IsOpen(fullURL) {
-			return nil, fmt.Errorf("circuit breaker is open for endpoint: %s", fullURL)
+			return nil, &errors.CircuitOpenError{Endpoint: fullURL}
 		}
 
 		if !c.circuitBreaker.CanAttempt(fullURL) {
-			return nil, fmt.Errorf("circuit breaker: too many requests in half-open state for: %s", fullURL)
+			return nil, &errors.CircuitOpenError{Endpoint: fullURL, HalfOpen: true}
 		}
 	}
 
 	// Determine max attempts (at least 1 even if no retries)
 	maxAttempts := 0
 	if hasRetries {
-		maxAttempts = c.config.RetryOptions.MaxRetries
+		maxAttempts = retryOptions.MaxRetries
 	}
 
 	var lastErr error
 	var lastResponse *models.Response
 	var lastStatusCode int
+	var delay time.Duration
 
 	// Retry loop
 	for attempt := 0; attempt <= maxAttempts; attempt++ {
 		// Execute request
-		resp, err := c.executeRequest(ctx, method, path, params, body, target, requestConfig)
+		attemptStart := time.Now()
+		resp, err := c.executeAttempt(ctx, method, path, params, body, target, requestConfig)
+		attemptDuration := time.Since(attemptStart)
 
 		// Success case
 		if err == nil && (resp == nil || resp.StatusCode < 500) {
@@ -249,22 +529,60 @@ func (c *Client) executeRequestWithRetry(ctx context.Context, method, path strin
 			return resp, err
 		}
 
-		// Check if we should retry
-		shouldRetry := c.retryManager.ShouldRetry(attempt, lastStatusCode, err)
+		// Check if we should retry; a streamed body that can't be rewound
+		// was consumed by the failed attempt
+		shouldRetry := retryManager.ShouldRetry(attempt, lastStatusCode, err)
+		if stream, ok := body.(*streamBody); ok && !stream.replayable() {
+			shouldRetry = false
+		}
+		var robotsErr *errors.RobotsDisallowedError
+		if stderrors.As(err, &robotsErr) {
+			shouldRetry = false
+		}
 
 		// Don't retry on last attempt or if not retryable
-		if !shouldRetry || attempt == c.config.RetryOptions.MaxRetries {
+		if !shouldRetry || attempt == retryOptions.MaxRetries {
 			break
 		}
 
-		// Wait before retry (with backoff and jitter)
-		c.retryManager.Wait(attempt)
+		c.loggerFor(ctx).DebugContext(ctx, "gofetch: retrying request",
+			"method", method, "path", path, "attempt", attempt+1, "status", lastStatusCode)
+
+		// Give up early if the backoff plus another attempt like the last
+		// one can't finish before the context deadline
+		delay = retryManager.NextDelay(attempt, delay)
+		if retryOptions.RespectRateLimits {
+			if wait, limited := rateLimitDelay(failedHeaders(resp, err)); limited {
+				if wait > retryOptions.MaxDelay {
+					break
+				}
+				delay = wait
+			}
+		}
+		if deadline, ok := ctx.Deadline(); ok {
+			if remaining := time.Until(deadline); delay+attemptDuration > remaining {
+				return lastResponse, &errors.RetryAbortedError{
+					Reason:    "deadline",
+					Attempts:  attempt + 1,
+					Delay:     delay,
+					Remaining: remaining,
+					Err:       lastErr,
+				}
+			}
+		}
 
-		// Check context cancellation
+		// Release an unread body of the response being retried
+		if resp != nil && resp.Body != nil {
+			resp.Body.Close()
+		}
+
+		// Wait before retry (with backoff and jitter)
+		timer := time.NewTimer(delay)
 		select {
 		case <-ctx.Done():
+			timer.Stop()
 			return nil, fmt.Errorf("request cancelled during retry: %w", ctx.Err())
-		default:
+		case <-timer.C:
 			// Continue to next attempt
 		}
 	}
@@ -273,69 +591,119 @@ func (c *Client) executeRequestWithRetry(ctx context.Context, method, path strin
 	return lastResponse, lastErr
 }
 
+// failedHeaders returns the headers of a failed attempt, if it got a response.
+func failedHeaders(resp *models.Response, err error) http.Header {
+	var httpErr *errors.HTTPError
+	if stderrors.As(err, &httpErr) {
+		return httpErr.Headers
+	}
+	if resp != nil {
+		return resp.Headers
+	}
+	return nil
+}
+
+// executeAttempt performs a single logical attempt, hedging it when configured.
+func (c *Client) executeAttempt(ctx context.Context, method, path string, params map[string]interface{}, body interface{}, target interface{}, requestConfig *models.Config) (*models.Response, error) {
+	hedging := c.stateFor(ctx).config.HedgingOptions
+	if requestConfig != nil && requestConfig.HedgingOptions != nil {
+		hedging = requestConfig.HedgingOptions
+	}
+
+	// Concurrent hedges would interleave writes to a body tee or read
+	// a streamed body at the same time, and losing hedges would leak
+	// unread bodies
+	teed := requestConfig != nil && len(requestConfig.BodyTee) > 0
+
+	if hedging != nil && isIdempotent(method) && !teed && !isStreamBody(body) && !isRawResponse(requestConfig) {
+		return c.executeHedged(ctx, hedging, method, path, params, body, target, requestConfig)
+	}
+
+	return c.executeRequest(ctx, method, path, params, body, target, requestConfig)
+}
+
 // executeRequest executes an HTTP request with all interceptors and error handling.
 func (c *Client) executeRequest(ctx context.Context, method, path string, params map[string]interface{}, body interface{}, target interface{}, requestConfig *models.Config) (*models.Response, error) {
 	// Merge configurations
-	config := c.config
-	if requestConfig != nil {
-		config = c.config.Merge(requestConfig)
-	}
+	state := c.stateFor(ctx)
+	config := state.merged(requestConfig)
 
-	// Build URL
-	fullURL, err := c.buildURL(path, params)
-	if err != nil {
-		return nil, fmt.Errorf("failed to build URL: %w", err)
+	// Decide once whether observability features run for the request
+	ctx = withSamplingDecision(ctx, config)
+
+	// Run caller trace hooks alongside the timing collection
+	if config.ClientTrace != nil && sampled(ctx) {
+		ctx = httptrace.WithClientTrace(ctx, config.ClientTrace)
 	}
 
-	// Prepare request body
-	var bodyReader io.Reader
-	if body != nil {
-		jsonData, err := json.Marshal(body)
-		if err != nil {
-			return nil, fmt.Errorf("failed to marshal request body: %w", err)
+	// Collect phase timings for the response
+	timing := newTimingCollector()
+	ctx = httptrace.WithClientTrace(ctx, timing.trace())
+	ctx = withTimingCollector(ctx, timing)
+
+	// Record the redirect chain for the response
+	ctx, redirects := withRedirectRecorder(ctx)
+
+	// Release resources on return, unless the body is handed to the
+	// caller in raw mode, whose Close then releases them
+	var cleanups cleanupStack
+	handedOff := false
+	defer func() {
+		if !handedOff {
+			cleanups.run()
 		}
-		bodyReader = bytes.NewBuffer(jsonData)
+	}()
 
-		// Wrap with progress tracking if callback is set
-		if c.uploadProgress != nil {
-			bodyReader = &progressReader{
-				reader:   bodyReader,
-				total:    int64(len(jsonData)),
-				callback: c.uploadProgress,
-			}
-		}
+	// Allow stalled bodies to abort the request
+	var cancelIdle context.CancelFunc
+	if c.idleReadTimeout > 0 {
+		ctx, cancelIdle = context.WithCancel(ctx)
+		cleanups.push(cancelIdle)
 	}
 
-	// Create request
-	req, err := http.NewRequestWithContext(ctx, method, fullURL, bodyReader)
+	req, err := c.buildRequest(ctx, method, path, params, body, config)
 	if err != nil {
-		return nil, fmt.Errorf("failed to create request: %w", err)
+		return nil, err
 	}
 
-	// Set default headers
-	for key, value := range config.Headers {
-		req.Header.Set(key, value)
+	// Respect client-wide and per-host rate limits
+	if err := c.rateLimiter.wait(ctx, req.URL.Host); err != nil {
+		return nil, fmt.Errorf("rate limit wait cancelled: %w", err)
 	}
 
-	// Set content type for body requests
-	if body != nil && req.Header.Get("Content-Type") == "" {
-		req.Header.Set("Content-Type", "application/json")
+	// Space out requests per host and honor robots.txt when crawling
+	releaseCrawl, err := c.crawler.acquire(ctx, c, req)
+	if err != nil {
+		return nil, err
 	}
+	cleanups.push(releaseCrawl)
 
-	// Apply request interceptors
-	for _, interceptor := range c.requestInterceptors {
-		req, err = interceptor(req)
-		if err != nil {
-			return nil, fmt.Errorf("request interceptor error: %w", err)
-		}
+	// Wait for a free slot if concurrency is limited
+	release, err := c.bulkhead.acquire(ctx, req.URL.Host, requestPriority(config))
+	if err != nil {
+		return nil, err
 	}
+	cleanups.push(release)
+
+	// Make sure a non-idempotent request doesn't land on a stale connection
+	c.staleGuard.prepare(ctx, c, req)
 
 	// Execute request
-	resp, err := c.httpClient.Do(req)
+	started := time.Now()
+	resp, err := c.proxies.do(req, func(req *http.Request) (*http.Response, error) {
+		return c.faults.do(req, c.httpClientFor(state, requestConfig).Do)
+	})
+	c.logRoundTrip(ctx, req, resp, started, err)
+	c.staleGuard.done(req)
 	if err != nil {
-		return nil, fmt.Errorf("request execution error: %w", err)
+		return nil, fmt.Errorf("request execution error: %w", errors.ClassifyTransportError(c.headerSizeError(err)))
+	}
+	cleanups.pushCloser(resp.Body)
+
+	// Reject responses with too many header fields before reading the body
+	if err := c.checkHeaderCount(resp); err != nil {
+		return nil, err
 	}
-	defer resp.Body.Close()
 
 	// Apply response interceptors
 	for _, interceptor := range c.responseInterceptors {
@@ -345,26 +713,101 @@ func (c *Client) executeRequest(ctx context.Context, method, path string, params
 		}
 	}
 
+	// Abort the request if the body stalls
+	if cancelIdle != nil {
+		body := newIdleTimeoutBody(resp.Body, c.idleReadTimeout, cancelIdle)
+		resp.Body = body
+		cleanups.pushCloser(body)
+	}
+
+	// Resume interrupted bodies with Range requests if enabled
+	if body := c.newResumableBody(ctx, req, resp); body != resp.Body {
+		resp.Body = body
+		cleanups.pushCloser(body)
+	}
+
+	// Decode content encodings the transport doesn't handle
+	decompressed, err := c.decompressBody(resp)
+	if err != nil {
+		return nil, err
+	}
+	if decompressed != nil {
+		cleanups.pushCloser(decompressed)
+	}
+
+	// Cap rejected bodies instead of buffering them whole
+	if c.errorBody != nil && !config.StatusValidator(resp.StatusCode) {
+		return nil, c.decodeError(c.readErrorBody(resp), config)
+	}
+
+	// Hand accepted bodies to the caller unread in raw mode
+	if config.RawResponse && config.StatusValidator(resp.StatusCode) {
+		response := models.NewResponse(resp.StatusCode, resp.Header, nil, nil)
+		response.BaseURL = config.BaseURL
+		response.Decompressed = resp.Uncompressed
+		response.Redirects = redirects.redirects()
+		response.Timings = timing.finish()
+		response.Body = &rawBody{reader: resp.Body, cleanups: cleanups}
+		handedOff = true
+		return response, nil
+	}
+
+	// Refuse bodies larger than the configured limit
+	var respReader io.Reader = resp.Body
+	if c.maxResponseSize > 0 {
+		if resp.ContentLength > c.maxResponseSize {
+			return nil, &errors.ResponseTooLargeError{Limit: c.maxResponseSize, ContentLength: resp.ContentLength}
+		}
+		respReader = &sizeLimitedReader{reader: respReader, limit: c.maxResponseSize}
+	}
+
+	// Copy accepted bodies to any tee writers while reading
+	var tee *teeReader
+	if len(config.BodyTee) > 0 && config.StatusValidator(resp.StatusCode) {
+		tee = &teeReader{reader: respReader, writer: io.MultiWriter(config.BodyTee...)}
+		respReader = tee
+	}
+
 	// Read response body with progress tracking
 	var respBody []byte
 	if c.downloadProgress != nil && resp.ContentLength > 0 {
 		progressReader := &progressReader{
-			reader:   resp.Body,
+			reader:   respReader,
 			total:    resp.ContentLength,
 			callback: c.downloadProgress,
 		}
 		respBody, err = io.ReadAll(progressReader)
 	} else {
-		respBody, err = io.ReadAll(resp.Body)
+		respBody, err = io.ReadAll(respReader)
+	}
+
+	if tee != nil && tee.err != nil {
+		return nil, fmt.Errorf("failed to write response body to tee: %w", tee.err)
 	}
 
 	if err != nil {
-		return nil, fmt.Errorf("failed to read response body: %w", err)
+		var tooLarge *errors.ResponseTooLargeError
+		if stderrors.As(err, &tooLarge) {
+			return nil, tooLarge
+		}
+		return nil, fmt.Errorf("failed to read response body: %w", errors.ClassifyTransportError(err))
+	}
+
+	// Record the exchange for later replay
+	if c.captures(req, resp.StatusCode) {
+		c.journal.record(req, resp.StatusCode, respBody)
 	}
 
 	// Validate status code
 	if !config.StatusValidator(resp.StatusCode) {
-		return nil, errors.NewHTTPError(resp, respBody, "")
+		return nil, c.decodeError(errors.NewHTTPError(resp, respBody, ""), config)
+	}
+
+	// Guard against bodies that don't match their declared type
+	if c.contentSniffing {
+		if err := checkContentType(resp.Header.Get("Content-Type"), respBody); err != nil {
+			return nil, err
+		}
 	}
 
 	// Apply data transformer if set
@@ -376,41 +819,238 @@ func (c *Client) executeRequest(ctx context.Context, method, path string, params
 	}
 
 	// Unmarshal response into target if provided
-	if target != nil && len(respBody) > 0 {
-		if err := json.Unmarshal(respBody, target); err != nil {
-			return nil, fmt.Errorf("failed to unmarshal response: %w", err)
+	if err := c.unmarshalTarget(respBody, resp.Header, target); err != nil {
+		return nil, err
+	}
+
+	response := models.NewResponse(resp.StatusCode, resp.Header, target, respBody)
+	response.BaseURL = config.BaseURL
+	response.Decompressed = resp.Uncompressed
+	response.Decoder = c.responseCodec(resp.Header).Unmarshal
+	response.Redirects = redirects.redirects()
+	response.Timings = timing.finish()
+	c.tlsCounters.record(response.Timings)
+
+	return response, nil
+}
+
+// buildRequest creates the HTTP request for a call: URL, encoded body,
+// headers, request interceptors and signature, ready to be sent.
+func (c *Client) buildRequest(ctx context.Context, method, path string, params map[string]interface{}, body interface{}, config *models.Config) (*http.Request, error) {
+	// Build URL
+	fullURL, err := c.buildURL(config.BaseURL, path, params)
+	if err != nil {
+		return nil, fmt.Errorf("failed to build URL: %w", err)
+	}
+
+	// Prepare request body
+	var bodyReader io.Reader
+	var bodyData []byte
+	var contentType string
+	bodySize := int64(-1)
+	stream, streamed := body.(*streamBody)
+	if streamed {
+		bodyReader, err = stream.open()
+		if err != nil {
+			return nil, err
+		}
+		bodySize = stream.size
+		contentType = binaryContentType
+		if stream.contentType != "" {
+			contentType = stream.contentType
+		}
+	} else if body != nil {
+		bodyData, contentType, err = c.encodeBody(body)
+		if err != nil {
+			return nil, fmt.Errorf("failed to marshal request body: %w", err)
+		}
+		if c.requestEncoding != "" && len(bodyData) > 0 {
+			if bodyData, err = c.compressBody(bodyData); err != nil {
+				return nil, err
+			}
+		}
+		bodyReader = bytes.NewBuffer(bodyData)
+		bodySize = int64(len(bodyData))
+	}
+
+	// Wrap with progress tracking if callback is set
+	if bodyReader != nil && c.uploadProgress != nil {
+		bodyReader = &progressReader{
+			reader:   bodyReader,
+			total:    bodySize,
+			callback: c.uploadProgress,
+		}
+	}
+
+	// Create request
+	req, err := http.NewRequestWithContext(ctx, method, fullURL, bodyReader)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create request: %w", err)
+	}
+
+	// Allow the body to be re-read, e.g. for signing or redirects. Streamed
+	// bodies of unknown size are sent chunked.
+	switch {
+	case bodyData != nil:
+		req.ContentLength = int64(len(bodyData))
+		req.GetBody = func() (io.ReadCloser, error) {
+			return io.NopCloser(bytes.NewReader(bodyData)), nil
+		}
+	case streamed:
+		req.ContentLength = max(bodySize, 0)
+		if bodySize == 0 {
+			req.Body = http.NoBody
+		}
+		if stream.replayable() {
+			req.GetBody = func() (io.ReadCloser, error) {
+				reader, err := stream.open()
+				if err != nil {
+					return nil, err
+				}
+				return readCloser(reader), nil
+			}
 		}
 	}
 
-	return models.NewResponse(resp.StatusCode, resp.Header, target, respBody), nil
+	// Set default headers
+	for key, value := range config.Headers {
+		req.Header.Set(key, value)
+	}
+	if config.HostOverride != "" {
+		req.Host = config.HostOverride
+	}
+
+	// Override content negotiation if requested
+	if encoding := acceptEncoding(ctx, config); encoding != "" {
+		req.Header.Set("Accept-Encoding", encoding)
+	} else if len(c.decompressors) > 0 {
+		req.Header.Set("Accept-Encoding", c.acceptedEncodings())
+	}
+
+	// Ask for the codec's format unless the caller chose one
+	if c.codec != nil && req.Header.Get("Accept") == "" {
+		req.Header.Set("Accept", c.codec.ContentType())
+	}
+
+	// Set content type for body requests
+	if body != nil && req.Header.Get("Content-Type") == "" {
+		req.Header.Set("Content-Type", resolveContentType(config.ContentType, contentType))
+	}
+
+	// Label compressed bodies
+	if c.requestEncoding != "" && len(bodyData) > 0 {
+		req.Header.Set("Content-Encoding", c.requestEncoding)
+	}
+
+	// Hash the body for servers that require integrity headers, rewinding
+	// streamed bodies read to compute it
+	if len(c.digestAlgorithms) > 0 {
+		switch {
+		case bodyData != nil:
+			contentDigestHeaders(req.Header, bytes.NewReader(bodyData), c.digestAlgorithms)
+		case streamed && stream.replayable():
+			if err := streamDigestHeaders(req, stream, c.digestAlgorithms); err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	// Apply request interceptors
+	for _, interceptor := range c.requestInterceptors {
+		req, err = interceptor(req)
+		if err != nil {
+			return nil, fmt.Errorf("request interceptor error: %w", err)
+		}
+	}
+
+	// Reduce cross-origin requests to simple requests if configured
+	if c.fetchOptions != nil && c.fetchOptions.SimpleHeadersOnly {
+		simplifyHeaders(req.Header)
+	}
+
+	// Fail early on requests the browser would preflight if asked to
+	if c.fetchOptions != nil && c.fetchOptions.ValidateCORS {
+		if err := validateCORS(req, c.fetchOptions); err != nil {
+			return nil, err
+		}
+	}
+
+	// Sign the final request
+	if c.signature != nil {
+		if err := signRequest(req, c.signature); err != nil {
+			return nil, err
+		}
+
+		// Rewind a streamed body read to compute the signed digest
+		if streamed && stream.replayable() {
+			if _, err := stream.open(); err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	return req, nil
+}
+
+// unmarshalTarget decodes the response body into target if both are
+// present, using the codec registered for the response Content-Type.
+// *models.Text targets receive the body as is.
+func (c *Client) unmarshalTarget(respBody []byte, headers http.Header, target interface{}) error {
+	if target == nil || len(respBody) == 0 || c.manualDecode {
+		return nil
+	}
+
+	if text, ok := target.(*models.Text); ok {
+		*text = models.Text(respBody)
+		return nil
+	}
+
+	if err := c.responseCodec(headers).Unmarshal(respBody, target); err != nil {
+		return &errors.DecodeError{Err: err}
+	}
+
+	return nil
 }
 
-// Get performs a GET request.
-func (c *Client) Get(ctx context.Context, path string, params map[string]interface{}, target interface{}) (*models.Response, error) {
-	return c.executeRequestWithRetry(ctx, http.MethodGet, path, params, nil, target, nil)
+// Get performs a GET request. Options override the client
+// configuration for this request only.
+func (c *Client) Get(ctx context.Context, path string, params map[string]interface{}, target interface{}, opts ...RequestOption) (*models.Response, error) {
+	return c.executeWithOptions(ctx, http.MethodGet, path, params, nil, target, opts)
 }
 
-// Post performs a POST request.
-func (c *Client) Post(ctx context.Context, path string, params map[string]interface{}, body interface{}, target interface{}) (*models.Response, error) {
-	return c.executeRequestWithRetry(ctx, http.MethodPost, path, params, body, target, nil)
+// Post performs a POST request. Options override the client
+// configuration for this request only.
+func (c *Client) Post(ctx context.Context, path string, params map[string]interface{}, body interface{}, target interface{}, opts ...RequestOption) (*models.Response, error) {
+	return c.executeWithOptions(ctx, http.MethodPost, path, params, body, target, opts)
 }
 
 // Config returns the client configuration for testing purposes.
 func (c *Client) Config() *models.Config {
-	return c.config
+	return c.state.Load().config
+}
+
+// Put performs a PUT request. Options override the client
+// configuration for this request only.
+func (c *Client) Put(ctx context.Context, path string, params map[string]interface{}, body interface{}, target interface{}, opts ...RequestOption) (*models.Response, error) {
+	return c.executeWithOptions(ctx, http.MethodPut, path, params, body, target, opts)
 }
 
-// Put performs a PUT request.
-func (c *Client) Put(ctx context.Context, path string, params map[string]interface{}, body interface{}, target interface{}) (*models.Response, error) {
-	return c.executeRequestWithRetry(ctx, http.MethodPut, path, params, body, target, nil)
+// Patch performs a PATCH request. Options override the client
+// configuration for this request only.
+func (c *Client) Patch(ctx context.Context, path string, params map[string]interface{}, body interface{}, target interface{}, opts ...RequestOption) (*models.Response, error) {
+	return c.executeWithOptions(ctx, http.MethodPatch, path, params, body, target, opts)
 }
 
-// Patch performs a PATCH request.
-func (c *Client) Patch(ctx context.Context, path string, params map[string]interface{}, body interface{}, target interface{}) (*models.Response, error) {
-	return c.executeRequestWithRetry(ctx, http.MethodPatch, path, params, body, target, nil)
+// Delete performs a DELETE request. Options override the client
+// configuration for this request only.
+func (c *Client) Delete(ctx context.Context, path string, params map[string]interface{}, target interface{}, opts ...RequestOption) (*models.Response, error) {
+	return c.executeWithOptions(ctx, http.MethodDelete, path, params, nil, target, opts)
 }
 
-// Delete performs a DELETE request.
-func (c *Client) Delete(ctx context.Context, path string, params map[string]interface{}, target interface{}) (*models.Response, error) {
-	return c.executeRequestWithRetry(ctx, http.MethodDelete, path, params, nil, target, nil)
+// Do performs a request with any method, such as the WebDAV PROPFIND and
+// REPORT methods or PURGE for caching proxies. Methods are case-sensitive
+// and sent as given. Options override the client configuration for this
+// request only.
+func (c *Client) Do(ctx context.Context, method, path string, params map[string]interface{}, body interface{}, target interface{}, opts ...RequestOption) (*models.Response, error) {
+	return c.executeWithOptions(ctx, method, path, params, body, target, opts)
 }
@@ -0,0 +1,139 @@
+package infrastructure
+
+import (
+	"encoding/json"
+	"mime"
+	"net/http"
+
+	"github.com/fourth-ally/gofetch/domain/contracts"
+)
+
+// JSONCodec encodes and decodes JSON. It is the default codec.
+var JSONCodec contracts.Codec = jsonCodec{}
+
+// jsonCodec implements contracts.Codec with a JSON implementation,
+// encoding/json unless one was set with SetJSON.
+type jsonCodec struct {
+	impl contracts.JSON
+}
+
+// ContentType implements contracts.Codec.
+func (jsonCodec) ContentType() string { return jsonContentType }
+
+// Marshal implements contracts.Codec.
+func (c jsonCodec) Marshal(v interface{}) ([]byte, error) {
+	if c.impl == nil {
+		return json.Marshal(v)
+	}
+	return c.impl.Marshal(v)
+}
+
+// Unmarshal implements contracts.Codec.
+func (c jsonCodec) Unmarshal(data []byte, v interface{}) error {
+	if c.impl == nil {
+		return json.Unmarshal(data, v)
+	}
+	return c.impl.Unmarshal(data, v)
+}
+
+// JSONFuncs adapts a pair of package-level functions, such as go-json's
+// Marshal and Unmarshal, to contracts.JSON. Implementations exposing
+// methods, like jsoniter.ConfigCompatibleWithStandardLibrary or
+// sonic.ConfigStd, can be passed to SetJSON directly.
+type JSONFuncs struct {
+	MarshalFunc   func(v interface{}) ([]byte, error)
+	UnmarshalFunc func(data []byte, v interface{}) error
+}
+
+// Marshal implements contracts.JSON.
+func (f JSONFuncs) Marshal(v interface{}) ([]byte, error) { return f.MarshalFunc(v) }
+
+// Unmarshal implements contracts.JSON.
+func (f JSONFuncs) Unmarshal(data []byte, v interface{}) error { return f.UnmarshalFunc(data, v) }
+
+// SetJSON replaces encoding/json wherever the client encodes or decodes
+// JSON: request bodies, responses, Response.Decode and the Docker and
+// Kubernetes streams. DecodeJSON and StreamJSONArray keep using the
+// streaming decoder of encoding/json. Pass nil to go back to encoding/json.
+func (c *Client) SetJSON(impl contracts.JSON) *Client {
+	c.jsonImpl = impl
+	return c
+}
+
+// SetCodec encodes request bodies with codec and asks for its media type
+// in the Accept header unless one is set. Responses declaring the codec's
+// media type are decoded with it; others fall back to their registered
+// codec or JSON. Pass nil to go back to JSON.
+func (c *Client) SetCodec(codec contracts.Codec) *Client {
+	c.codec = codec
+	if codec != nil {
+		c.RegisterCodec(codec)
+	}
+	return c
+}
+
+// RegisterCodec decodes responses whose Content-Type is the codec's media
+// type, or one of mediaTypes if given, with codec.
+func (c *Client) RegisterCodec(codec contracts.Codec, mediaTypes ...string) *Client {
+	if len(mediaTypes) == 0 {
+		mediaTypes = []string{codec.ContentType()}
+	}
+
+	codecs := make(map[string]contracts.Codec, len(c.codecs)+len(mediaTypes))
+	for mediaType, registered := range c.codecs {
+		codecs[mediaType] = registered
+	}
+	for _, mediaType := range mediaTypes {
+		codecs[baseMediaType(mediaType)] = codec
+	}
+
+	c.codecs = codecs
+	return c
+}
+
+// SetAutoDecode controls whether responses are decoded into the target
+// passed to request methods. When disabled, the target is left untouched
+// and callers decode Response.RawBody explicitly with Response.Decode,
+// e.g. to measure decoding separately or pick the type after inspecting
+// the status. Enabled by default.
+func (c *Client) SetAutoDecode(enabled bool) *Client {
+	c.manualDecode = !enabled
+	return c
+}
+
+// requestCodec returns the codec for request bodies.
+func (c *Client) requestCodec() contracts.Codec {
+	if c.codec == nil {
+		return c.jsonCodec()
+	}
+	return c.codec
+}
+
+// responseCodec returns the codec for a response with the given headers.
+func (c *Client) responseCodec(headers http.Header) contracts.Codec {
+	mediaType := baseMediaType(headers.Get("Content-Type"))
+	if codec, ok := c.codecs[mediaType]; ok {
+		return codec
+	}
+	if codec := c.negotiatedCodec(mediaType); codec != nil {
+		return codec
+	}
+	return c.jsonCodec()
+}
+
+// jsonCodec returns the codec for JSON bodies.
+func (c *Client) jsonCodec() contracts.Codec {
+	if c.jsonImpl == nil {
+		return JSONCodec
+	}
+	return jsonCodec{impl: c.jsonImpl}
+}
+
+// baseMediaType strips parameters from a Content-Type value.
+func baseMediaType(contentType string) string {
+	mediaType, _, err := mime.ParseMediaType(contentType)
+	if err != nil {
+		return contentType
+	}
+	return mediaType
+}
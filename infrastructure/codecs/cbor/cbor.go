@@ -0,0 +1,37 @@
+// Package cbor provides a CBOR (RFC 8949) codec for use with
+// Client.SetCodec, for IoT and COSE-adjacent APIs.
+package cbor
+
+import (
+	"github.com/fxamacker/cbor/v2"
+
+	"github.com/fourth-ally/gofetch/domain/contracts"
+)
+
+// ContentType is the media type of CBOR bodies.
+const ContentType = "application/cbor"
+
+// codec implements contracts.Codec with CBOR (RFC 8949).
+type codec struct{}
+
+// New returns a CBOR codec. Struct fields are named by their cbor tags,
+// falling back to json tags, so the same types can be exchanged as JSON
+// or CBOR.
+func New() contracts.Codec {
+	return codec{}
+}
+
+// ContentType implements contracts.Codec.
+func (codec) ContentType() string {
+	return ContentType
+}
+
+// Marshal implements contracts.Codec.
+func (codec) Marshal(v interface{}) ([]byte, error) {
+	return cbor.Marshal(v)
+}
+
+// Unmarshal implements contracts.Codec.
+func (codec) Unmarshal(data []byte, v interface{}) error {
+	return cbor.Unmarshal(data, v)
+}
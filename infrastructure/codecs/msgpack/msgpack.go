@@ -0,0 +1,47 @@
+// Package msgpack provides a MessagePack codec for use with
+// Client.SetCodec and Client.RegisterCodec. It reads json struct tags, so
+// existing JSON types can be sent as MessagePack unchanged.
+package msgpack
+
+import (
+	"bytes"
+
+	"github.com/vmihailenco/msgpack/v5"
+
+	"github.com/fourth-ally/gofetch/domain/contracts"
+)
+
+// ContentType is the media type of MessagePack bodies.
+const ContentType = "application/msgpack"
+
+// codec implements contracts.Codec with MessagePack.
+type codec struct{}
+
+// New returns a MessagePack codec. Struct fields are named by their json
+// tags, so the same types can be exchanged as JSON or MessagePack.
+func New() contracts.Codec {
+	return codec{}
+}
+
+// ContentType implements contracts.Codec.
+func (codec) ContentType() string {
+	return ContentType
+}
+
+// Marshal implements contracts.Codec.
+func (codec) Marshal(v interface{}) ([]byte, error) {
+	var buf bytes.Buffer
+	encoder := msgpack.NewEncoder(&buf)
+	encoder.SetCustomStructTag("json")
+	if err := encoder.Encode(v); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+// Unmarshal implements contracts.Codec.
+func (codec) Unmarshal(data []byte, v interface{}) error {
+	decoder := msgpack.NewDecoder(bytes.NewReader(data))
+	decoder.SetCustomStructTag("json")
+	return decoder.Decode(v)
+}
@@ -0,0 +1,60 @@
+package protobuf
+
+import (
+	"fmt"
+
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protodesc"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/types/descriptorpb"
+	"google.golang.org/protobuf/types/dynamicpb"
+	"google.golang.org/protobuf/types/known/anypb"
+
+	"github.com/fourth-ally/gofetch/domain/contracts"
+)
+
+// Types holds message types loaded from a descriptor set, for decoding
+// responses of services without generated Go types, e.g. in CLIs and
+// gateways.
+type Types struct {
+	types *dynamicpb.Types
+}
+
+// LoadDescriptorSet loads the types of a serialized FileDescriptorSet, as
+// written by protoc --descriptor_set_out. The set must be self-contained;
+// build it with --include_imports.
+func LoadDescriptorSet(data []byte) (*Types, error) {
+	var set descriptorpb.FileDescriptorSet
+	if err := proto.Unmarshal(data, &set); err != nil {
+		return nil, fmt.Errorf("protobuf: invalid descriptor set: %w", err)
+	}
+
+	files, err := protodesc.NewFiles(&set)
+	if err != nil {
+		return nil, fmt.Errorf("protobuf: invalid descriptor set: %w", err)
+	}
+	return &Types{types: dynamicpb.NewTypes(files)}, nil
+}
+
+// NewMessage returns an empty dynamic message of the named type, e.g.
+// "acme.v1.User", to pass as a response target.
+func (t *Types) NewMessage(fullName string) (*dynamicpb.Message, error) {
+	messageType, err := t.types.FindMessageByName(protoreflect.FullName(fullName))
+	if err != nil {
+		return nil, fmt.Errorf("protobuf: unknown message type %q: %w", fullName, err)
+	}
+	return dynamicpb.NewMessage(messageType.Descriptor()), nil
+}
+
+// UnpackAny decodes the message held by packed into a dynamic message of
+// the type named by its type URL.
+func (t *Types) UnpackAny(packed *anypb.Any) (proto.Message, error) {
+	return anypb.UnmarshalNew(packed, proto.UnmarshalOptions{Resolver: t.types})
+}
+
+// NewWithTypes returns a protobuf codec that resolves extension fields
+// against types when decoding. Targets can be generated or dynamic
+// messages.
+func NewWithTypes(types *Types) contracts.Codec {
+	return codec{unmarshal: proto.UnmarshalOptions{Resolver: types.types}}
+}
@@ -0,0 +1,50 @@
+// Package protobuf provides a Protocol Buffers codec for use with
+// Client.SetCodec, for gRPC-gateway and Twirp style endpoints. Bodies and
+// targets are proto.Message values; messages only known at run time can
+// be decoded from their descriptors.
+package protobuf
+
+import (
+	"fmt"
+
+	"google.golang.org/protobuf/proto"
+
+	"github.com/fourth-ally/gofetch/domain/contracts"
+)
+
+// ContentType is the media type of protobuf bodies.
+const ContentType = "application/x-protobuf"
+
+// codec implements contracts.Codec with binary protobuf encoding.
+type codec struct {
+	unmarshal proto.UnmarshalOptions
+}
+
+// New returns a protobuf codec. Bodies and targets must be proto.Message
+// values.
+func New() contracts.Codec {
+	return codec{}
+}
+
+// ContentType implements contracts.Codec.
+func (codec) ContentType() string {
+	return ContentType
+}
+
+// Marshal implements contracts.Codec.
+func (codec) Marshal(v interface{}) ([]byte, error) {
+	message, ok := v.(proto.Message)
+	if !ok {
+		return nil, fmt.Errorf("protobuf: %T is not a proto.Message", v)
+	}
+	return proto.Marshal(message)
+}
+
+// Unmarshal implements contracts.Codec.
+func (c codec) Unmarshal(data []byte, v interface{}) error {
+	message, ok := v.(proto.Message)
+	if !ok {
+		return fmt.Errorf("protobuf: %T is not a proto.Message", v)
+	}
+	return c.unmarshal.Unmarshal(data, message)
+}
@@ -0,0 +1,36 @@
+package infrastructure
+
+import (
+	"sync"
+
+	"github.com/fourth-ally/gofetch/domain/models"
+)
+
+// MemoryCollectionStore is an in-memory implementation of
+// contracts.CollectionStore. The snapshot is lost when the process exits.
+type MemoryCollectionStore struct {
+	mu       sync.Mutex
+	snapshot *models.CollectionSnapshot
+}
+
+// NewMemoryCollectionStore creates an empty in-memory collection store.
+func NewMemoryCollectionStore() *MemoryCollectionStore {
+	return &MemoryCollectionStore{}
+}
+
+// Load returns the stored snapshot, or nil before the first sync.
+func (s *MemoryCollectionStore) Load() (*models.CollectionSnapshot, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return s.snapshot, nil
+}
+
+// Save replaces the stored snapshot.
+func (s *MemoryCollectionStore) Save(snapshot *models.CollectionSnapshot) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.snapshot = snapshot
+	return nil
+}
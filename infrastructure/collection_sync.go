@@ -0,0 +1,231 @@
+package infrastructure
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	stderrors "errors"
+	"fmt"
+	"maps"
+	"net/http"
+	"slices"
+	"time"
+
+	"github.com/fourth-ally/gofetch/domain/contracts"
+	"github.com/fourth-ally/gofetch/domain/errors"
+	"github.com/fourth-ally/gofetch/domain/models"
+)
+
+// SyncCollection brings the local snapshot of the collection at path in
+// store up to date and returns what changed, in a building block for
+// offline-capable apps. Once the endpoint has handed out a delta token,
+// only changes since the last sync are requested; a 410 Gone for an
+// expired token falls back to a full listing. Full listings are
+// revalidated with If-None-Match, so an unchanged collection costs a 304
+// and yields no events, and are diffed against the snapshot. The snapshot
+// is saved only when the sync succeeds. Pass nil options for a plain
+// array of items identified by "id".
+func (c *Client) SyncCollection(ctx context.Context, path string, store contracts.CollectionStore, options *models.SyncOptions) ([]models.CollectionEvent, error) {
+	if options == nil {
+		options = models.NewSyncOptions()
+	}
+
+	snapshot, err := store.Load()
+	if err != nil {
+		return nil, fmt.Errorf("failed to load collection snapshot: %w", err)
+	}
+	if snapshot == nil {
+		snapshot = &models.CollectionSnapshot{}
+	}
+
+	delta := options.DeltaParam != "" && snapshot.DeltaToken != ""
+	resp, err := c.fetchCollection(ctx, path, snapshot, options, delta)
+	var httpErr *errors.HTTPError
+	if delta && stderrors.As(err, &httpErr) && httpErr.StatusCode == http.StatusGone {
+		delta = false
+		resp, err = c.fetchCollection(ctx, path, &models.CollectionSnapshot{}, options, false)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	next := &models.CollectionSnapshot{
+		Items:      maps.Clone(snapshot.Items),
+		ETag:       snapshot.ETag,
+		DeltaToken: snapshot.DeltaToken,
+		SyncedAt:   time.Now(),
+	}
+	if next.Items == nil {
+		next.Items = make(map[string]json.RawMessage)
+	}
+
+	var events []models.CollectionEvent
+	if resp.StatusCode != http.StatusNotModified {
+		items, token, err := parseCollection(resp.RawBody, options)
+		if err != nil {
+			return nil, err
+		}
+		if delta {
+			events, err = applyDelta(next.Items, items, options)
+		} else {
+			events, err = replaceCollection(next.Items, items, options)
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		next.ETag = resp.Headers.Get("ETag")
+		if delta && next.ETag == "" {
+			next.ETag = snapshot.ETag
+		}
+		next.DeltaToken = token
+	}
+
+	if err := store.Save(next); err != nil {
+		return nil, fmt.Errorf("failed to save collection snapshot: %w", err)
+	}
+	return events, nil
+}
+
+// fetchCollection requests the changes since snapshot, or the revalidated
+// full listing.
+func (c *Client) fetchCollection(ctx context.Context, path string, snapshot *models.CollectionSnapshot, options *models.SyncOptions, delta bool) (*models.Response, error) {
+	if delta {
+		params := map[string]interface{}{options.DeltaParam: snapshot.DeltaToken}
+		return c.executeUncached(ctx, http.MethodGet, path, params, nil, nil, nil)
+	}
+
+	var config *models.Config
+	if snapshot.ETag != "" {
+		validators := http.Header{}
+		validators.Set("ETag", snapshot.ETag)
+		config = conditionalConfig(nil, c.state.Load().config.StatusValidator, validators)
+	}
+	return c.executeUncached(ctx, http.MethodGet, path, nil, nil, nil, config)
+}
+
+// parseCollection extracts the items and any delta token from a body.
+func parseCollection(body []byte, options *models.SyncOptions) ([]json.RawMessage, string, error) {
+	var items []json.RawMessage
+	if options.ItemsField == "" {
+		if err := json.Unmarshal(body, &items); err != nil {
+			return nil, "", &errors.DecodeError{Err: err}
+		}
+		return items, "", nil
+	}
+
+	var envelope map[string]json.RawMessage
+	if err := json.Unmarshal(body, &envelope); err != nil {
+		return nil, "", &errors.DecodeError{Err: err}
+	}
+	if raw, ok := envelope[options.ItemsField]; ok {
+		if err := json.Unmarshal(raw, &items); err != nil {
+			return nil, "", &errors.DecodeError{Err: err}
+		}
+	}
+
+	var token string
+	if raw, ok := envelope[options.DeltaTokenField]; ok && options.DeltaTokenField != "" {
+		json.Unmarshal(raw, &token)
+	}
+	return items, token, nil
+}
+
+// replaceCollection makes stored match a full listing and reports the
+// differences, ordered by ID with deletions last.
+func replaceCollection(stored map[string]json.RawMessage, items []json.RawMessage, options *models.SyncOptions) ([]models.CollectionEvent, error) {
+	listed := make(map[string]json.RawMessage, len(items))
+	for _, item := range items {
+		id, _, err := collectionItemID(item, options)
+		if err != nil {
+			return nil, err
+		}
+		listed[id] = compactJSON(item)
+	}
+
+	var events []models.CollectionEvent
+	for _, id := range slices.Sorted(maps.Keys(listed)) {
+		if event, changed := upsertItem(stored, id, listed[id]); changed {
+			events = append(events, event)
+		}
+	}
+	for _, id := range slices.Sorted(maps.Keys(stored)) {
+		if _, ok := listed[id]; !ok {
+			events = append(events, models.CollectionEvent{Type: models.CollectionItemDeleted, ID: id, Item: stored[id]})
+			delete(stored, id)
+		}
+	}
+	return events, nil
+}
+
+// applyDelta applies the changes of a delta response to stored, in order.
+func applyDelta(stored map[string]json.RawMessage, items []json.RawMessage, options *models.SyncOptions) ([]models.CollectionEvent, error) {
+	var events []models.CollectionEvent
+	for _, item := range items {
+		id, fields, err := collectionItemID(item, options)
+		if err != nil {
+			return nil, err
+		}
+
+		if deleted, ok := fields[options.DeletedField]; ok && options.DeletedField != "" && !isJSONFalsy(deleted) {
+			if previous, exists := stored[id]; exists {
+				events = append(events, models.CollectionEvent{Type: models.CollectionItemDeleted, ID: id, Item: previous})
+				delete(stored, id)
+			}
+			continue
+		}
+
+		if event, changed := upsertItem(stored, id, compactJSON(item)); changed {
+			events = append(events, event)
+		}
+	}
+	return events, nil
+}
+
+// upsertItem stores item under id and reports the change, if any.
+func upsertItem(stored map[string]json.RawMessage, id string, item json.RawMessage) (models.CollectionEvent, bool) {
+	previous, exists := stored[id]
+	if exists && bytes.Equal(previous, item) {
+		return models.CollectionEvent{}, false
+	}
+
+	stored[id] = item
+	if exists {
+		return models.CollectionEvent{Type: models.CollectionItemUpdated, ID: id, Item: item}, true
+	}
+	return models.CollectionEvent{Type: models.CollectionItemAdded, ID: id, Item: item}, true
+}
+
+// collectionItemID returns the ID of item and its decoded fields.
+func collectionItemID(item json.RawMessage, options *models.SyncOptions) (string, map[string]json.RawMessage, error) {
+	var fields map[string]json.RawMessage
+	if err := json.Unmarshal(item, &fields); err != nil {
+		return "", nil, &errors.DecodeError{Err: err}
+	}
+
+	raw, ok := fields[options.IDField]
+	if !ok {
+		return "", nil, fmt.Errorf("collection item has no %q field: %s", options.IDField, item)
+	}
+
+	var id string
+	if err := json.Unmarshal(raw, &id); err != nil {
+		id = string(compactJSON(raw))
+	}
+	return id, fields, nil
+}
+
+// compactJSON strips insignificant whitespace so equal items compare equal.
+func compactJSON(data json.RawMessage) json.RawMessage {
+	var buf bytes.Buffer
+	if err := json.Compact(&buf, data); err != nil {
+		return data
+	}
+	return buf.Bytes()
+}
+
+// isJSONFalsy reports whether raw is false or null.
+func isJSONFalsy(raw json.RawMessage) bool {
+	value := string(compactJSON(raw))
+	return value == "false" || value == "null"
+}
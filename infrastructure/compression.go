@@ -0,0 +1,69 @@
+package infrastructure
+
+import (
+	"bytes"
+	"fmt"
+
+	"github.com/fourth-ally/gofetch/domain/contracts"
+	"github.com/fourth-ally/gofetch/domain/errors"
+)
+
+// RegisterCompressor adds a compression codec, such as those in the
+// infrastructure/compression packages, to the client's registry. It
+// decodes responses sent with the codec's Content-Encoding like
+// RegisterDecompressor, and makes the encoding available to
+// SetRequestCompression. gzip is always available.
+func (c *Client) RegisterCompressor(compressor contracts.Compressor) *Client {
+	return c.RegisterDecompressor(compressor)
+}
+
+// SetRequestCompression compresses request bodies with the registered
+// codec for encoding, e.g. "gzip" or "zstd", and sends them with a
+// matching Content-Encoding header. Content digests and signatures cover
+// the compressed body. Streamed bodies are sent as is. Requests fail with
+// *errors.ConfigError if no compressor is registered for encoding. An
+// empty encoding turns request compression off.
+func (c *Client) SetRequestCompression(encoding string) *Client {
+	c.requestEncoding = encoding
+	return c
+}
+
+// compressor returns the registered compressor for encoding, or nil.
+func (c *Client) compressor(encoding string) contracts.Compressor {
+	for _, decompressor := range c.decompressors {
+		if compressor, ok := decompressor.(contracts.Compressor); ok && compressor.Encoding() == encoding {
+			return compressor
+		}
+	}
+
+	if encoding == (gzipCompressor{}).Encoding() {
+		return gzipCompressor{}
+	}
+	return nil
+}
+
+// compressBody encodes data with the request compression codec.
+func (c *Client) compressBody(data []byte) ([]byte, error) {
+	compressor := c.compressor(c.requestEncoding)
+	if compressor == nil {
+		return nil, &errors.ConfigError{
+			Field:   "RequestCompression",
+			Message: fmt.Sprintf("no compressor registered for %q", c.requestEncoding),
+		}
+	}
+
+	var buf bytes.Buffer
+	writer, err := compressor.NewWriter(&buf)
+	if err != nil {
+		return nil, fmt.Errorf("failed to compress request body: %w", err)
+	}
+	if _, err := writer.Write(data); err != nil {
+		writer.Close()
+		return nil, fmt.Errorf("failed to compress request body: %w", err)
+	}
+	if err := writer.Close(); err != nil {
+		return nil, fmt.Errorf("failed to compress request body: %w", err)
+	}
+
+	return buf.Bytes(), nil
+}
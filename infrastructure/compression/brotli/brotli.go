@@ -0,0 +1,38 @@
+// Package brotli provides a Brotli ("br") compressor for
+// Client.RegisterCompressor, for decoding the Brotli responses many CDNs
+// prefer and for compressing request bodies with SetRequestCompression.
+package brotli
+
+import (
+	"io"
+
+	"github.com/andybalholm/brotli"
+
+	"github.com/fourth-ally/gofetch/domain/contracts"
+)
+
+// Encoding is the Content-Encoding token of Brotli bodies.
+const Encoding = "br"
+
+// compressor implements contracts.Compressor with Brotli (RFC 7932).
+type compressor struct{}
+
+// New returns a Brotli compressor.
+func New() contracts.Compressor {
+	return compressor{}
+}
+
+// Encoding implements contracts.Decompressor.
+func (compressor) Encoding() string {
+	return Encoding
+}
+
+// NewReader implements contracts.Decompressor.
+func (compressor) NewReader(r io.Reader) (io.ReadCloser, error) {
+	return io.NopCloser(brotli.NewReader(r)), nil
+}
+
+// NewWriter implements contracts.Compressor.
+func (compressor) NewWriter(w io.Writer) (io.WriteCloser, error) {
+	return brotli.NewWriter(w), nil
+}
@@ -0,0 +1,40 @@
+// Package snappy provides a Snappy compressor for use with
+// Client.RegisterCompressor, for internal services that exchange bodies
+// in the Snappy framing format under the unregistered "snappy"
+// Content-Encoding.
+package snappy
+
+import (
+	"io"
+
+	"github.com/klauspost/compress/snappy"
+
+	"github.com/fourth-ally/gofetch/domain/contracts"
+)
+
+// Encoding is the Content-Encoding token of Snappy bodies. It is not
+// registered with IANA, so both sides must agree on it.
+const Encoding = "snappy"
+
+// compressor implements contracts.Compressor with the Snappy framing format.
+type compressor struct{}
+
+// New returns a Snappy compressor.
+func New() contracts.Compressor {
+	return compressor{}
+}
+
+// Encoding implements contracts.Decompressor.
+func (compressor) Encoding() string {
+	return Encoding
+}
+
+// NewReader implements contracts.Decompressor.
+func (compressor) NewReader(r io.Reader) (io.ReadCloser, error) {
+	return io.NopCloser(snappy.NewReader(r)), nil
+}
+
+// NewWriter implements contracts.Compressor.
+func (compressor) NewWriter(w io.Writer) (io.WriteCloser, error) {
+	return snappy.NewBufferedWriter(w), nil
+}
@@ -0,0 +1,47 @@
+// Package zstd provides a Zstandard ("zstd") compressor for
+// Client.RegisterCompressor. Decoding is limited to the 8 MiB window
+// RFC 9659 allows HTTP senders, so a hostile response can't make the
+// decoder allocate more.
+package zstd
+
+import (
+	"io"
+
+	"github.com/klauspost/compress/zstd"
+
+	"github.com/fourth-ally/gofetch/domain/contracts"
+)
+
+// Encoding is the Content-Encoding token of Zstandard bodies.
+const Encoding = "zstd"
+
+// maxWindowSize caps the window at the 8 MiB RFC 9659 requires HTTP
+// senders to stay within, bounding decoder memory per stream.
+const maxWindowSize = 8 << 20
+
+// compressor implements contracts.Compressor with Zstandard (RFC 8878).
+type compressor struct{}
+
+// New returns a Zstandard compressor.
+func New() contracts.Compressor {
+	return compressor{}
+}
+
+// Encoding implements contracts.Decompressor.
+func (compressor) Encoding() string {
+	return Encoding
+}
+
+// NewReader implements contracts.Decompressor.
+func (compressor) NewReader(r io.Reader) (io.ReadCloser, error) {
+	decoder, err := zstd.NewReader(r, zstd.WithDecoderConcurrency(1), zstd.WithDecoderMaxWindow(maxWindowSize))
+	if err != nil {
+		return nil, err
+	}
+	return decoder.IOReadCloser(), nil
+}
+
+// NewWriter implements contracts.Compressor.
+func (compressor) NewWriter(w io.Writer) (io.WriteCloser, error) {
+	return zstd.NewWriter(w, zstd.WithEncoderConcurrency(1), zstd.WithWindowSize(maxWindowSize))
+}
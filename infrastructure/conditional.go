@@ -0,0 +1,70 @@
+package infrastructure
+
+import (
+	"context"
+	"net/http"
+	"time"
+
+	"github.com/fourth-ally/gofetch/domain/contracts"
+	"github.com/fourth-ally/gofetch/domain/models"
+)
+
+// SetConditionalRequests enables automatic conditional GETs. Responses
+// carrying an ETag or Last-Modified header are kept in store, later requests
+// for the same URL send If-None-Match/If-Modified-Since, and a 304 Not
+// Modified is answered with the stored body. Unlike SetCache, every request
+// reaches the server. Pass nil to disable.
+func (c *Client) SetConditionalRequests(store contracts.CacheStore) *Client {
+	c.validatorStore = store
+	return c
+}
+
+// executeConditional performs a GET revalidating any stored representation.
+func (c *Client) executeConditional(ctx context.Context, method, path string, params map[string]interface{}, target interface{}, requestConfig *models.Config) (*models.Response, error) {
+	config := c.mergedConfig(requestConfig)
+	fullURL, err := c.buildURL(config.BaseURL, path, params)
+	if err != nil {
+		return c.executeUncached(ctx, method, path, params, nil, target, requestConfig)
+	}
+
+	key := cacheKey(fullURL)
+	entry, found := c.validatorStore.Get(key)
+
+	networkConfig := requestConfig
+	if found {
+		networkConfig = conditionalConfig(requestConfig, config.StatusValidator, entry.Headers)
+	}
+
+	resp, err := c.executeUncached(ctx, method, path, params, nil, nil, networkConfig)
+	if err != nil {
+		return resp, err
+	}
+
+	if found && resp.StatusCode == http.StatusNotModified {
+		if err := c.unmarshalTarget(entry.Body, entry.Headers, target); err != nil {
+			return nil, err
+		}
+
+		notModified := models.NewResponse(entry.StatusCode, entry.Headers, target, entry.Body)
+		notModified.Timings = resp.Timings
+		notModified.FromCache = true
+		notModified.Decoder = c.responseCodec(entry.Headers).Unmarshal
+		return notModified, nil
+	}
+
+	if resp.StatusCode == http.StatusOK && hasValidators(resp.Headers) {
+		c.validatorStore.Set(key, &models.CacheEntry{
+			StatusCode: resp.StatusCode,
+			Headers:    resp.Headers.Clone(),
+			Body:       resp.RawBody,
+			StoredAt:   time.Now(),
+		})
+	}
+
+	if err := c.unmarshalTarget(resp.RawBody, resp.Headers, target); err != nil {
+		return nil, err
+	}
+	resp.Data = target
+
+	return resp, nil
+}
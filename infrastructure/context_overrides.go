@@ -0,0 +1,66 @@
+package infrastructure
+
+import (
+	"context"
+	"time"
+
+	"github.com/fourth-ally/gofetch/domain/models"
+)
+
+// contextOverridesKey is the context key for per-request overrides.
+type contextOverridesKey struct{}
+
+// contextOverrides holds the overrides carried by a context.
+type contextOverrides struct {
+	headers map[string]string
+	timeout time.Duration
+}
+
+// WithHeaderContext returns a context that sets header key to value on
+// requests made with it, overriding client defaults. It lets frameworks
+// that only pass contexts through their layers add headers such as a
+// tenant or trace ID. Calls accumulate; per-request options take
+// precedence.
+func WithHeaderContext(ctx context.Context, key, value string) context.Context {
+	overrides := overridesFrom(ctx)
+
+	headers := make(map[string]string, len(overrides.headers)+1)
+	for k, v := range overrides.headers {
+		headers[k] = v
+	}
+	headers[key] = value
+	overrides.headers = headers
+
+	return context.WithValue(ctx, contextOverridesKey{}, overrides)
+}
+
+// WithTimeoutContext returns a context that replaces the client timeout
+// for requests made with it. Unlike context.WithTimeout, the timeout
+// applies to each request separately, and may exceed the client default.
+// Per-request options take precedence.
+func WithTimeoutContext(ctx context.Context, timeout time.Duration) context.Context {
+	overrides := overridesFrom(ctx)
+	overrides.timeout = timeout
+	return context.WithValue(ctx, contextOverridesKey{}, overrides)
+}
+
+// overridesFrom returns a copy of the overrides carried by ctx.
+func overridesFrom(ctx context.Context) contextOverrides {
+	overrides, _ := ctx.Value(contextOverridesKey{}).(contextOverrides)
+	return overrides
+}
+
+// withContextOverrides layers the overrides carried by ctx beneath the
+// per-request config.
+func withContextOverrides(ctx context.Context, requestConfig *models.Config) *models.Config {
+	overrides := overridesFrom(ctx)
+	if len(overrides.headers) == 0 && overrides.timeout <= 0 {
+		return requestConfig
+	}
+
+	contextConfig := &models.Config{Headers: overrides.headers, Timeout: overrides.timeout}
+	if requestConfig == nil {
+		return contextConfig
+	}
+	return contextConfig.Merge(requestConfig)
+}
@@ -0,0 +1,216 @@
+package infrastructure
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"sync"
+	"time"
+
+	"github.com/fourth-ally/gofetch/domain/errors"
+	"github.com/fourth-ally/gofetch/domain/models"
+)
+
+// crawler enforces a crawl policy per host.
+type crawler struct {
+	options *models.CrawlOptions
+
+	mu    sync.Mutex
+	hosts map[string]*crawlHost
+}
+
+// crawlHost is the crawl state of one host.
+type crawlHost struct {
+	slots chan struct{}
+
+	mu     sync.Mutex
+	next   time.Time
+	robots *robotsEntry
+}
+
+// robotsEntry is a cached robots.txt, fetched once by the first request
+// that needs it while the others wait.
+type robotsEntry struct {
+	ready   chan struct{}
+	rules   *robotsRules
+	err     error
+	expires time.Time
+}
+
+// SetCrawlPolicy makes the client a polite crawler: requests to the same
+// host are spaced at least options.Delay apart (or the host's robots.txt
+// Crawl-delay, if larger), at most options.MaxPerHost run at once, and,
+// with RespectRobots, paths disallowed by the host's cached robots.txt
+// fail with *errors.RobotsDisallowedError without being sent. A robots.txt
+// that is missing (4xx) allows everything; one that can't be fetched fails
+// the request and is tried again on the next one. options.UserAgent is
+// set as the User-Agent header. Pass nil to remove the policy.
+func (c *Client) SetCrawlPolicy(options *models.CrawlOptions) *Client {
+	if options == nil {
+		c.crawler = nil
+		return c
+	}
+
+	if options.UserAgent != "" {
+		c.SetHeader("User-Agent", options.UserAgent)
+	}
+	c.crawler = &crawler{
+		options: options,
+		hosts:   make(map[string]*crawlHost),
+	}
+	return c
+}
+
+// acquire waits until the policy admits req. The returned function
+// releases its per-host slot.
+func (k *crawler) acquire(ctx context.Context, c *Client, req *http.Request) (func(), error) {
+	if k == nil {
+		return func() {}, nil
+	}
+
+	host := k.host(req.URL.Host)
+	delay := k.options.Delay
+
+	if k.options.RespectRobots && req.URL.Path != "/robots.txt" {
+		rules, err := k.robots(ctx, c, host, req.URL)
+		if err != nil {
+			return nil, err
+		}
+		if !rules.allowed(req.URL.RequestURI()) {
+			return nil, &errors.RobotsDisallowedError{URL: req.URL.String(), UserAgent: k.options.UserAgent}
+		}
+		delay = max(delay, rules.crawlDelay)
+	}
+
+	if host.slots != nil {
+		select {
+		case host.slots <- struct{}{}:
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		}
+	}
+	release := func() {
+		if host.slots != nil {
+			<-host.slots
+		}
+	}
+
+	// Reserve the next start time for the host
+	host.mu.Lock()
+	start := time.Now()
+	if host.next.After(start) {
+		start = host.next
+	}
+	host.next = start.Add(delay)
+	host.mu.Unlock()
+
+	if wait := time.Until(start); wait > 0 {
+		timer := time.NewTimer(wait)
+		defer timer.Stop()
+		select {
+		case <-timer.C:
+		case <-ctx.Done():
+			release()
+			return nil, ctx.Err()
+		}
+	}
+
+	return release, nil
+}
+
+// host returns the crawl state of host, creating it if needed.
+func (k *crawler) host(name string) *crawlHost {
+	k.mu.Lock()
+	defer k.mu.Unlock()
+
+	host, ok := k.hosts[name]
+	if !ok {
+		host = &crawlHost{}
+		if k.options.MaxPerHost > 0 {
+			host.slots = make(chan struct{}, k.options.MaxPerHost)
+		}
+		k.hosts[name] = host
+	}
+	return host
+}
+
+// robots returns the robots.txt rules of the host serving target,
+// fetching them when they are not cached or have expired.
+func (k *crawler) robots(ctx context.Context, c *Client, host *crawlHost, target *url.URL) (*robotsRules, error) {
+	host.mu.Lock()
+	entry := host.robots
+	if entry == nil || entry.expired() {
+		entry = &robotsEntry{ready: make(chan struct{})}
+		host.robots = entry
+		host.mu.Unlock()
+
+		entry.rules, entry.err = k.fetchRobots(ctx, c, target)
+		ttl := k.options.RobotsTTL
+		if ttl <= 0 {
+			ttl = 24 * time.Hour
+		}
+		entry.expires = time.Now().Add(ttl)
+
+		// Don't cache failures
+		if entry.err != nil {
+			host.mu.Lock()
+			if host.robots == entry {
+				host.robots = nil
+			}
+			host.mu.Unlock()
+		}
+		close(entry.ready)
+	} else {
+		host.mu.Unlock()
+	}
+
+	select {
+	case <-entry.ready:
+		return entry.rules, entry.err
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	}
+}
+
+// expired reports whether a fetched entry is past its lifetime. Entries
+// still being fetched are not expired.
+func (e *robotsEntry) expired() bool {
+	select {
+	case <-e.ready:
+		return time.Now().After(e.expires)
+	default:
+		return false
+	}
+}
+
+// fetchRobots downloads and parses the robots.txt of target's host.
+func (k *crawler) fetchRobots(ctx context.Context, c *Client, target *url.URL) (*robotsRules, error) {
+	robotsURL := &url.URL{Scheme: target.Scheme, Host: target.Host, Path: "/robots.txt"}
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, robotsURL.String(), nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create robots.txt request: %w", err)
+	}
+	if k.options.UserAgent != "" {
+		req.Header.Set("User-Agent", k.options.UserAgent)
+	}
+
+	resp, err := c.state.Load().httpClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch robots.txt: %w", errors.ClassifyTransportError(err))
+	}
+	defer resp.Body.Close()
+
+	data, err := io.ReadAll(io.LimitReader(resp.Body, maxRobotsSize))
+	switch {
+	case resp.StatusCode >= 400 && resp.StatusCode < 500:
+		return &robotsRules{}, nil
+	case resp.StatusCode < 200 || resp.StatusCode >= 300:
+		return nil, fmt.Errorf("failed to fetch robots.txt: %w", errors.NewHTTPError(resp, data, ""))
+	case err != nil:
+		return nil, fmt.Errorf("failed to read robots.txt: %w", errors.ClassifyTransportError(err))
+	}
+
+	return parseRobots(data, k.options.UserAgent), nil
+}
@@ -0,0 +1,110 @@
+package infrastructure
+
+import (
+	"compress/gzip"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+
+	"github.com/fourth-ally/gofetch/domain/contracts"
+)
+
+// gzipCompressor implements contracts.Compressor with gzip. The
+// transport decodes gzip on its own unless the client sets
+// Accept-Encoding, which it does once other decompressors are registered.
+type gzipCompressor struct{}
+
+// Encoding implements contracts.Decompressor.
+func (gzipCompressor) Encoding() string { return "gzip" }
+
+// NewReader implements contracts.Decompressor.
+func (gzipCompressor) NewReader(r io.Reader) (io.ReadCloser, error) { return gzip.NewReader(r) }
+
+// NewWriter implements contracts.Compressor.
+func (gzipCompressor) NewWriter(w io.Writer) (io.WriteCloser, error) { return gzip.NewWriter(w), nil }
+
+// RegisterDecompressor decodes responses sent with the decompressor's
+// Content-Encoding, such as Brotli or Zstandard from the
+// infrastructure/compression packages, and advertises it in
+// Accept-Encoding along with gzip. Bodies are decoded before progress is
+// reported and before decoding into the target. An Accept-Encoding set
+// with SetAcceptEncoding or per request still takes precedence.
+func (c *Client) RegisterDecompressor(decompressor contracts.Decompressor) *Client {
+	decompressors := append([]contracts.Decompressor(nil), c.decompressors...)
+	if len(decompressors) == 0 {
+		decompressors = append(decompressors, gzipCompressor{})
+	}
+
+	for i, registered := range decompressors {
+		if registered.Encoding() == decompressor.Encoding() {
+			decompressors[i] = decompressor
+			c.decompressors = decompressors
+			return c
+		}
+	}
+
+	c.decompressors = append(decompressors, decompressor)
+	return c
+}
+
+// acceptedEncodings returns the Accept-Encoding value listing the
+// registered decompressors, or "" to leave negotiation to the transport.
+func (c *Client) acceptedEncodings() string {
+	encodings := make([]string, len(c.decompressors))
+	for i, decompressor := range c.decompressors {
+		encodings[i] = decompressor.Encoding()
+	}
+	return strings.Join(encodings, ", ")
+}
+
+// decompressBody replaces the body of a response sent with a registered
+// Content-Encoding by its decoded content. It returns the new body, or nil
+// when the body was left as is.
+func (c *Client) decompressBody(resp *http.Response) (io.ReadCloser, error) {
+	if len(c.decompressors) == 0 || resp.ContentLength == 0 ||
+		resp.StatusCode == http.StatusNoContent || resp.StatusCode == http.StatusNotModified ||
+		(resp.Request != nil && resp.Request.Method == http.MethodHead) {
+		return nil, nil
+	}
+
+	encoding := strings.ToLower(strings.TrimSpace(resp.Header.Get("Content-Encoding")))
+	for _, decompressor := range c.decompressors {
+		if decompressor.Encoding() != encoding {
+			continue
+		}
+
+		reader, err := decompressor.NewReader(resp.Body)
+		if err != nil {
+			return nil, fmt.Errorf("failed to decompress %s response: %w", encoding, err)
+		}
+
+		body := &decompressedBody{reader: reader, body: resp.Body}
+		resp.Body = body
+		resp.Header.Del("Content-Encoding")
+		resp.Header.Del("Content-Length")
+		resp.ContentLength = -1
+		resp.Uncompressed = true
+		return body, nil
+	}
+
+	return nil, nil
+}
+
+// decompressedBody reads decoded content and closes both the decoder and
+// the underlying body.
+type decompressedBody struct {
+	reader io.ReadCloser
+	body   io.ReadCloser
+}
+
+// Read implements io.Reader.
+func (b *decompressedBody) Read(p []byte) (int, error) {
+	return b.reader.Read(p)
+}
+
+// Close implements io.Closer.
+func (b *decompressedBody) Close() error {
+	b.reader.Close()
+	return b.body.Close()
+}
@@ -0,0 +1,129 @@
+package infrastructure
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"sort"
+	"sync"
+
+	"github.com/fourth-ally/gofetch/domain/models"
+)
+
+// inflightCall is a request shared by concurrent identical callers.
+type inflightCall struct {
+	done     chan struct{}
+	resp     *models.Response
+	err      error
+	canceled bool
+}
+
+// requestGroup collapses concurrent identical requests into one upstream call.
+type requestGroup struct {
+	mu    sync.Mutex
+	calls map[string]*inflightCall
+}
+
+// newRequestGroup creates an empty request group.
+func newRequestGroup() *requestGroup {
+	return &requestGroup{calls: make(map[string]*inflightCall)}
+}
+
+// do runs fn once per key among concurrent callers and shares its result.
+// Callers stop waiting when ctx is done. If the caller running fn gives up
+// because its context ended, the waiting callers run the request again
+// instead of inheriting that cancellation.
+func (g *requestGroup) do(ctx context.Context, key string, fn func() (*models.Response, error)) (*models.Response, error) {
+	g.mu.Lock()
+	for {
+		call, ok := g.calls[key]
+		if !ok {
+			break
+		}
+		g.mu.Unlock()
+
+		select {
+		case <-call.done:
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		}
+		if !call.canceled {
+			return call.resp, call.err
+		}
+		g.mu.Lock()
+	}
+
+	call := &inflightCall{done: make(chan struct{})}
+	g.calls[key] = call
+	g.mu.Unlock()
+
+	call.resp, call.err = fn()
+	call.canceled = call.err != nil && ctx.Err() != nil
+
+	g.mu.Lock()
+	delete(g.calls, key)
+	g.mu.Unlock()
+	close(call.done)
+
+	return call.resp, call.err
+}
+
+// SetDeduplication collapses concurrent identical GET requests (same URL
+// and headers) into a single upstream call whose response is shared by
+// all callers. The shared request runs with the first caller's context;
+// if that caller gives up, the others send the request again. Each caller
+// stops waiting when its own context is done.
+func (c *Client) SetDeduplication(enabled bool) *Client {
+	if enabled {
+		c.deduplicator = newRequestGroup()
+	} else {
+		c.deduplicator = nil
+	}
+	return c
+}
+
+// executeDeduplicated shares one upstream GET between concurrent identical
+// callers. The key is built before a request ID is added, since a fresh ID
+// would make every request unique; the shared request carries the ID of
+// the first caller.
+func (c *Client) executeDeduplicated(ctx context.Context, method, path string, params map[string]interface{}, target interface{}, requestConfig *models.Config) (*models.Response, error) {
+	config := c.mergedConfig(requestConfig)
+	fullURL, err := c.buildURL(config.BaseURL, path, params)
+	if err != nil {
+		return c.dispatch(ctx, method, path, params, nil, target, c.withRequestID(requestConfig))
+	}
+
+	key := deduplicationKey(method, fullURL, config.Headers)
+	shared, err := c.deduplicator.do(ctx, key, func() (*models.Response, error) {
+		return c.dispatch(ctx, method, path, params, nil, nil, c.withRequestID(requestConfig))
+	})
+	if err != nil {
+		return shared, err
+	}
+
+	// Each caller decodes into its own target from the shared body
+	resp := *shared
+	if err := c.unmarshalTarget(resp.RawBody, resp.Headers, target); err != nil {
+		return nil, err
+	}
+	resp.Data = target
+
+	return &resp, nil
+}
+
+// deduplicationKey hashes the method, URL and headers identifying a request.
+func deduplicationKey(method, fullURL string, headers map[string]string) string {
+	keys := make([]string, 0, len(headers))
+	for key := range headers {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	hash := sha256.New()
+	hash.Write([]byte(method + " " + fullURL + "\n"))
+	for _, key := range keys {
+		hash.Write([]byte(key + ": " + headers[key] + "\n"))
+	}
+
+	return hex.EncodeToString(hash.Sum(nil))
+}
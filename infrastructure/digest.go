@@ -0,0 +1,90 @@
+package infrastructure
+
+import (
+	"crypto/md5"
+	"crypto/sha256"
+	"crypto/sha512"
+	"encoding/base64"
+	"fmt"
+	"hash"
+	"io"
+	"net/http"
+	"strings"
+
+	"github.com/fourth-ally/gofetch/domain/models"
+)
+
+// SetContentDigest makes the client hash every request body and send the
+// result as Content-MD5 and/or RFC 9530 Content-Digest headers, as required
+// by several storage and banking APIs. Files and seekable readers are
+// hashed in a pass of their own before they are sent. Bodies streamed from
+// readers that can't be rewound, such as pipes, are sent without digest
+// headers, since they would have to be buffered to be hashed up front.
+// Call without algorithms to disable.
+func (c *Client) SetContentDigest(algorithms ...models.DigestAlgorithm) *Client {
+	c.digestAlgorithms = append([]models.DigestAlgorithm(nil), algorithms...)
+	return c
+}
+
+// newDigestHash returns the hash for algorithm, or nil if it is unknown.
+func newDigestHash(algorithm models.DigestAlgorithm) hash.Hash {
+	switch algorithm {
+	case models.DigestMD5:
+		return md5.New()
+	case models.DigestSHA256:
+		return sha256.New()
+	case models.DigestSHA512:
+		return sha512.New()
+	default:
+		return nil
+	}
+}
+
+// contentDigestHeaders hashes body and sets the digest headers on header.
+func contentDigestHeaders(header http.Header, body io.Reader, algorithms []models.DigestAlgorithm) error {
+	hashes := make(map[models.DigestAlgorithm]hash.Hash)
+	var writers []io.Writer
+	for _, algorithm := range algorithms {
+		if h := newDigestHash(algorithm); h != nil {
+			hashes[algorithm] = h
+			writers = append(writers, h)
+		}
+	}
+
+	if _, err := io.Copy(io.MultiWriter(writers...), body); err != nil {
+		return fmt.Errorf("failed to read body for digest: %w", err)
+	}
+
+	var fields []string
+	for _, algorithm := range []models.DigestAlgorithm{models.DigestSHA256, models.DigestSHA512} {
+		if h, ok := hashes[algorithm]; ok {
+			fields = append(fields, string(algorithm)+"=:"+base64.StdEncoding.EncodeToString(h.Sum(nil))+":")
+		}
+	}
+
+	if len(fields) > 0 {
+		header.Set("Content-Digest", strings.Join(fields, ", "))
+	}
+
+	if h, ok := hashes[models.DigestMD5]; ok {
+		header.Set("Content-MD5", base64.StdEncoding.EncodeToString(h.Sum(nil)))
+	}
+	return nil
+}
+
+// streamDigestHeaders hashes a replayable streamed body and rewinds it for
+// sending.
+func streamDigestHeaders(req *http.Request, stream *streamBody, algorithms []models.DigestAlgorithm) error {
+	body, err := req.GetBody()
+	if err != nil {
+		return fmt.Errorf("failed to read body for digest: %w", err)
+	}
+	err = contentDigestHeaders(req.Header, body, algorithms)
+	body.Close()
+	if err != nil {
+		return err
+	}
+
+	_, err = stream.open()
+	return err
+}
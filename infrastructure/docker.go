@@ -0,0 +1,70 @@
+package infrastructure
+
+import (
+	"context"
+	"encoding/binary"
+	"fmt"
+	"io"
+
+	"github.com/fourth-ally/gofetch/domain/models"
+)
+
+// Docker multiplexed stream identifiers.
+const (
+	dockerStdin  = 0
+	dockerStdout = 1
+	dockerStderr = 2
+)
+
+// StreamDockerMessages sends a request to the Docker Engine API that
+// answers with a JSON progress stream, such as POST /images/create, and
+// calls onMessage for each message as it arrives. A message reporting an
+// error ends the stream with that error, since the daemon has already
+// answered 200 OK by the time the operation fails.
+func (c *Client) StreamDockerMessages(ctx context.Context, method, path string, params map[string]interface{}, body interface{}, onMessage func(models.DockerMessage) error) error {
+	return c.StreamNDJSONRequest(ctx, method, path, params, body, func(line []byte) error {
+		var message models.DockerMessage
+		if err := c.jsonCodec().Unmarshal(line, &message); err != nil {
+			return fmt.Errorf("failed to decode docker message: %w", err)
+		}
+
+		if message.Error != "" {
+			return fmt.Errorf("docker: %s", message.Error)
+		}
+		return onMessage(message)
+	})
+}
+
+// DemuxDockerStream copies a multiplexed attach or exec stream of a
+// container without a TTY to stdout and stderr until it ends. Each frame
+// carries an 8-byte header holding the stream identifier and the payload
+// size. A nil writer discards its stream.
+func DemuxDockerStream(stream io.Reader, stdout, stderr io.Writer) error {
+	var header [8]byte
+	for {
+		if _, err := io.ReadFull(stream, header[:]); err != nil {
+			if err == io.EOF {
+				return nil
+			}
+			return fmt.Errorf("failed to read docker stream header: %w", err)
+		}
+
+		var writer io.Writer
+		switch header[0] {
+		case dockerStdin, dockerStdout:
+			writer = stdout
+		case dockerStderr:
+			writer = stderr
+		default:
+			return fmt.Errorf("invalid docker stream identifier %d", header[0])
+		}
+		if writer == nil {
+			writer = io.Discard
+		}
+
+		size := int64(binary.BigEndian.Uint32(header[4:]))
+		if _, err := io.CopyN(writer, stream, size); err != nil {
+			return fmt.Errorf("failed to copy docker stream frame: %w", err)
+		}
+	}
+}
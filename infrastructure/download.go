@@ -0,0 +1,73 @@
+package infrastructure
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+
+	"github.com/fourth-ally/gofetch/domain/errors"
+	"github.com/fourth-ally/gofetch/domain/models"
+)
+
+// Download performs a GET request and copies the response body to w as it
+// arrives instead of loading it into memory, reporting progress to the
+// download progress callback. The returned response carries the status
+// and headers but no body. The client timeout does not apply since large
+// downloads take long; bound them with ctx instead. Responses rejected by
+// the status validator are returned as *errors.HTTPError and nothing is
+// written to w. Retries, caching and hedging don't apply.
+func (c *Client) Download(ctx context.Context, path string, params map[string]interface{}, w io.Writer) (*models.Response, error) {
+	resp, err := c.download(ctx, path, params, nil, func(*http.Response) (io.Writer, error) { return w, nil })
+	if err != nil {
+		return nil, err
+	}
+
+	response := models.NewResponse(resp.StatusCode, resp.Header, nil, nil)
+	response.BaseURL = c.mergedConfig(nil).BaseURL
+	response.Decompressed = resp.Uncompressed
+	return response, nil
+}
+
+// download streams the body of a GET request to the writer returned by
+// writerFor, which sees the response headers first, and returns the
+// response with its body consumed and closed.
+func (c *Client) download(ctx context.Context, path string, params map[string]interface{}, requestConfig *models.Config, writerFor func(*http.Response) (io.Writer, error)) (*http.Response, error) {
+	resp, err := c.openStream(ctx, http.MethodGet, path, params, nil, requestConfig)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	w, err := writerFor(resp)
+	if err != nil {
+		return nil, err
+	}
+
+	// Keep write errors apart from transport errors
+	tee := &teeReader{reader: c.progressBody(resp), writer: w}
+	_, err = io.Copy(io.Discard, tee)
+	if tee.err != nil {
+		return nil, fmt.Errorf("failed to write download: %w", tee.err)
+	}
+	if err != nil {
+		if ctx.Err() != nil {
+			return nil, ctx.Err()
+		}
+		return nil, fmt.Errorf("failed to read response body: %w", errors.ClassifyTransportError(err))
+	}
+	return resp, nil
+}
+
+// progressBody returns the body of a streamed response, reporting reads
+// to the download progress callback when one is set.
+func (c *Client) progressBody(resp *http.Response) io.Reader {
+	if c.downloadProgress == nil {
+		return resp.Body
+	}
+	return &progressReader{
+		reader:   resp.Body,
+		total:    resp.ContentLength,
+		callback: c.downloadProgress,
+	}
+}
@@ -0,0 +1,285 @@
+package infrastructure
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	stderrors "errors"
+	"fmt"
+	"io"
+	"mime"
+	"net/http"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+
+	"github.com/fourth-ally/gofetch/domain/errors"
+	"github.com/fourth-ally/gofetch/domain/models"
+)
+
+// DownloadFile performs a GET request and saves the response body to
+// destPath like Download, reporting progress to the download progress
+// callback. The body is written to a temporary file next to the
+// destination, synced to disk and renamed into place only once it is
+// complete, so destPath never holds a partial download; on failure the
+// temporary file is removed unless the download is resumable. Checksums
+// given in options are verified before the rename. Large files can be
+// fetched in parallel ranges with options.Parallel. The saved path is
+// reported in Response.File. Pass nil options for the defaults.
+func (c *Client) DownloadFile(ctx context.Context, path string, params map[string]interface{}, destPath string, options *models.DownloadFileOptions) (*models.Response, error) {
+	if options == nil {
+		options = models.NewDownloadFileOptions()
+	}
+
+	expected, err := optionChecksum(options)
+	if err != nil {
+		return nil, err
+	}
+
+	dir := filepath.Dir(destPath)
+	if options.UseContentDisposition {
+		dir = destPath
+	}
+
+	var partial *partialDownload
+	if options.Resume {
+		partial, err = c.openPartialDownload(path, params, destPath, options)
+	} else {
+		partial, err = newTempDownload(dir)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to create download file: %w", err)
+	}
+	saved := false
+	defer func() {
+		if !saved {
+			partial.abandon(options.Resume && !isChecksumMismatch(err))
+		}
+	}()
+
+	// Hash while streaming so verification needs no second pass
+	var verifier *checksumVerifier
+	writerFor := func(resp *http.Response) (io.Writer, error) {
+		resumed, err := partial.prepare(resp)
+		if err != nil {
+			return nil, err
+		}
+		if options.VerifyDigest && !resumed {
+			expected = append(expected, headerChecksums(resp)...)
+		}
+		verifier = newChecksumVerifier(expected)
+		if resumed {
+			if err := partial.hashExisting(verifier.writer(io.Discard)); err != nil {
+				return nil, err
+			}
+		}
+		return verifier.writer(partial.file), nil
+	}
+
+	var resp *http.Response
+	if options.Parallel > 1 && !options.Resume {
+		resp, err = c.downloadParallel(ctx, path, params, partial.file, options, expected)
+		if err != nil {
+			return nil, err
+		}
+	}
+	if resp == nil {
+		resp, err = c.download(ctx, path, params, partial.rangeConfig(), writerFor)
+		var httpErr *errors.HTTPError
+		if stderrors.As(err, &httpErr) && httpErr.StatusCode == http.StatusRequestedRangeNotSatisfiable && partial.offset > 0 {
+			// The partial file no longer fits the resource; start over
+			partial.offset = 0
+			resp, err = c.download(ctx, path, params, nil, writerFor)
+		}
+		if err != nil {
+			return nil, err
+		}
+		if err = verifier.verify(); err != nil {
+			return nil, err
+		}
+	}
+
+	if err = partial.file.Sync(); err != nil {
+		return nil, fmt.Errorf("failed to write download: %w", err)
+	}
+	if err = partial.file.Close(); err != nil {
+		return nil, fmt.Errorf("failed to write download: %w", err)
+	}
+	perm := options.Perm
+	if perm == 0 {
+		perm = models.NewDownloadFileOptions().Perm
+	}
+	if err = os.Chmod(partial.file.Name(), perm); err != nil {
+		return nil, fmt.Errorf("failed to write download: %w", err)
+	}
+
+	if options.UseContentDisposition {
+		destPath = filepath.Join(dir, downloadFilename(resp))
+	}
+	if err = os.Rename(partial.file.Name(), destPath); err != nil {
+		return nil, fmt.Errorf("failed to save download: %w", err)
+	}
+	saved = true
+	partial.removeValidator()
+
+	response := models.NewResponse(resp.StatusCode, resp.Header, nil, nil)
+	response.BaseURL = c.mergedConfig(nil).BaseURL
+	response.Decompressed = resp.Uncompressed
+	response.File = destPath
+	return response, nil
+}
+
+// partialDownload is the file a download is written to before it is
+// renamed into place. Resumable downloads keep it, with the validator of
+// the response in a file next to it, to continue later.
+type partialDownload struct {
+	file      *os.File
+	offset    int64
+	validator string
+	resumable bool
+}
+
+// newTempDownload creates a fresh temporary file in dir.
+func newTempDownload(dir string) (*partialDownload, error) {
+	file, err := os.CreateTemp(dir, ".gofetch-download-*")
+	if err != nil {
+		return nil, err
+	}
+	return &partialDownload{file: file}, nil
+}
+
+// openPartialDownload opens the partial file of a resumable download,
+// named after destPath, or for Content-Disposition downloads after the
+// request URL, and reads how far it got.
+func (c *Client) openPartialDownload(path string, params map[string]interface{}, destPath string, options *models.DownloadFileOptions) (*partialDownload, error) {
+	name := destPath + ".part"
+	if options.UseContentDisposition {
+		fullURL, err := c.buildURL(c.mergedConfig(nil).BaseURL, path, params)
+		if err != nil {
+			return nil, err
+		}
+		sum := sha256.Sum256([]byte(fullURL))
+		name = filepath.Join(destPath, ".gofetch-"+hex.EncodeToString(sum[:8])+".part")
+	}
+
+	file, err := os.OpenFile(name, os.O_RDWR|os.O_CREATE, 0o600)
+	if err != nil {
+		return nil, err
+	}
+	partial := &partialDownload{file: file, resumable: true}
+
+	// Without a validator the partial content can't be trusted
+	validator, _ := os.ReadFile(partial.validatorPath())
+	info, err := file.Stat()
+	if err == nil && len(validator) > 0 {
+		partial.offset = info.Size()
+		partial.validator = string(validator)
+	}
+	return partial, nil
+}
+
+// rangeConfig returns the request config resuming the download, or nil
+// to download from the start.
+func (p *partialDownload) rangeConfig() *models.Config {
+	if p.offset == 0 {
+		return nil
+	}
+	return &models.Config{Headers: map[string]string{
+		"Range":    fmt.Sprintf("bytes=%d-", p.offset),
+		"If-Range": p.validator,
+	}}
+}
+
+// prepare positions the file for the body of resp and reports whether it
+// continues the partial content. A full response replaces it.
+func (p *partialDownload) prepare(resp *http.Response) (bool, error) {
+	if p.offset > 0 && resp.StatusCode == http.StatusPartialContent {
+		if rangeStart(resp.Header.Get("Content-Range")) != p.offset {
+			return false, fmt.Errorf("server did not resume at byte %d", p.offset)
+		}
+		_, err := p.file.Seek(0, io.SeekEnd)
+		return true, err
+	}
+
+	p.offset = 0
+	if err := p.file.Truncate(0); err != nil {
+		return false, err
+	}
+	if _, err := p.file.Seek(0, io.SeekStart); err != nil {
+		return false, err
+	}
+
+	// Transparently decompressed bodies can't be resumed by byte offset
+	if p.resumable {
+		p.validator = ""
+		if !resp.Uncompressed {
+			p.validator = rangeValidator(resp.Header)
+		}
+		p.removeValidator()
+		if p.validator != "" {
+			return false, os.WriteFile(p.validatorPath(), []byte(p.validator), 0o600)
+		}
+	}
+	return false, nil
+}
+
+// hashExisting feeds the content already downloaded to w.
+func (p *partialDownload) hashExisting(w io.Writer) error {
+	_, err := io.Copy(w, io.NewSectionReader(p.file, 0, p.offset))
+	return err
+}
+
+// abandon closes the file of a failed download, keeping it to resume
+// later if keep is set.
+func (p *partialDownload) abandon(keep bool) {
+	p.file.Close()
+	if !keep {
+		os.Remove(p.file.Name())
+		p.removeValidator()
+	}
+}
+
+// validatorPath is the file holding the validator of a resumable download.
+func (p *partialDownload) validatorPath() string {
+	return p.file.Name() + ".validator"
+}
+
+// removeValidator deletes the validator file, if any.
+func (p *partialDownload) removeValidator() {
+	if p.resumable {
+		os.Remove(p.validatorPath())
+	}
+}
+
+// isChecksumMismatch reports whether err means the content is corrupt.
+func isChecksumMismatch(err error) bool {
+	var mismatch *errors.ChecksumMismatchError
+	return stderrors.As(err, &mismatch)
+}
+
+// downloadFilename picks a safe file name for resp from its
+// Content-Disposition header or, failing that, its URL path.
+func downloadFilename(resp *http.Response) string {
+	if _, params, err := mime.ParseMediaType(resp.Header.Get("Content-Disposition")); err == nil {
+		if name := safeFilename(params["filename"]); name != "" {
+			return name
+		}
+	}
+	if resp.Request != nil {
+		if name := safeFilename(path.Base(resp.Request.URL.Path)); name != "" {
+			return name
+		}
+	}
+	return "download"
+}
+
+// safeFilename strips any directory from a server supplied name and
+// rejects names that would escape the destination directory.
+func safeFilename(name string) string {
+	name = filepath.Base(strings.ReplaceAll(name, "\\", "/"))
+	if name == "." || name == ".." || name == "/" || strings.HasPrefix(name, ".") {
+		return ""
+	}
+	return name
+}
@@ -0,0 +1,143 @@
+package infrastructure
+
+import (
+	"context"
+	stderrors "errors"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"sync"
+
+	"github.com/fourth-ally/gofetch/domain/contracts"
+	"github.com/fourth-ally/gofetch/domain/errors"
+	"github.com/fourth-ally/gofetch/domain/models"
+)
+
+// downloadParallel downloads into file with concurrent Range requests,
+// one per chunk, after a HEAD request has shown that the server supports
+// ranges. It returns a nil response without error when it doesn't, for
+// the caller to download in one stream instead. Checksums are verified
+// by reading the assembled file back.
+func (c *Client) downloadParallel(ctx context.Context, path string, params map[string]interface{}, file *os.File, options *models.DownloadFileOptions, expected []expectedChecksum) (*http.Response, error) {
+	head, err := c.openStream(ctx, http.MethodHead, path, params, nil, nil)
+	if err != nil {
+		var httpErr *errors.HTTPError
+		if stderrors.As(err, &httpErr) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	head.Body.Close()
+
+	size := head.ContentLength
+	if head.Header.Get("Accept-Ranges") != "bytes" || size <= 0 {
+		return nil, nil
+	}
+	if err := file.Truncate(size); err != nil {
+		return nil, fmt.Errorf("failed to write download: %w", err)
+	}
+
+	chunks := min(int64(options.Parallel), size)
+	chunkSize := (size + chunks - 1) / chunks
+	validator := rangeValidator(head.Header)
+	progress := &chunkProgress{total: size, callback: c.downloadProgress}
+
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	var wg sync.WaitGroup
+	var once sync.Once
+	var firstErr error
+	for start := int64(0); start < size; start += chunkSize {
+		end := min(start+chunkSize, size) - 1
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if err := c.downloadChunk(ctx, path, params, file, start, end, validator, progress); err != nil {
+				once.Do(func() {
+					firstErr = err
+					cancel()
+				})
+			}
+		}()
+	}
+	wg.Wait()
+	if firstErr != nil {
+		return nil, firstErr
+	}
+
+	if options.VerifyDigest {
+		expected = append(expected, headerChecksums(head)...)
+	}
+	if len(expected) > 0 {
+		verifier := newChecksumVerifier(expected)
+		if _, err := io.Copy(verifier.writer(io.Discard), io.NewSectionReader(file, 0, size)); err != nil {
+			return nil, fmt.Errorf("failed to read download: %w", err)
+		}
+		if err := verifier.verify(); err != nil {
+			return nil, err
+		}
+	}
+
+	return head, nil
+}
+
+// downloadChunk writes bytes start to end, inclusive, of the resource to
+// the same offsets of file.
+func (c *Client) downloadChunk(ctx context.Context, path string, params map[string]interface{}, file *os.File, start, end int64, validator string, progress *chunkProgress) error {
+	headers := map[string]string{"Range": fmt.Sprintf("bytes=%d-%d", start, end)}
+	if validator != "" {
+		headers["If-Range"] = validator
+	}
+
+	resp, err := c.openStream(ctx, http.MethodGet, path, params, nil, &models.Config{Headers: headers})
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	// A full response means the resource changed or ranges aren't honored
+	if resp.StatusCode != http.StatusPartialContent || rangeStart(resp.Header.Get("Content-Range")) != start {
+		return fmt.Errorf("server did not return bytes %d-%d (status %d)", start, end, resp.StatusCode)
+	}
+
+	length := end - start + 1
+	tee := &teeReader{reader: io.LimitReader(resp.Body, length), writer: io.NewOffsetWriter(file, start)}
+	n, err := io.Copy(progress, tee)
+	if tee.err != nil {
+		return fmt.Errorf("failed to write download: %w", tee.err)
+	}
+	if err == nil && n < length {
+		err = io.ErrUnexpectedEOF
+	}
+	if err != nil {
+		if ctx.Err() != nil {
+			return ctx.Err()
+		}
+		return fmt.Errorf("failed to read response body: %w", errors.ClassifyTransportError(err))
+	}
+	return nil
+}
+
+// chunkProgress sums the bytes received by all chunks of a parallel
+// download and reports them to the download progress callback.
+type chunkProgress struct {
+	mu          sync.Mutex
+	transferred int64
+	total       int64
+	callback    contracts.ProgressCallback
+}
+
+// Write implements io.Writer, counting p.
+func (p *chunkProgress) Write(b []byte) (int, error) {
+	if p.callback == nil {
+		return len(b), nil
+	}
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.transferred += int64(len(b))
+	p.callback(p.transferred, p.total)
+	return len(b), nil
+}
@@ -0,0 +1,105 @@
+package infrastructure
+
+import (
+	"net/http"
+	"sort"
+
+	"github.com/fourth-ally/gofetch/domain/models"
+)
+
+// EffectiveConfig returns the settings a request made with opts would use:
+// client defaults merged with the per-request options. Settings changed by
+// opts are listed in the snapshot's Overrides. Secret headers are redacted,
+// so the snapshot and its String dump are safe to log.
+func (c *Client) EffectiveConfig(opts ...RequestOption) models.ConfigSnapshot {
+	defaults := c.snapshot(c.state.Load().config, nil)
+
+	requestConfig := applyOptions(opts)
+	if requestConfig == nil {
+		return defaults
+	}
+
+	effective := c.snapshot(c.mergedConfig(requestConfig), requestConfig)
+	effective.Overrides = defaults.Diff(effective)
+	return effective
+}
+
+// snapshot captures config together with the client's transport settings.
+func (c *Client) snapshot(config *models.Config, requestConfig *models.Config) models.ConfigSnapshot {
+	config = config.Clone()
+
+	snapshot := models.ConfigSnapshot{
+		BaseURL:         config.BaseURL,
+		BaseURLs:        config.BaseURLs,
+		Headers:         models.RedactHeaders(config.Headers),
+		Timeout:         c.httpClientFor(c.state.Load(), requestConfig).Timeout,
+		DialTimeout:     c.dialTimeout,
+		IdleReadTimeout: c.idleReadTimeout,
+		RetryOptions:    config.RetryOptions,
+		HedgingOptions:  config.HedgingOptions,
+		AcceptEncoding:  config.AcceptEncoding,
+		ContentType:     config.ContentType,
+		Priority:        requestPriority(config),
+		Features:        c.features(),
+	}
+
+	if transport, ok := c.state.Load().httpClient.Transport.(*http.Transport); ok {
+		snapshot.TLSHandshakeTimeout = transport.TLSHandshakeTimeout
+		snapshot.ResponseHeaderTimeout = transport.ResponseHeaderTimeout
+		if transport.TLSClientConfig != nil {
+			snapshot.MinTLSVersion = transport.TLSClientConfig.MinVersion
+		}
+	}
+
+	return snapshot
+}
+
+// features lists the optional subsystems enabled on the client.
+func (c *Client) features() []string {
+	enabled := map[string]bool{
+		"bulkhead":          c.bulkhead != nil,
+		"cache":             c.cache != nil,
+		"canary":            c.canary != nil,
+		"circuit-breaker":   c.circuitBreaker != nil,
+		"content-digest":    len(c.digestAlgorithms) > 0,
+		"content-sniffing":  c.contentSniffing,
+		"deduplication":     c.deduplicator != nil,
+		"endpoint-pool":     c.pool != nil,
+		"error-body-limit":  c.errorBody != nil,
+		"fault-injection":   c.faults != nil,
+		"fetch-options":     c.fetchOptions != nil,
+		"health-checks":     c.health != nil,
+		"idempotency-keys":  c.idempotencyKeys,
+		"logging":           c.logger != nil,
+		"offline-queue":     c.offlineQueue != nil,
+		"proxies":           c.proxies != nil,
+		"rate-limit":        c.rateLimiter != nil,
+		"request-ids":       c.requestIDHeader != "",
+		"request-signing":   c.signature != nil,
+		"body-resumption":   c.maxBodyResumes > 0,
+		"shadow":            c.shadow != nil,
+		"conditional-cache": c.validatorStore != nil,
+		"unix-socket":       c.unixSocket != "",
+		"header-limit":      c.maxHeaderCount > 0,
+		"manual-decode":     c.manualDecode,
+		"decompressors":     len(c.decompressors) > 0,
+		"max-response-size": c.maxResponseSize > 0,
+		"request-encoding":  c.requestEncoding != "",
+		"crawl-policy":      c.crawler != nil,
+		"custom-json":       c.jsonImpl != nil,
+		"journal":           c.journal != nil,
+		"stale-conn-check":  c.staleGuard != nil,
+		"capture-filter":    c.captureFilter != nil,
+		"query-encoder":     c.queryEncoder != nil,
+		"host-mapping":      len(c.hostMapping) > 0,
+	}
+
+	var features []string
+	for name, on := range enabled {
+		if on {
+			features = append(features, name)
+		}
+	}
+	sort.Strings(features)
+	return features
+}
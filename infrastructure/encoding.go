@@ -0,0 +1,35 @@
+package infrastructure
+
+import (
+	"context"
+
+	"github.com/fourth-ally/gofetch/domain/models"
+)
+
+// EncodingIdentity requests an uncompressed response body, so that
+// Content-Length matches the bytes read and progress reporting is accurate.
+const EncodingIdentity = "identity"
+
+// acceptEncodingKey is the context key for per-request Accept-Encoding overrides.
+type acceptEncodingKey struct{}
+
+// SetAcceptEncoding sets the Accept-Encoding header sent with every request.
+// An empty value restores transparent gzip negotiation by the transport.
+func (c *Client) SetAcceptEncoding(encoding string) *Client {
+	c.state.Load().config.AcceptEncoding = encoding
+	return c
+}
+
+// WithAcceptEncodingContext returns a context that overrides Accept-Encoding
+// for requests made with it, e.g. EncodingIdentity for a single download.
+func WithAcceptEncodingContext(ctx context.Context, encoding string) context.Context {
+	return context.WithValue(ctx, acceptEncodingKey{}, encoding)
+}
+
+// acceptEncoding resolves the Accept-Encoding override for a request.
+func acceptEncoding(ctx context.Context, config *models.Config) string {
+	if encoding, ok := ctx.Value(acceptEncodingKey{}).(string); ok && encoding != "" {
+		return encoding
+	}
+	return config.AcceptEncoding
+}
@@ -0,0 +1,165 @@
+package infrastructure
+
+import (
+	"context"
+	"math/rand"
+	"sync"
+	"time"
+
+	"github.com/fourth-ally/gofetch/domain/models"
+)
+
+// endpointPool balances requests across upstream hosts and tracks their health.
+type endpointPool struct {
+	mu sync.Mutex
+
+	endpoints []*endpointState
+	options   *models.EndpointPoolOptions
+	next      int
+	rng       *rand.Rand
+}
+
+// endpointState is the mutable health record of a pool endpoint.
+type endpointState struct {
+	endpoint            models.Endpoint
+	consecutiveFailures int
+	downUntil           time.Time
+	requests            int64
+	failures            int64
+}
+
+// SetEndpointPool spreads requests across endpoints using the configured
+// strategy. Endpoints failing repeatedly are taken out of rotation for a
+// cooldown period. Pass an empty slice to disable the pool.
+func (c *Client) SetEndpointPool(endpoints []models.Endpoint, options *models.EndpointPoolOptions) *Client {
+	if len(endpoints) == 0 {
+		c.pool = nil
+		return c
+	}
+
+	if options == nil {
+		options = models.NewEndpointPoolOptions()
+	}
+
+	pool := &endpointPool{
+		options: options,
+		rng:     rand.New(rand.NewSource(time.Now().UnixNano())),
+	}
+	for _, endpoint := range endpoints {
+		if endpoint.Weight <= 0 {
+			endpoint.Weight = 1
+		}
+		pool.endpoints = append(pool.endpoints, &endpointState{endpoint: endpoint})
+	}
+
+	c.pool = pool
+	return c
+}
+
+// EndpointStatuses returns the health and traffic counters of the pool.
+func (c *Client) EndpointStatuses() []models.EndpointStatus {
+	if c.pool == nil {
+		return nil
+	}
+	return c.pool.statuses()
+}
+
+// executeBalanced sends a request to the endpoint picked by the pool.
+func (c *Client) executeBalanced(ctx context.Context, method, path string, params map[string]interface{}, body interface{}, target interface{}, requestConfig *models.Config) (*models.Response, error) {
+	baseURL := c.pool.pick(c.health.isDown)
+	hostConfig := overrideConfig(requestConfig, &models.Config{BaseURL: baseURL})
+
+	resp, err := c.executeRequestWithRetry(ctx, method, path, params, body, target, hostConfig)
+	if ctx.Err() == nil {
+		c.pool.record(baseURL, !endpointFailed(resp, err))
+	}
+
+	return resp, err
+}
+
+// pick selects the endpoint for the next request, skipping endpoints in
+// cooldown or reported down by probeDown. If every endpoint is down, all of
+// them are considered so traffic is never blackholed.
+func (p *endpointPool) pick(probeDown func(string) bool) string {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	now := time.Now()
+	candidates := make([]*endpointState, 0, len(p.endpoints))
+	for _, state := range p.endpoints {
+		if now.After(state.downUntil) && !probeDown(state.endpoint.URL) {
+			candidates = append(candidates, state)
+		}
+	}
+	if len(candidates) == 0 {
+		candidates = p.endpoints
+	}
+
+	var chosen *endpointState
+	switch p.options.Strategy {
+	case models.BalanceWeightedRandom:
+		total := 0
+		for _, state := range candidates {
+			total += state.endpoint.Weight
+		}
+
+		roll := p.rng.Intn(total)
+		for _, state := range candidates {
+			roll -= state.endpoint.Weight
+			if roll < 0 {
+				chosen = state
+				break
+			}
+		}
+	default:
+		chosen = candidates[p.next%len(candidates)]
+		p.next++
+	}
+
+	chosen.requests++
+	return chosen.endpoint.URL
+}
+
+// record updates the health of an endpoint after a request.
+func (p *endpointPool) record(url string, success bool) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	for _, state := range p.endpoints {
+		if state.endpoint.URL != url {
+			continue
+		}
+
+		if success {
+			state.consecutiveFailures = 0
+			return
+		}
+
+		state.failures++
+		state.consecutiveFailures++
+		if state.consecutiveFailures >= p.options.FailureThreshold {
+			state.downUntil = time.Now().Add(p.options.Cooldown)
+			state.consecutiveFailures = 0
+		}
+		return
+	}
+}
+
+// statuses snapshots the pool's endpoints.
+func (p *endpointPool) statuses() []models.EndpointStatus {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	now := time.Now()
+	statuses := make([]models.EndpointStatus, len(p.endpoints))
+	for i, state := range p.endpoints {
+		statuses[i] = models.EndpointStatus{
+			URL:      state.endpoint.URL,
+			Weight:   state.endpoint.Weight,
+			Healthy:  now.After(state.downUntil),
+			Requests: state.requests,
+			Failures: state.failures,
+		}
+	}
+	return statuses
+}
@@ -0,0 +1,106 @@
+package infrastructure
+
+import (
+	"io"
+	"net/http"
+	"os"
+	"reflect"
+
+	"github.com/fourth-ally/gofetch/domain/errors"
+	"github.com/fourth-ally/gofetch/domain/models"
+)
+
+// SetErrorBodyLimit caps the body stored in HTTPError for responses
+// rejected by the status validator, so a huge error page isn't buffered in
+// memory. The rest of the body is discarded unless SpillToFile is set.
+// Pass nil to store complete error bodies again.
+func (c *Client) SetErrorBodyLimit(options *models.ErrorBodyOptions) *Client {
+	c.errorBody = options
+	return c
+}
+
+// SetError decodes the body of every response rejected by the status
+// validator into a new value of the type of prototype, e.g. APIError{} or
+// &APIError{}, with the codec for the response Content-Type, and attaches
+// it to the returned *errors.HTTPError as Decoded (a *APIError in both
+// examples). Bodies that don't decode leave Decoded nil. Pass nil to stop
+// decoding error bodies.
+func (c *Client) SetError(prototype interface{}) *Client {
+	c.errorType = nil
+	if prototype != nil {
+		c.errorType = reflect.TypeOf(prototype)
+		if c.errorType.Kind() == reflect.Pointer {
+			c.errorType = c.errorType.Elem()
+		}
+	}
+	return c
+}
+
+// decodeError parses problem details and decodes the body of httpErr into
+// the error target of the request or client, if any, and returns httpErr.
+func (c *Client) decodeError(httpErr *errors.HTTPError, config *models.Config) *errors.HTTPError {
+	if errors.IsProblemResponse(httpErr.Headers) && !httpErr.Truncated {
+		httpErr.Problem, _ = errors.ParseProblemDetails(httpErr.Body)
+	}
+
+	target := config.ErrorTarget
+	if target == nil && c.errorType != nil {
+		target = reflect.New(c.errorType).Interface()
+	}
+	if target == nil || len(httpErr.Body) == 0 {
+		return httpErr
+	}
+
+	if err := c.responseCodec(httpErr.Headers).Unmarshal(httpErr.Body, target); err == nil {
+		httpErr.Decoded = target
+	}
+	return httpErr
+}
+
+// readErrorBody builds the HTTPError of a rejected response, reading at
+// most the configured number of bytes into memory.
+func (c *Client) readErrorBody(resp *http.Response) *errors.HTTPError {
+	options := c.errorBody
+	limit := options.MaxBytes
+	if limit <= 0 {
+		limit = models.NewErrorBodyOptions().MaxBytes
+	}
+
+	var spill *os.File
+	var reader io.Reader = resp.Body
+	if options.SpillToFile {
+		if file, err := os.CreateTemp(options.SpillDir, "gofetch-error-*"); err == nil {
+			spill = file
+			reader = io.TeeReader(resp.Body, file)
+		}
+	}
+
+	body, _ := io.ReadAll(io.LimitReader(reader, limit+1))
+
+	httpErr := errors.NewHTTPError(resp, body, "")
+	if int64(len(body)) <= limit {
+		if spill != nil {
+			spill.Close()
+			os.Remove(spill.Name())
+		}
+		return httpErr
+	}
+
+	httpErr.Body = body[:limit]
+	httpErr.Truncated = true
+	httpErr.BodyLength = resp.ContentLength
+
+	if spill != nil {
+		rest, err := io.Copy(spill, resp.Body)
+		spill.Close()
+		if err != nil {
+			os.Remove(spill.Name())
+			return httpErr
+		}
+
+		httpErr.BodyLength = int64(len(body)) + rest
+		httpErr.BodyFile = spill.Name()
+	}
+
+	return httpErr
+}
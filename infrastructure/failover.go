@@ -0,0 +1,108 @@
+package infrastructure
+
+import (
+	"context"
+	stderrors "errors"
+
+	"github.com/fourth-ally/gofetch/domain/errors"
+	"github.com/fourth-ally/gofetch/domain/models"
+)
+
+// SetBaseURLs configures a primary base URL followed by mirrors. When a
+// request fails at transport level or with a 5xx status on one host, it is
+// transparently retried against the next. Requests with methods that
+// aren't idempotent, such as POST, only move on when the connection to the
+// host couldn't be established. Response.BaseURL reports which
+// host served the response.
+func (c *Client) SetBaseURLs(baseURLs []string) *Client {
+	c.state.Load().config.BaseURLs = append([]string(nil), baseURLs...)
+	if len(baseURLs) > 0 {
+		c.state.Load().config.BaseURL = baseURLs[0]
+	}
+	return c
+}
+
+// executeWithFailover runs the retry pipeline against each configured base
+// URL in turn until one succeeds or a non-failover error occurs. Hosts
+// failing their health check are skipped. When an endpoint pool is
+// configured, the pool picks the host instead.
+func (c *Client) executeWithFailover(ctx context.Context, method, path string, params map[string]interface{}, body interface{}, target interface{}, requestConfig *models.Config) (*models.Response, error) {
+	// Requests pinned to a specific host (e.g. canary or shadow) are sent as-is
+	if requestConfig != nil && requestConfig.BaseURL != "" {
+		return c.executeRequestWithRetry(ctx, method, path, params, body, target, requestConfig)
+	}
+
+	if c.pool != nil {
+		return c.executeBalanced(ctx, method, path, params, body, target, requestConfig)
+	}
+
+	baseURLs := c.health.available(c.stateFor(ctx).merged(requestConfig).BaseURLs)
+	if len(baseURLs) == 1 {
+		hostConfig := overrideConfig(requestConfig, &models.Config{BaseURL: baseURLs[0]})
+		return c.executeRequestWithRetry(ctx, method, path, params, body, target, hostConfig)
+	}
+	if len(baseURLs) == 0 {
+		return c.executeRequestWithRetry(ctx, method, path, params, body, target, requestConfig)
+	}
+
+	var resp *models.Response
+	var err error
+	for _, baseURL := range baseURLs {
+		hostConfig := overrideConfig(requestConfig, &models.Config{BaseURL: baseURL})
+		resp, err = c.executeRequestWithRetry(ctx, method, path, params, body, target, hostConfig)
+		if !shouldFailover(method, resp, err) || ctx.Err() != nil {
+			return resp, err
+		}
+	}
+
+	return resp, err
+}
+
+// shouldFailover reports whether a result warrants trying the next host:
+// a transport error or a 5xx status. Requests that aren't idempotent may
+// already have taken effect, so they only fail over when the connection
+// couldn't be established.
+func shouldFailover(method string, resp *models.Response, err error) bool {
+	if !endpointFailed(resp, err) {
+		return false
+	}
+	return isIdempotent(method) || notSent(err)
+}
+
+// endpointFailed reports whether a result shows the host as unhealthy:
+// a transport error, a 5xx status or an open circuit. Errors such as
+// undecodable bodies or client-side limits say nothing about the host.
+func endpointFailed(resp *models.Response, err error) bool {
+	if err == nil {
+		return resp != nil && resp.StatusCode >= 500
+	}
+
+	var httpErr *errors.HTTPError
+	if stderrors.As(err, &httpErr) {
+		return httpErr.StatusCode >= 500
+	}
+
+	var transportErr *errors.TransportError
+	var circuitErr *errors.CircuitOpenError
+	return stderrors.As(err, &transportErr) || stderrors.As(err, &circuitErr)
+}
+
+// notSent reports whether err means the request never reached the host,
+// because the connection couldn't be established or its circuit is open.
+func notSent(err error) bool {
+	var circuitErr *errors.CircuitOpenError
+	if stderrors.As(err, &circuitErr) {
+		return true
+	}
+
+	var transportErr *errors.TransportError
+	if !stderrors.As(err, &transportErr) {
+		return false
+	}
+	switch transportErr.Kind {
+	case errors.TransportDNS, errors.TransportConnectionRefused, errors.TransportTLS:
+		return true
+	default:
+		return false
+	}
+}
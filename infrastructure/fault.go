@@ -0,0 +1,94 @@
+package infrastructure
+
+import (
+	stderrors "errors"
+	"io"
+	"math/rand"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/fourth-ally/gofetch/domain/errors"
+	"github.com/fourth-ally/gofetch/domain/models"
+)
+
+// ErrInjectedFault is returned for requests dropped by the fault injector.
+var ErrInjectedFault = stderrors.New("gofetch: connection dropped by fault injector")
+
+// faultInjector decides which requests receive artificial faults.
+type faultInjector struct {
+	options *models.FaultOptions
+
+	mu  sync.Mutex
+	rng *rand.Rand
+}
+
+// SetFaultInjector enables chaos mode: requests are randomly delayed,
+// dropped or answered with an error status according to options, so the
+// resilience of callers can be tested without an external proxy.
+// Pass nil to disable it.
+func (c *Client) SetFaultInjector(options *models.FaultOptions) *Client {
+	if options == nil {
+		c.faults = nil
+		return c
+	}
+
+	c.faults = &faultInjector{
+		options: options,
+		rng:     rand.New(rand.NewSource(time.Now().UnixNano())),
+	}
+	return c
+}
+
+// do sends req through send unless a fault is injected.
+func (f *faultInjector) do(req *http.Request, send func(*http.Request) (*http.Response, error)) (*http.Response, error) {
+	if f == nil {
+		return send(req)
+	}
+
+	if f.roll(f.options.LatencyRate) {
+		timer := time.NewTimer(f.options.Latency)
+		select {
+		case <-timer.C:
+		case <-req.Context().Done():
+			timer.Stop()
+			return nil, req.Context().Err()
+		}
+	}
+
+	if f.roll(f.options.DropRate) {
+		return nil, &errors.TransportError{Kind: errors.TransportConnectionReset, Err: ErrInjectedFault}
+	}
+
+	if f.roll(f.options.ErrorRate) {
+		status := f.options.ErrorStatus
+		if status == 0 {
+			status = http.StatusServiceUnavailable
+		}
+
+		return &http.Response{
+			Status:     http.StatusText(status),
+			StatusCode: status,
+			Proto:      "HTTP/1.1",
+			ProtoMajor: 1,
+			ProtoMinor: 1,
+			Header:     http.Header{"X-Gofetch-Fault": []string{"injected"}},
+			Body:       io.NopCloser(strings.NewReader("")),
+			Request:    req,
+		}, nil
+	}
+
+	return send(req)
+}
+
+// roll reports whether an event with the given probability occurs.
+func (f *faultInjector) roll(rate float64) bool {
+	if rate <= 0 {
+		return false
+	}
+
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return f.rng.Float64() < rate
+}
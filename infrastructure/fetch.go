@@ -0,0 +1,145 @@
+package infrastructure
+
+import (
+	"fmt"
+	"mime"
+	"net/http"
+	"sort"
+	"strings"
+
+	"github.com/fourth-ally/gofetch/domain/errors"
+	"github.com/fourth-ally/gofetch/domain/models"
+)
+
+// corsSafelistedHeaders are the request headers allowed in simple requests.
+var corsSafelistedHeaders = map[string]bool{
+	"Accept":           true,
+	"Accept-Language":  true,
+	"Content-Language": true,
+	"Content-Type":     true,
+	"Range":            true,
+}
+
+// simpleContentTypes are the Content-Types allowed in simple requests.
+var simpleContentTypes = map[string]bool{
+	"application/x-www-form-urlencoded": true,
+	"multipart/form-data":               true,
+	"text/plain":                        true,
+}
+
+// simpleMethods are the methods allowed in simple requests.
+var simpleMethods = map[string]bool{
+	http.MethodGet:  true,
+	http.MethodHead: true,
+	http.MethodPost: true,
+}
+
+// forbiddenHeaders are request headers browsers set themselves and drop
+// from fetch() calls, so they never trigger a preflight.
+var forbiddenHeaders = map[string]bool{
+	"Accept-Charset":                 true,
+	"Accept-Encoding":                true,
+	"Access-Control-Request-Headers": true,
+	"Access-Control-Request-Method":  true,
+	"Connection":                     true,
+	"Content-Length":                 true,
+	"Cookie":                         true,
+	"Date":                           true,
+	"Dnt":                            true,
+	"Expect":                         true,
+	"Host":                           true,
+	"Keep-Alive":                     true,
+	"Origin":                         true,
+	"Referer":                        true,
+	"Te":                             true,
+	"Trailer":                        true,
+	"Transfer-Encoding":              true,
+	"Upgrade":                        true,
+	"Via":                            true,
+}
+
+// maxSafelistedValue is the longest value a safelisted header may have.
+const maxSafelistedValue = 128
+
+// SetFetchOptions configures the browser Fetch API used by WebAssembly
+// builds: request mode, credentials, cache mode and redirect handling.
+// With SimpleHeadersOnly, requests are reduced to CORS simple requests to
+// avoid preflight round trips; with ValidateCORS, requests that would
+// need one fail early with a descriptive error. Pass nil to restore the
+// defaults.
+func (c *Client) SetFetchOptions(options *models.FetchOptions) *Client {
+	c.fetchOptions = options
+	c.installFetchTransport(options)
+	return c
+}
+
+// simplifyHeaders removes everything that would trigger a CORS preflight.
+func simplifyHeaders(header http.Header) {
+	for key := range header {
+		if !corsSafelistedHeaders[http.CanonicalHeaderKey(key)] {
+			header.Del(key)
+		}
+	}
+
+	if contentType := header.Get("Content-Type"); contentType != "" {
+		mediaType, _, err := mime.ParseMediaType(contentType)
+		if err != nil || !simpleContentTypes[mediaType] {
+			header.Set("Content-Type", "text/plain;charset=UTF-8")
+		}
+	}
+}
+
+// validateCORS returns a *errors.CORSError if req is a cross-origin
+// request the browser would preflight or block under options.
+func validateCORS(req *http.Request, options *models.FetchOptions) error {
+	if origin := pageOrigin(); origin != "" && origin == req.URL.Scheme+"://"+req.URL.Host {
+		return nil
+	}
+
+	var reasons []string
+	if options.Mode == "same-origin" {
+		reasons = append(reasons, `mode "same-origin" forbids cross-origin requests`)
+	}
+
+	if !simpleMethods[req.Method] {
+		reasons = append(reasons, fmt.Sprintf("method %s is not GET, HEAD or POST", req.Method))
+	}
+
+	var unsafe []string
+	for key, values := range req.Header {
+		key = http.CanonicalHeaderKey(key)
+		switch {
+		case forbiddenHeaders[key] || strings.HasPrefix(key, "Proxy-") || strings.HasPrefix(key, "Sec-"):
+		case !corsSafelistedHeaders[key]:
+			unsafe = append(unsafe, key)
+		case key != "Range" && len(strings.Join(values, ", ")) > maxSafelistedValue:
+			reasons = append(reasons, fmt.Sprintf("header %s is longer than %d bytes", key, maxSafelistedValue))
+		}
+	}
+	if len(unsafe) > 0 {
+		sort.Strings(unsafe)
+		reasons = append(reasons, "headers not CORS-safelisted: "+strings.Join(unsafe, ", "))
+	}
+
+	if contentType := req.Header.Get("Content-Type"); contentType != "" {
+		mediaType, _, err := mime.ParseMediaType(contentType)
+		if err != nil || !simpleContentTypes[mediaType] {
+			reasons = append(reasons, fmt.Sprintf("Content-Type %s is not form data or text/plain", contentType))
+		}
+	}
+
+	if len(reasons) == 0 {
+		return nil
+	}
+
+	corsErr := &errors.CORSError{Method: req.Method, URL: req.URL.String(), Reasons: reasons}
+	switch {
+	case options.Mode == "no-cors":
+		corsErr.Hint = `mode "no-cors" only allows simple requests; the browser will reject this one`
+	case options.Credentials == "include":
+		corsErr.Hint = "credentials are included, so the server must answer the preflight with the exact origin rather than Access-Control-Allow-Origin: * and with Access-Control-Allow-Credentials: true"
+	default:
+		corsErr.Hint = "the server must answer the preflight with matching Access-Control-Allow-Methods and Access-Control-Allow-Headers, or enable SimpleHeadersOnly"
+	}
+	return corsErr
+}
@@ -0,0 +1,168 @@
+//go:build js && wasm
+
+package infrastructure
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"syscall/js"
+
+	"github.com/fourth-ally/gofetch/domain/models"
+)
+
+// fetchTransport is an http.RoundTripper backed by the browser Fetch API.
+// Unlike the standard library transport, it exposes every fetch option.
+type fetchTransport struct {
+	options *models.FetchOptions
+}
+
+// installFetchTransport switches the client to the Fetch API transport.
+func (c *Client) installFetchTransport(options *models.FetchOptions) {
+	if options == nil {
+		if _, ok := c.state.Load().httpClient.Transport.(*fetchTransport); ok {
+			c.state.Load().httpClient.Transport = nil
+		}
+		return
+	}
+
+	c.state.Load().httpClient.Transport = &fetchTransport{options: options}
+}
+
+// pageOrigin returns the origin of the page running the program, or ""
+// in runtimes without a location such as Node.js.
+func pageOrigin() string {
+	location := js.Global().Get("location")
+	if location.IsUndefined() || location.IsNull() {
+		return ""
+	}
+	return location.Get("origin").String()
+}
+
+// RoundTrip implements http.RoundTripper using fetch().
+func (t *fetchTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	ctx := req.Context()
+
+	init := js.Global().Get("Object").New()
+	init.Set("method", req.Method)
+	for key, value := range map[string]string{
+		"mode":        t.options.Mode,
+		"credentials": t.options.Credentials,
+		"cache":       t.options.Cache,
+		"redirect":    t.options.Redirect,
+	} {
+		if value != "" {
+			init.Set(key, value)
+		}
+	}
+
+	abort := js.Global().Get("AbortController")
+	if !abort.IsUndefined() {
+		abort = abort.New()
+		init.Set("signal", abort.Get("signal"))
+	}
+
+	headers := js.Global().Get("Headers").New()
+	for key, values := range req.Header {
+		for _, value := range values {
+			headers.Call("append", key, value)
+		}
+	}
+	init.Set("headers", headers)
+
+	if req.Body != nil {
+		body, err := io.ReadAll(req.Body)
+		req.Body.Close()
+		if err != nil {
+			return nil, err
+		}
+
+		if len(body) > 0 {
+			buf := js.Global().Get("Uint8Array").New(len(body))
+			js.CopyBytesToJS(buf, body)
+			init.Set("body", buf)
+		}
+	}
+
+	result, err := awaitPromise(ctx, abort, js.Global().Call("fetch", req.URL.String(), init))
+	if err != nil {
+		return nil, err
+	}
+
+	header := http.Header{}
+	entries := result.Get("headers").Call("entries")
+	for {
+		next := entries.Call("next")
+		if next.Get("done").Bool() {
+			break
+		}
+		pair := next.Get("value")
+		header.Add(pair.Index(0).String(), pair.Index(1).String())
+	}
+
+	contentLength := int64(-1)
+	if value, err := strconv.ParseInt(header.Get("Content-Length"), 10, 64); err == nil {
+		contentLength = value
+	}
+
+	buffer, err := awaitPromise(ctx, abort, result.Call("arrayBuffer"))
+	if err != nil {
+		return nil, err
+	}
+
+	data := js.Global().Get("Uint8Array").New(buffer)
+	body := make([]byte, data.Get("length").Int())
+	js.CopyBytesToGo(body, data)
+
+	status := result.Get("status").Int()
+	return &http.Response{
+		Status:        fmt.Sprintf("%d %s", status, result.Get("statusText").String()),
+		StatusCode:    status,
+		Header:        header,
+		ContentLength: contentLength,
+		Body:          io.NopCloser(bytes.NewReader(body)),
+		Request:       req,
+	}, nil
+}
+
+// awaitPromise blocks until promise settles or ctx is done, aborting the
+// fetch in the latter case.
+func awaitPromise(ctx context.Context, abort js.Value, promise js.Value) (js.Value, error) {
+	results := make(chan js.Value, 1)
+	failures := make(chan error, 1)
+
+	onSuccess := js.FuncOf(func(this js.Value, args []js.Value) interface{} {
+		results <- args[0]
+		return nil
+	})
+	defer onSuccess.Release()
+
+	onFailure := js.FuncOf(func(this js.Value, args []js.Value) interface{} {
+		failures <- fmt.Errorf("fetch failed: %s", args[0].Call("toString").String())
+		return nil
+	})
+	defer onFailure.Release()
+
+	promise.Call("then", onSuccess, onFailure)
+
+	select {
+	case value := <-results:
+		return value, nil
+	case err := <-failures:
+		return js.Undefined(), err
+	case <-ctx.Done():
+		if !abort.IsUndefined() {
+			abort.Call("abort")
+		}
+
+		// Wait for the promise to settle so the callbacks can be released
+		select {
+		case <-results:
+		case <-failures:
+		}
+		return js.Undefined(), ctx.Err()
+	}
+}
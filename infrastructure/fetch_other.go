@@ -0,0 +1,11 @@
+//go:build !(js && wasm)
+
+package infrastructure
+
+import "github.com/fourth-ally/gofetch/domain/models"
+
+// installFetchTransport is a no-op outside the browser.
+func (c *Client) installFetchTransport(options *models.FetchOptions) {}
+
+// pageOrigin returns "" outside the browser, where there is no page.
+func pageOrigin() string { return "" }
@@ -0,0 +1,62 @@
+package infrastructure
+
+import (
+	"net/http"
+	"strings"
+
+	"github.com/fourth-ally/gofetch/domain/errors"
+)
+
+// SetMaxResponseHeaderBytes limits the total size of response headers,
+// protecting memory against servers that send huge headers. Responses
+// over the limit fail with *errors.HeaderLimitError. Zero uses the
+// net/http default of 1 MiB.
+func (c *Client) SetMaxResponseHeaderBytes(limit int64) *Client {
+	transport := c.transport()
+	if transport == nil {
+		return c
+	}
+
+	transport.MaxResponseHeaderBytes = limit
+	return c
+}
+
+// SetMaxResponseHeaderCount limits the number of response header fields,
+// counting every value of repeated headers. Responses over the limit fail
+// with *errors.HeaderLimitError before their body is read. Zero disables
+// the limit.
+func (c *Client) SetMaxResponseHeaderCount(limit int) *Client {
+	c.maxHeaderCount = limit
+	return c
+}
+
+// checkHeaderCount enforces the header count limit on a response.
+func (c *Client) checkHeaderCount(resp *http.Response) error {
+	if c.maxHeaderCount <= 0 {
+		return nil
+	}
+
+	count := 0
+	for _, values := range resp.Header {
+		count += len(values)
+	}
+
+	if count > c.maxHeaderCount {
+		return &errors.HeaderLimitError{Limit: errors.HeaderLimitCount, Max: int64(c.maxHeaderCount), Actual: int64(count)}
+	}
+	return nil
+}
+
+// headerSizeError converts the transport's header size error into a
+// *errors.HeaderLimitError. net/http reports it only as a plain error.
+func (c *Client) headerSizeError(err error) error {
+	if err == nil || !strings.Contains(err.Error(), "server response headers exceeded") {
+		return err
+	}
+
+	limit := int64(http.DefaultMaxHeaderBytes)
+	if transport, ok := c.state.Load().httpClient.Transport.(*http.Transport); ok && transport.MaxResponseHeaderBytes > 0 {
+		limit = transport.MaxResponseHeaderBytes
+	}
+	return &errors.HeaderLimitError{Limit: errors.HeaderLimitBytes, Max: limit, Err: err}
+}
@@ -0,0 +1,182 @@
+package infrastructure
+
+import (
+	"context"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/fourth-ally/gofetch/domain/models"
+)
+
+// healthChecker tracks the probed state of base URLs.
+type healthChecker struct {
+	mu   sync.RWMutex
+	down map[string]bool
+}
+
+// StartHealthChecks probes options.Path on every configured base URL and
+// endpoint pool member until ctx is cancelled. Endpoints failing their probe
+// are skipped by failover and load balancing until they recover. The first
+// probe round runs immediately. Configure base URLs and the endpoint pool
+// before starting health checks.
+func (c *Client) StartHealthChecks(ctx context.Context, options *models.HealthCheckOptions) *Client {
+	if options == nil {
+		options = models.NewHealthCheckOptions()
+	}
+
+	c.health = &healthChecker{down: make(map[string]bool)}
+	targets := c.healthCheckTargets()
+
+	go func() {
+		ticker := time.NewTicker(options.Interval)
+		defer ticker.Stop()
+
+		for {
+			c.probeAll(ctx, targets, options)
+
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+			}
+		}
+	}()
+
+	return c
+}
+
+// HealthStatus returns the probed state of each checked base URL, or nil
+// if health checks were never started.
+func (c *Client) HealthStatus() map[string]bool {
+	if c.health == nil {
+		return nil
+	}
+
+	c.health.mu.RLock()
+	defer c.health.mu.RUnlock()
+
+	status := make(map[string]bool, len(c.health.down))
+	for baseURL, down := range c.health.down {
+		status[baseURL] = !down
+	}
+	return status
+}
+
+// healthCheckTargets lists the base URLs known to the client.
+func (c *Client) healthCheckTargets() []string {
+	seen := make(map[string]bool)
+	var targets []string
+	add := func(baseURL string) {
+		if baseURL != "" && !seen[baseURL] {
+			seen[baseURL] = true
+			targets = append(targets, baseURL)
+		}
+	}
+
+	add(c.state.Load().config.BaseURL)
+	for _, baseURL := range c.state.Load().config.BaseURLs {
+		add(baseURL)
+	}
+	if c.pool != nil {
+		for _, state := range c.pool.endpoints {
+			add(state.endpoint.URL)
+		}
+	}
+
+	return targets
+}
+
+// probeAll probes every target concurrently and records state changes.
+func (c *Client) probeAll(ctx context.Context, targets []string, options *models.HealthCheckOptions) {
+	var wg sync.WaitGroup
+	for _, baseURL := range targets {
+		wg.Add(1)
+		go func(baseURL string) {
+			defer wg.Done()
+
+			healthy := c.probe(ctx, baseURL, options)
+			if ctx.Err() != nil {
+				return
+			}
+
+			if c.health.set(baseURL, healthy) && options.OnStateChange != nil {
+				options.OnStateChange(baseURL, healthy)
+			}
+		}(baseURL)
+	}
+	wg.Wait()
+}
+
+// probe sends a single health check request, bypassing interceptors and retries.
+func (c *Client) probe(ctx context.Context, baseURL string, options *models.HealthCheckOptions) bool {
+	if options.Timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, options.Timeout)
+		defer cancel()
+	}
+
+	fullURL, err := c.buildURL(baseURL, options.Path, nil)
+	if err != nil {
+		return false
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, fullURL, nil)
+	if err != nil {
+		return false
+	}
+
+	resp, err := c.state.Load().httpClient.Do(req)
+	if err != nil {
+		return false
+	}
+	resp.Body.Close()
+
+	return resp.StatusCode >= 200 && resp.StatusCode < 400
+}
+
+// set records the state of baseURL and reports whether it changed. The
+// first observation of a healthy endpoint is not a change.
+func (h *healthChecker) set(baseURL string, healthy bool) bool {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	down, known := h.down[baseURL]
+	h.down[baseURL] = !healthy
+
+	if !known {
+		return !healthy
+	}
+	return down == healthy
+}
+
+// isDown reports whether baseURL failed its latest probe.
+func (h *healthChecker) isDown(baseURL string) bool {
+	if h == nil {
+		return false
+	}
+
+	h.mu.RLock()
+	defer h.mu.RUnlock()
+	return h.down[baseURL]
+}
+
+// available filters out base URLs that failed their latest probe. If every
+// base URL is down, all of them are returned so requests still go out.
+func (h *healthChecker) available(baseURLs []string) []string {
+	if h == nil {
+		return baseURLs
+	}
+
+	up := make([]string, 0, len(baseURLs))
+	for _, baseURL := range baseURLs {
+		if !h.isDown(baseURL) {
+			up = append(up, baseURL)
+		}
+	}
+
+	if len(up) == 0 {
+		return baseURLs
+	}
+	return up
+}
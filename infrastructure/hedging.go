@@ -0,0 +1,92 @@
+package infrastructure
+
+import (
+	"context"
+	"net/http"
+	"time"
+
+	"github.com/fourth-ally/gofetch/domain/models"
+)
+
+// hedgeResult carries the outcome of a single hedged request.
+type hedgeResult struct {
+	resp   *models.Response
+	err    error
+	hedged bool
+}
+
+// isIdempotent reports whether a method is idempotent as defined by RFC 9110,
+// or one of the safe WebDAV methods PROPFIND (RFC 4918) and REPORT (RFC 3253).
+func isIdempotent(method string) bool {
+	switch method {
+	case http.MethodGet, http.MethodHead, http.MethodOptions, http.MethodTrace,
+		http.MethodPut, http.MethodDelete, "PROPFIND", "REPORT":
+		return true
+	default:
+		return false
+	}
+}
+
+// executeHedged fires the request and, if it has not completed within the
+// configured delay, fires up to MaxHedges additional copies. The first
+// successful response wins and all other in-flight requests are cancelled.
+func (c *Client) executeHedged(ctx context.Context, options *models.HedgingOptions, method, path string, params map[string]interface{}, body interface{}, target interface{}, requestConfig *models.Config) (*models.Response, error) {
+	maxHedges := options.MaxHedges
+	if maxHedges <= 0 {
+		maxHedges = 1
+	}
+
+	hedgeCtx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	// Buffered so that losers never block after the winner returns
+	results := make(chan hedgeResult, maxHedges+1)
+
+	launch := func(hedged bool) {
+		go func() {
+			// Decode into target only once a winner is known to avoid
+			// concurrent writes from competing requests
+			resp, err := c.executeRequest(hedgeCtx, method, path, params, body, nil, requestConfig)
+			results <- hedgeResult{resp: resp, err: err, hedged: hedged}
+		}()
+	}
+
+	launch(false)
+	launched, inFlight := 1, 1
+
+	timer := time.NewTimer(options.Delay)
+	defer timer.Stop()
+
+	var lastResult hedgeResult
+	for {
+		select {
+		case result := <-results:
+			inFlight--
+			if result.err == nil {
+				cancel()
+				if err := c.unmarshalTarget(result.resp.RawBody, result.resp.Headers, target); err != nil {
+					return nil, err
+				}
+				result.resp.Data = target
+				result.resp.Hedged = result.hedged
+				return result.resp, nil
+			}
+
+			lastResult = result
+			if inFlight == 0 {
+				return lastResult.resp, lastResult.err
+			}
+
+		case <-timer.C:
+			if launched <= maxHedges {
+				launch(true)
+				launched++
+				inFlight++
+				timer.Reset(options.Delay)
+			}
+
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		}
+	}
+}
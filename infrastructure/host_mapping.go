@@ -0,0 +1,58 @@
+package infrastructure
+
+import (
+	"context"
+	"net"
+	"strings"
+)
+
+// SetHostMapping dials mapped addresses instead of resolving host names,
+// like entries in /etc/hosts, e.g. to send staging traffic to a canary or
+// point tests at a local server. Keys are a host name or host:port; values
+// are an IP address or IP:port, keeping the original port when it is
+// omitted. The Host header and TLS server name still use the original
+// host. Pass nil to remove the mapping. It has no effect with a Unix
+// socket or a custom RoundTripper.
+func (c *Client) SetHostMapping(mapping map[string]string) *Client {
+	transport := c.transport()
+	if transport == nil {
+		return c
+	}
+
+	c.hostMapping = make(map[string]string, len(mapping))
+	for host, addr := range mapping {
+		c.hostMapping[strings.ToLower(host)] = addr
+	}
+	transport.DialContext = c.dialContext()
+	return c
+}
+
+// mappedDial returns a dial function that replaces mapped addresses before
+// calling dial.
+func mappedDial(dial func(ctx context.Context, network, addr string) (net.Conn, error), mapping map[string]string) func(ctx context.Context, network, addr string) (net.Conn, error) {
+	return func(ctx context.Context, network, addr string) (net.Conn, error) {
+		return dial(ctx, network, mapAddress(addr, mapping))
+	}
+}
+
+// mapAddress returns the mapped address for addr, preferring a host:port
+// entry over a host entry.
+func mapAddress(addr string, mapping map[string]string) string {
+	host, port, err := net.SplitHostPort(addr)
+	if err != nil {
+		return addr
+	}
+	host = strings.ToLower(host)
+
+	target, ok := mapping[net.JoinHostPort(host, port)]
+	if !ok {
+		if target, ok = mapping[host]; !ok {
+			return addr
+		}
+	}
+
+	if _, _, err := net.SplitHostPort(target); err == nil {
+		return target
+	}
+	return net.JoinHostPort(strings.Trim(target, "[]"), port)
+}
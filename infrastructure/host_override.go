@@ -0,0 +1,69 @@
+package infrastructure
+
+import "net/http"
+
+// SetHostOverride sends every request with host as its Host header while
+// connecting to the host of the URL, e.g. to call a server by IP address
+// as one of its virtual hosts. Combine it with SetSNI for HTTPS.
+func (c *Client) SetHostOverride(host string) *Client {
+	c.state.Load().config.HostOverride = host
+	return c
+}
+
+// SetSNI presents serverName in the TLS handshake and verifies the server
+// certificate against it instead of the URL's host. It has no effect with
+// a custom RoundTripper.
+func (c *Client) SetSNI(serverName string) *Client {
+	transport := c.transport()
+	if transport == nil {
+		return c
+	}
+
+	tlsClientConfig(transport).ServerName = serverName
+	return c
+}
+
+// WithHostOverride sets the Host header of a single request, as
+// SetHostOverride does for the client.
+func WithHostOverride(host string) RequestOption {
+	return func(o *requestOptions) {
+		o.config.HostOverride = host
+	}
+}
+
+// WithSNI sets the TLS server name of a single request, as SetSNI does for
+// the client. Connections made with a server name are pooled separately.
+func WithSNI(serverName string) RequestOption {
+	return func(o *requestOptions) {
+		o.config.ServerName = serverName
+	}
+}
+
+// sniTransportKey identifies a transport derived for a TLS server name.
+type sniTransportKey struct {
+	base       *http.Transport
+	serverName string
+}
+
+// sniTransport returns a copy of the transport that presents serverName,
+// reused across requests so its connections are pooled. Custom round
+// trippers are returned unchanged.
+func (c *Client) sniTransport(transport http.RoundTripper, serverName string) http.RoundTripper {
+	base, ok := transport.(*http.Transport)
+	if transport == nil {
+		base, ok = http.DefaultTransport.(*http.Transport), true
+	}
+	if !ok {
+		return transport
+	}
+
+	key := sniTransportKey{base: base, serverName: serverName}
+	if cached, ok := c.sniTransports.Load(key); ok {
+		return cached.(*http.Transport)
+	}
+
+	derived := base.Clone()
+	tlsClientConfig(derived).ServerName = serverName
+	cached, _ := c.sniTransports.LoadOrStore(key, derived)
+	return cached.(*http.Transport)
+}
@@ -0,0 +1,83 @@
+package infrastructure
+
+import (
+	"crypto/rand"
+	"encoding/binary"
+	"fmt"
+	"time"
+
+	"github.com/fourth-ally/gofetch/domain/contracts"
+	"github.com/fourth-ally/gofetch/domain/models"
+)
+
+// UUIDv7Generator generates time-ordered (version 7) UUIDs. It is the
+// default generator of the client.
+var UUIDv7Generator contracts.IDGenerator = contracts.IDGeneratorFunc(newUUIDv7)
+
+// UUIDv4Generator generates random (version 4) UUIDs.
+var UUIDv4Generator contracts.IDGenerator = contracts.IDGeneratorFunc(newUUIDv4)
+
+// SetIDGenerator replaces the generator used for request IDs, idempotency
+// keys and offline queue entries, e.g. to produce ULIDs or snowflake IDs.
+// Pass nil to restore the default UUIDv7 generator.
+func (c *Client) SetIDGenerator(generator contracts.IDGenerator) *Client {
+	c.idGenerator = generator
+	return c
+}
+
+// SetRequestIDHeader sends a generated ID in the named header (e.g.
+// "X-Request-ID") with every call. The ID is shared by all retries and
+// hedges of the call; an explicitly set header is left as is. An empty
+// name disables request IDs.
+func (c *Client) SetRequestIDHeader(name string) *Client {
+	c.requestIDHeader = name
+	return c
+}
+
+// withRequestID adds a fresh request ID to requestConfig when enabled.
+func (c *Client) withRequestID(requestConfig *models.Config) *models.Config {
+	if c.requestIDHeader == "" || headerValue(c.mergedConfig(requestConfig).Headers, c.requestIDHeader) != "" {
+		return requestConfig
+	}
+
+	return overrideConfig(requestConfig, &models.Config{
+		Headers: map[string]string{c.requestIDHeader: c.newID()},
+	})
+}
+
+// newID returns an ID from the configured generator.
+func (c *Client) newID() string {
+	if c.idGenerator == nil {
+		return newUUIDv7()
+	}
+	return c.idGenerator.NewID()
+}
+
+// newUUIDv7 returns a version 7 UUID: a 48-bit Unix millisecond timestamp
+// followed by random bits.
+func newUUIDv7() string {
+	var b [16]byte
+	rand.Read(b[6:])
+
+	var millis [8]byte
+	binary.BigEndian.PutUint64(millis[:], uint64(time.Now().UnixMilli()))
+	copy(b[0:6], millis[2:8])
+
+	b[6] = (b[6] & 0x0f) | 0x70
+	b[8] = (b[8] & 0x3f) | 0x80
+	return formatUUID(b)
+}
+
+// newUUIDv4 returns a random (version 4) UUID.
+func newUUIDv4() string {
+	var b [16]byte
+	rand.Read(b[:])
+	b[6] = (b[6] & 0x0f) | 0x40
+	b[8] = (b[8] & 0x3f) | 0x80
+	return formatUUID(b)
+}
+
+// formatUUID renders b in the canonical 8-4-4-4-12 form.
+func formatUUID(b [16]byte) string {
+	return fmt.Sprintf("%x-%x-%x-%x-%x", b[0:4], b[4:6], b[6:8], b[8:10], b[10:16])
+}
@@ -0,0 +1,34 @@
+package infrastructure
+
+import (
+	"net/http"
+
+	"github.com/fourth-ally/gofetch/domain/models"
+)
+
+// idempotencyKeyHeader is the header carrying the idempotency key.
+const idempotencyKeyHeader = "Idempotency-Key"
+
+// SetIdempotencyKeys enables automatic Idempotency-Key headers on POST and
+// PATCH requests. A key is generated once per call and reused by all of its
+// retries, hedges and failover attempts, so the server can safely
+// deduplicate retried mutations. An explicitly set header is left as is.
+func (c *Client) SetIdempotencyKeys(enabled bool) *Client {
+	c.idempotencyKeys = enabled
+	return c
+}
+
+// withIdempotencyKey adds a fresh idempotency key to requestConfig when enabled.
+func (c *Client) withIdempotencyKey(method string, requestConfig *models.Config) *models.Config {
+	if !c.idempotencyKeys || (method != http.MethodPost && method != http.MethodPatch) {
+		return requestConfig
+	}
+
+	if headerValue(c.mergedConfig(requestConfig).Headers, idempotencyKeyHeader) != "" {
+		return requestConfig
+	}
+
+	return overrideConfig(requestConfig, &models.Config{
+		Headers: map[string]string{idempotencyKeyHeader: c.newID()},
+	})
+}
@@ -0,0 +1,153 @@
+package infrastructure
+
+import (
+	"fmt"
+
+	"github.com/fourth-ally/gofetch/domain/contracts"
+)
+
+// AddNamedRequestInterceptor appends a request interceptor under name, so
+// other components can position their interceptors relative to it.
+func (c *Client) AddNamedRequestInterceptor(name string, interceptor contracts.RequestInterceptor) *Client {
+	c.requestInterceptors, c.requestInterceptorNames = insertInterceptor(c.requestInterceptors, c.requestInterceptorNames, len(c.requestInterceptors), name, interceptor)
+	return c
+}
+
+// PrependRequestInterceptor adds a request interceptor that runs before
+// all others.
+func (c *Client) PrependRequestInterceptor(interceptor contracts.RequestInterceptor) *Client {
+	c.requestInterceptors, c.requestInterceptorNames = insertInterceptor(c.requestInterceptors, c.requestInterceptorNames, 0, "", interceptor)
+	return c
+}
+
+// InsertRequestInterceptorBefore adds a named request interceptor that
+// runs just before the interceptor named anchor. It returns an error if no
+// interceptor is named anchor.
+func (c *Client) InsertRequestInterceptorBefore(anchor, name string, interceptor contracts.RequestInterceptor) error {
+	return c.insertRequestInterceptor(anchor, 0, name, interceptor)
+}
+
+// InsertRequestInterceptorAfter adds a named request interceptor that runs
+// just after the interceptor named anchor. It returns an error if no
+// interceptor is named anchor.
+func (c *Client) InsertRequestInterceptorAfter(anchor, name string, interceptor contracts.RequestInterceptor) error {
+	return c.insertRequestInterceptor(anchor, 1, name, interceptor)
+}
+
+// RemoveRequestInterceptor removes the request interceptor named name and
+// reports whether it was found.
+func (c *Client) RemoveRequestInterceptor(name string) bool {
+	var found bool
+	c.requestInterceptors, c.requestInterceptorNames, found = removeInterceptor(c.requestInterceptors, c.requestInterceptorNames, name)
+	return found
+}
+
+// RequestInterceptorNames returns the names of the request interceptors in
+// execution order. Unnamed interceptors are reported as "".
+func (c *Client) RequestInterceptorNames() []string {
+	return interceptorNames(c.requestInterceptorNames, len(c.requestInterceptors))
+}
+
+// AddNamedResponseInterceptor appends a response interceptor under name.
+func (c *Client) AddNamedResponseInterceptor(name string, interceptor contracts.ResponseInterceptor) *Client {
+	c.responseInterceptors, c.responseInterceptorNames = insertInterceptor(c.responseInterceptors, c.responseInterceptorNames, len(c.responseInterceptors), name, interceptor)
+	return c
+}
+
+// PrependResponseInterceptor adds a response interceptor that runs before
+// all others.
+func (c *Client) PrependResponseInterceptor(interceptor contracts.ResponseInterceptor) *Client {
+	c.responseInterceptors, c.responseInterceptorNames = insertInterceptor(c.responseInterceptors, c.responseInterceptorNames, 0, "", interceptor)
+	return c
+}
+
+// InsertResponseInterceptorBefore adds a named response interceptor that
+// runs just before the interceptor named anchor.
+func (c *Client) InsertResponseInterceptorBefore(anchor, name string, interceptor contracts.ResponseInterceptor) error {
+	return c.insertResponseInterceptor(anchor, 0, name, interceptor)
+}
+
+// InsertResponseInterceptorAfter adds a named response interceptor that
+// runs just after the interceptor named anchor.
+func (c *Client) InsertResponseInterceptorAfter(anchor, name string, interceptor contracts.ResponseInterceptor) error {
+	return c.insertResponseInterceptor(anchor, 1, name, interceptor)
+}
+
+// RemoveResponseInterceptor removes the response interceptor named name
+// and reports whether it was found.
+func (c *Client) RemoveResponseInterceptor(name string) bool {
+	var found bool
+	c.responseInterceptors, c.responseInterceptorNames, found = removeInterceptor(c.responseInterceptors, c.responseInterceptorNames, name)
+	return found
+}
+
+// ResponseInterceptorNames returns the names of the response interceptors
+// in execution order. Unnamed interceptors are reported as "".
+func (c *Client) ResponseInterceptorNames() []string {
+	return interceptorNames(c.responseInterceptorNames, len(c.responseInterceptors))
+}
+
+// insertRequestInterceptor inserts a request interceptor relative to anchor.
+func (c *Client) insertRequestInterceptor(anchor string, offset int, name string, interceptor contracts.RequestInterceptor) error {
+	index := interceptorIndex(c.requestInterceptorNames, anchor)
+	if index < 0 {
+		return fmt.Errorf("request interceptor %q not found", anchor)
+	}
+
+	c.requestInterceptors, c.requestInterceptorNames = insertInterceptor(c.requestInterceptors, c.requestInterceptorNames, index+offset, name, interceptor)
+	return nil
+}
+
+// insertResponseInterceptor inserts a response interceptor relative to anchor.
+func (c *Client) insertResponseInterceptor(anchor string, offset int, name string, interceptor contracts.ResponseInterceptor) error {
+	index := interceptorIndex(c.responseInterceptorNames, anchor)
+	if index < 0 {
+		return fmt.Errorf("response interceptor %q not found", anchor)
+	}
+
+	c.responseInterceptors, c.responseInterceptorNames = insertInterceptor(c.responseInterceptors, c.responseInterceptorNames, index+offset, name, interceptor)
+	return nil
+}
+
+// insertInterceptor inserts interceptor and its name at index. Names are
+// kept parallel to the interceptors, padding for unnamed ones.
+func insertInterceptor[T any](interceptors []T, names []string, index int, name string, interceptor T) ([]T, []string) {
+	names = interceptorNames(names, len(interceptors))
+
+	interceptors = append(interceptors[:index:index], append([]T{interceptor}, interceptors[index:]...)...)
+	names = append(names[:index:index], append([]string{name}, names[index:]...)...)
+	return interceptors, names
+}
+
+// removeInterceptor removes the first interceptor named name.
+func removeInterceptor[T any](interceptors []T, names []string, name string) ([]T, []string, bool) {
+	index := interceptorIndex(names, name)
+	if index < 0 {
+		return interceptors, names, false
+	}
+
+	interceptors = append(interceptors[:index:index], interceptors[index+1:]...)
+	names = append(names[:index:index], names[index+1:]...)
+	return interceptors, names, true
+}
+
+// interceptorIndex returns the position of the interceptor named name, or -1.
+func interceptorIndex(names []string, name string) int {
+	if name == "" {
+		return -1
+	}
+
+	for i, candidate := range names {
+		if candidate == name {
+			return i
+		}
+	}
+	return -1
+}
+
+// interceptorNames returns a copy of names padded to count entries.
+func interceptorNames(names []string, count int) []string {
+	padded := make([]string, count)
+	copy(padded, names)
+	return padded
+}
@@ -0,0 +1,270 @@
+// Package interceptors provides ready-made request and response
+// interceptors for Client.AddRequestInterceptor and
+// Client.AddResponseInterceptor. Interceptors that need both sides of a
+// round trip, such as Logging, Timing and RetryAfter, are types whose
+// Request and Response methods are added separately:
+//
+//	timing := interceptors.NewTiming(record)
+//	client.AddRequestInterceptor(timing.Request).
+//		AddResponseInterceptor(timing.Response)
+package interceptors
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/fourth-ally/gofetch/domain/contracts"
+	"github.com/fourth-ally/gofetch/infrastructure"
+)
+
+// ErrRetryAfter is returned by RetryAfter when a host asked clients to
+// back off for longer than the configured maximum wait.
+var ErrRetryAfter = errors.New("server asked to retry later")
+
+// UserAgent sets the User-Agent header to userAgent on requests that don't
+// set one explicitly.
+func UserAgent(userAgent string) contracts.RequestInterceptor {
+	return func(req *http.Request) (*http.Request, error) {
+		if req.Header.Get("User-Agent") == "" {
+			req.Header.Set("User-Agent", userAgent)
+		}
+		return req, nil
+	}
+}
+
+// RequestID sets header (X-Request-ID if empty) to an ID from generator on
+// requests that don't carry one. A nil generator uses UUIDv7 IDs. Unlike
+// Client.SetRequestIDHeader, every attempt of a retried call gets a new ID.
+func RequestID(header string, generator contracts.IDGenerator) contracts.RequestInterceptor {
+	if header == "" {
+		header = "X-Request-ID"
+	}
+	if generator == nil {
+		generator = infrastructure.UUIDv7Generator
+	}
+
+	return func(req *http.Request) (*http.Request, error) {
+		if req.Header.Get(header) == "" {
+			req.Header.Set(header, generator.NewID())
+		}
+		return req, nil
+	}
+}
+
+// incomingHeadersKey is the context key of the headers to propagate.
+type incomingHeadersKey struct{}
+
+// WithIncomingHeaders returns a context carrying the headers of an
+// incoming request, for HeaderPropagation to copy onto outgoing requests.
+// Server handlers typically call it with r.Context() and r.Header.
+func WithIncomingHeaders(ctx context.Context, headers http.Header) context.Context {
+	return context.WithValue(ctx, incomingHeadersKey{}, headers)
+}
+
+// HeaderPropagation copies the named headers, such as traceparent or
+// X-Request-ID, from the headers stored with WithIncomingHeaders in the
+// request context. Headers the outgoing request already sets are kept.
+func HeaderPropagation(names ...string) contracts.RequestInterceptor {
+	return func(req *http.Request) (*http.Request, error) {
+		incoming, _ := req.Context().Value(incomingHeadersKey{}).(http.Header)
+		for _, name := range names {
+			values := incoming.Values(name)
+			if len(values) == 0 || req.Header.Get(name) != "" {
+				continue
+			}
+			for _, value := range values {
+				req.Header.Add(name, value)
+			}
+		}
+		return req, nil
+	}
+}
+
+// BearerAuth sets the Authorization header to a bearer token from token,
+// which is called for every request so it can refresh expiring tokens.
+// Requests that already set Authorization are left as is.
+func BearerAuth(token func(ctx context.Context) (string, error)) contracts.RequestInterceptor {
+	return func(req *http.Request) (*http.Request, error) {
+		if req.Header.Get("Authorization") != "" {
+			return req, nil
+		}
+
+		value, err := token(req.Context())
+		if err != nil {
+			return nil, fmt.Errorf("bearer token: %w", err)
+		}
+		req.Header.Set("Authorization", "Bearer "+value)
+		return req, nil
+	}
+}
+
+// StaticToken returns a token function for BearerAuth that always returns
+// token.
+func StaticToken(token string) func(ctx context.Context) (string, error) {
+	return func(context.Context) (string, error) {
+		return token, nil
+	}
+}
+
+// startKey is the context key of the time a request was sent.
+type startKey struct{}
+
+// markStart records the send time of req in its context.
+func markStart(req *http.Request) *http.Request {
+	return req.WithContext(context.WithValue(req.Context(), startKey{}, time.Now()))
+}
+
+// elapsed returns the time since the request of resp was sent, or zero if
+// it wasn't recorded.
+func elapsed(resp *http.Response) time.Duration {
+	if resp.Request == nil {
+		return 0
+	}
+	if started, ok := resp.Request.Context().Value(startKey{}).(time.Time); ok {
+		return time.Since(started)
+	}
+	return 0
+}
+
+// Logging logs every request and response to a slog.Logger.
+type Logging struct {
+	logger *slog.Logger
+	level  slog.Level
+}
+
+// NewLogging creates a logging interceptor writing to logger at level. A
+// nil logger uses slog.Default().
+func NewLogging(logger *slog.Logger, level slog.Level) *Logging {
+	if logger == nil {
+		logger = slog.Default()
+	}
+	return &Logging{logger: logger, level: level}
+}
+
+// Request logs the method and redacted URL of req.
+func (l *Logging) Request(req *http.Request) (*http.Request, error) {
+	l.logger.Log(req.Context(), l.level, "sending request",
+		slog.String("method", req.Method),
+		slog.String("url", req.URL.Redacted()),
+	)
+	return markStart(req), nil
+}
+
+// Response logs the status of resp and how long the round trip took.
+func (l *Logging) Response(resp *http.Response) (*http.Response, error) {
+	ctx := context.Background()
+	attrs := []slog.Attr{slog.Int("status", resp.StatusCode), slog.Duration("duration", elapsed(resp))}
+	if resp.Request != nil {
+		ctx = resp.Request.Context()
+		attrs = append(attrs,
+			slog.String("method", resp.Request.Method),
+			slog.String("url", resp.Request.URL.Redacted()),
+		)
+	}
+	l.logger.LogAttrs(ctx, l.level, "received response", attrs...)
+	return resp, nil
+}
+
+// Timing reports the duration of every round trip, e.g. to a metrics
+// histogram. The duration covers the time until response headers arrive.
+type Timing struct {
+	record func(resp *http.Response, duration time.Duration)
+}
+
+// NewTiming creates a timing interceptor calling record for every
+// response.
+func NewTiming(record func(resp *http.Response, duration time.Duration)) *Timing {
+	return &Timing{record: record}
+}
+
+// Request records the send time of req.
+func (t *Timing) Request(req *http.Request) (*http.Request, error) {
+	return markStart(req), nil
+}
+
+// Response reports the duration of the round trip of resp.
+func (t *Timing) Response(resp *http.Response) (*http.Response, error) {
+	t.record(resp, elapsed(resp))
+	return resp, nil
+}
+
+// RetryAfter holds back requests to hosts that answered 429 or 503 with a
+// Retry-After header until the requested time, so every caller sharing a
+// client backs off, not only the retries of the throttled call.
+type RetryAfter struct {
+	maxWait time.Duration
+
+	mu    sync.Mutex
+	until map[string]time.Time
+}
+
+// NewRetryAfter creates a Retry-After interceptor. Requests wait at most
+// maxWait for a host to accept requests again; longer back-offs fail
+// immediately with ErrRetryAfter. A zero maxWait never waits.
+func NewRetryAfter(maxWait time.Duration) *RetryAfter {
+	return &RetryAfter{maxWait: maxWait, until: make(map[string]time.Time)}
+}
+
+// Request waits until the host of req accepts requests again.
+func (r *RetryAfter) Request(req *http.Request) (*http.Request, error) {
+	r.mu.Lock()
+	wait := time.Until(r.until[req.URL.Host])
+	if wait <= 0 {
+		delete(r.until, req.URL.Host)
+	}
+	r.mu.Unlock()
+	if wait <= 0 {
+		return req, nil
+	}
+	if wait > r.maxWait {
+		return nil, fmt.Errorf("%w: %s is unavailable for %s", ErrRetryAfter, req.URL.Host, wait.Round(time.Second))
+	}
+
+	timer := time.NewTimer(wait)
+	defer timer.Stop()
+	select {
+	case <-timer.C:
+		return req, nil
+	case <-req.Context().Done():
+		return nil, req.Context().Err()
+	}
+}
+
+// Response records the Retry-After time of throttled responses.
+func (r *RetryAfter) Response(resp *http.Response) (*http.Response, error) {
+	if resp.Request == nil || (resp.StatusCode != http.StatusTooManyRequests && resp.StatusCode != http.StatusServiceUnavailable) {
+		return resp, nil
+	}
+
+	until, ok := retryAfterTime(resp.Header.Get("Retry-After"))
+	if !ok {
+		return resp, nil
+	}
+
+	r.mu.Lock()
+	if until.After(r.until[resp.Request.URL.Host]) {
+		r.until[resp.Request.URL.Host] = until
+	}
+	r.mu.Unlock()
+	return resp, nil
+}
+
+// retryAfterTime parses a Retry-After value in seconds or as an HTTP date.
+func retryAfterTime(value string) (time.Time, bool) {
+	if value == "" {
+		return time.Time{}, false
+	}
+	if seconds, err := strconv.Atoi(value); err == nil && seconds >= 0 {
+		return time.Now().Add(time.Duration(seconds) * time.Second), true
+	}
+	if at, err := http.ParseTime(value); err == nil {
+		return at, true
+	}
+	return time.Time{}, false
+}
@@ -0,0 +1,62 @@
+package infrastructure
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/fourth-ally/gofetch/domain/models"
+)
+
+// journal writes completed exchanges as JSON lines.
+type journal struct {
+	mu      sync.Mutex
+	encoder *json.Encoder
+}
+
+// SetJournal records every request that gets a response, with the
+// response status and body, as one models.RecordedExchange JSON line on w,
+// for later use with LoadJournal and Replay. Credential headers are
+// redacted. Raw and streamed responses are not recorded. Write errors
+// are ignored. Pass nil to stop recording.
+func (c *Client) SetJournal(w io.Writer) *Client {
+	if w == nil {
+		c.journal = nil
+		return c
+	}
+	c.journal = &journal{encoder: json.NewEncoder(w)}
+	return c
+}
+
+// record writes the exchange of req. It does nothing on a nil journal.
+func (j *journal) record(req *http.Request, status int, respBody []byte) {
+	if j == nil {
+		return
+	}
+
+	headers := make(map[string]string, len(req.Header))
+	for key := range req.Header {
+		headers[key] = req.Header.Get(key)
+	}
+
+	exchange := models.RecordedExchange{
+		Method:       req.Method,
+		URL:          req.URL.String(),
+		Headers:      models.RedactHeaders(headers),
+		Status:       status,
+		ResponseBody: respBody,
+		RecordedAt:   time.Now(),
+	}
+	if req.GetBody != nil {
+		if body, err := req.GetBody(); err == nil {
+			exchange.Body, _ = io.ReadAll(body)
+			body.Close()
+		}
+	}
+
+	j.mu.Lock()
+	defer j.mu.Unlock()
+	j.encoder.Encode(exchange)
+}
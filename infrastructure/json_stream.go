@@ -0,0 +1,122 @@
+package infrastructure
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+
+	"github.com/fourth-ally/gofetch/domain/errors"
+	"github.com/fourth-ally/gofetch/domain/models"
+)
+
+// DecodeJSON performs a GET request and decodes the JSON response body
+// into target as it arrives, without first buffering the whole body, so
+// large payloads need roughly half the memory of Get. The returned
+// response carries the status, headers and Data but no RawBody. The
+// client timeout does not apply; bound the request with ctx instead.
+// Responses rejected by the status validator are returned as
+// *errors.HTTPError. Retries, caching and hedging don't apply.
+func (c *Client) DecodeJSON(ctx context.Context, path string, params map[string]interface{}, target interface{}) (*models.Response, error) {
+	return c.decodeJSONStream(ctx, path, params, func(decoder *json.Decoder) error {
+		return decoder.Decode(target)
+	}, target)
+}
+
+// StreamJSONArray performs a GET request whose response body is a JSON
+// array and calls onElement once per element as it is read. onElement
+// receives a decode function that unmarshals the current element into v;
+// only one element is held in memory at a time. Streaming stops at the
+// first error returned by onElement. Otherwise it behaves like DecodeJSON.
+func (c *Client) StreamJSONArray(ctx context.Context, path string, params map[string]interface{}, onElement func(decode func(v interface{}) error) error) (*models.Response, error) {
+	return c.decodeJSONStream(ctx, path, params, func(decoder *json.Decoder) error {
+		token, err := decoder.Token()
+		if err != nil {
+			return err
+		}
+		if delim, ok := token.(json.Delim); !ok || delim != '[' {
+			return fmt.Errorf("expected JSON array, got %v", token)
+		}
+
+		for decoder.More() {
+			decoded := false
+			var decodeErr error
+			err := onElement(func(v interface{}) error {
+				decoded = true
+				decodeErr = decoder.Decode(v)
+				return decodeErr
+			})
+			if err != nil && err == decodeErr {
+				return err
+			}
+			if err != nil {
+				return &elementError{err}
+			}
+			// Skip elements the callback chose not to decode
+			if !decoded {
+				var skipped json.RawMessage
+				if err := decoder.Decode(&skipped); err != nil {
+					return err
+				}
+			}
+		}
+
+		_, err = decoder.Token()
+		return err
+	}, nil)
+}
+
+// elementError marks an error returned by a StreamJSONArray callback so
+// it is passed through unwrapped.
+type elementError struct {
+	err error
+}
+
+func (e *elementError) Error() string {
+	return e.err.Error()
+}
+
+// decodeJSONStream opens a GET stream and runs decode over its body,
+// telling transport failures apart from malformed JSON.
+func (c *Client) decodeJSONStream(ctx context.Context, path string, params map[string]interface{}, decode func(*json.Decoder) error, target interface{}) (*models.Response, error) {
+	resp, err := c.openStream(ctx, http.MethodGet, path, params, nil, nil)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	body := &readErrorRecorder{reader: c.progressBody(resp)}
+	if err := decode(json.NewDecoder(body)); err != nil {
+		if elementErr, ok := err.(*elementError); ok {
+			return nil, elementErr.err
+		}
+		if ctx.Err() != nil {
+			return nil, ctx.Err()
+		}
+		if body.err != nil {
+			return nil, fmt.Errorf("failed to read response body: %w", errors.ClassifyTransportError(body.err))
+		}
+		return nil, &errors.DecodeError{Err: err}
+	}
+
+	response := models.NewResponse(resp.StatusCode, resp.Header, target, nil)
+	response.BaseURL = c.mergedConfig(nil).BaseURL
+	response.Decompressed = resp.Uncompressed
+	return response, nil
+}
+
+// readErrorRecorder remembers the first non-EOF error returned by reader,
+// since json.Decoder reports read failures like syntax errors.
+type readErrorRecorder struct {
+	reader io.Reader
+	err    error
+}
+
+func (r *readErrorRecorder) Read(p []byte) (int, error) {
+	n, err := r.reader.Read(p)
+	if err != nil && err != io.EOF && r.err == nil {
+		r.err = err
+	}
+	return n, err
+}
@@ -0,0 +1,139 @@
+package infrastructure
+
+import (
+	"context"
+	stderrors "errors"
+	"fmt"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/fourth-ally/gofetch/domain/errors"
+	"github.com/fourth-ally/gofetch/domain/models"
+)
+
+// errResourceExpired signals a 410 Gone from inside a watch stream.
+var errResourceExpired = stderrors.New("resource version expired")
+
+// kubeObject holds the parts of a Kubernetes object the watch inspects.
+type kubeObject struct {
+	Kind     string `json:"kind"`
+	Code     int    `json:"code"`
+	Message  string `json:"message"`
+	Metadata struct {
+		ResourceVersion string `json:"resourceVersion"`
+	} `json:"metadata"`
+}
+
+// WatchKubernetes watches a Kubernetes collection such as
+// "/api/v1/namespaces/:namespace/pods", calling onEvent with each ADDED,
+// MODIFIED and DELETED event until ctx is cancelled or onEvent returns an
+// error. It tracks the resource version of every event and bookmark and
+// reconnects from it whenever the server ends the stream. When the
+// version has expired (410 Gone) it calls options.Relist and resumes from
+// the version it returns, or fails with *errors.ResourceExpiredError if
+// Relist is nil. Other ERROR events are returned as *errors.HTTPError.
+func (c *Client) WatchKubernetes(ctx context.Context, path string, params map[string]interface{}, options *models.KubeWatchOptions, onEvent func(models.KubeWatchEvent) error) error {
+	if options == nil {
+		options = models.NewKubeWatchOptions()
+	}
+	resourceVersion := options.ResourceVersion
+
+	for {
+		var expired string
+		err := c.StreamNDJSON(ctx, path, kubeWatchParams(params, options, resourceVersion), func(line []byte) error {
+			var event models.KubeWatchEvent
+			if err := c.jsonCodec().Unmarshal(line, &event); err != nil {
+				return fmt.Errorf("failed to decode watch event: %w", err)
+			}
+
+			var object kubeObject
+			if err := c.jsonCodec().Unmarshal(event.Object, &object); err != nil {
+				return fmt.Errorf("failed to decode watch event object: %w", err)
+			}
+
+			switch event.Type {
+			case models.KubeEventError:
+				if object.Code == http.StatusGone {
+					expired = object.Message
+					return errResourceExpired
+				}
+				return &errors.HTTPError{StatusCode: object.Code, Body: event.Object, Message: object.Message, BodyLength: int64(len(event.Object))}
+			case models.KubeEventBookmark:
+				resourceVersion = object.Metadata.ResourceVersion
+				return nil
+			}
+
+			if object.Metadata.ResourceVersion != "" {
+				resourceVersion = object.Metadata.ResourceVersion
+			}
+			return onEvent(event)
+		})
+
+		var httpErr *errors.HTTPError
+		if stderrors.As(err, &httpErr) && httpErr.StatusCode == http.StatusGone {
+			expired, err = httpErr.Message, errResourceExpired
+		}
+
+		if err == errResourceExpired {
+			if options.Relist == nil {
+				return &errors.ResourceExpiredError{ResourceVersion: resourceVersion, Message: expired}
+			}
+			if resourceVersion, err = options.Relist(ctx); err != nil {
+				return err
+			}
+			continue
+		}
+
+		if err != nil {
+			return err
+		}
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+	}
+}
+
+// kubeWatchParams adds the watch query parameters to params.
+func kubeWatchParams(params map[string]interface{}, options *models.KubeWatchOptions, resourceVersion string) map[string]interface{} {
+	watchParams := make(map[string]interface{}, len(params)+6)
+	for key, value := range params {
+		watchParams[key] = value
+	}
+
+	watchParams["watch"] = "true"
+	if options.LabelSelector != "" {
+		watchParams["labelSelector"] = options.LabelSelector
+	}
+	if options.FieldSelector != "" {
+		watchParams["fieldSelector"] = options.FieldSelector
+	}
+	if resourceVersion != "" {
+		watchParams["resourceVersion"] = resourceVersion
+	}
+	if options.AllowBookmarks {
+		watchParams["allowWatchBookmarks"] = "true"
+	}
+	if options.TimeoutSeconds > 0 {
+		watchParams["timeoutSeconds"] = strconv.Itoa(options.TimeoutSeconds)
+	}
+
+	return watchParams
+}
+
+// FormatSelector builds an equality-based label or field selector such as
+// "app=web,tier=frontend" from a map, sorted by key for stable URLs.
+func FormatSelector(selector map[string]string) string {
+	keys := make([]string, 0, len(selector))
+	for key := range selector {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	requirements := make([]string, len(keys))
+	for i, key := range keys {
+		requirements[i] = key + "=" + selector[key]
+	}
+	return strings.Join(requirements, ",")
+}
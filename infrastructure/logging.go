@@ -0,0 +1,68 @@
+package infrastructure
+
+import (
+	"context"
+	"log/slog"
+	"net/http"
+	"time"
+)
+
+// discardLogger is used when no logger is configured.
+var discardLogger = slog.New(slog.DiscardHandler)
+
+// loggerKey is the context key for request-scoped loggers.
+type loggerKey struct{}
+
+// SetLogger sets the logger used for debug output of every request.
+// Pass nil to disable logging.
+func (c *Client) SetLogger(logger *slog.Logger) *Client {
+	c.logger = logger
+	return c
+}
+
+// WithLogger returns a context whose requests log to logger instead of the
+// client's logger, e.g. a logger carrying the tenant or trace ID of the
+// server request being handled.
+func WithLogger(ctx context.Context, logger *slog.Logger) context.Context {
+	return context.WithValue(ctx, loggerKey{}, logger)
+}
+
+// loggerFor returns the request-scoped logger, falling back to the
+// client's logger.
+func (c *Client) loggerFor(ctx context.Context) *slog.Logger {
+	if logger, ok := ctx.Value(loggerKey{}).(*slog.Logger); ok && logger != nil {
+		return logger
+	}
+	if c.logger != nil {
+		return c.logger
+	}
+	return discardLogger
+}
+
+// logRoundTrip logs the outcome of a single HTTP round trip.
+func (c *Client) logRoundTrip(ctx context.Context, req *http.Request, resp *http.Response, started time.Time, err error) {
+	logger := c.loggerFor(ctx)
+	if !logger.Enabled(ctx, slog.LevelDebug) {
+		return
+	}
+	status := 0
+	if resp != nil {
+		status = resp.StatusCode
+	}
+	if !c.captures(req, status) {
+		return
+	}
+
+	attrs := []any{
+		slog.String("method", req.Method),
+		slog.String("url", req.URL.Redacted()),
+		slog.Duration("duration", time.Since(started)),
+	}
+
+	if err != nil {
+		logger.DebugContext(ctx, "gofetch: request failed", append(attrs, slog.Any("error", err))...)
+		return
+	}
+
+	logger.DebugContext(ctx, "gofetch: request completed", append(attrs, slog.Int("status", resp.StatusCode))...)
+}
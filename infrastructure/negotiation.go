@@ -0,0 +1,119 @@
+package infrastructure
+
+import (
+	"encoding"
+	"encoding/xml"
+	"fmt"
+	"path"
+	"strings"
+
+	"github.com/fourth-ally/gofetch/domain/contracts"
+)
+
+// xmlContentType is the Content-Type of XML bodies.
+const xmlContentType = "application/xml; charset=utf-8"
+
+// XMLCodec encodes and decodes XML with encoding/xml.
+var XMLCodec contracts.Codec = xmlCodec{}
+
+// TextCodec decodes text responses into a *string, *[]byte or
+// encoding.TextUnmarshaler and encodes the same kinds of values.
+var TextCodec contracts.Codec = textCodec{}
+
+// xmlCodec implements contracts.Codec with encoding/xml.
+type xmlCodec struct{}
+
+// ContentType implements contracts.Codec.
+func (xmlCodec) ContentType() string { return xmlContentType }
+
+// Marshal implements contracts.Codec.
+func (xmlCodec) Marshal(v interface{}) ([]byte, error) { return xml.Marshal(v) }
+
+// Unmarshal implements contracts.Codec.
+func (xmlCodec) Unmarshal(data []byte, v interface{}) error { return xml.Unmarshal(data, v) }
+
+// textCodec implements contracts.Codec for plain text.
+type textCodec struct{}
+
+// ContentType implements contracts.Codec.
+func (textCodec) ContentType() string { return textContentType }
+
+// Marshal implements contracts.Codec.
+func (textCodec) Marshal(v interface{}) ([]byte, error) {
+	switch value := v.(type) {
+	case string:
+		return []byte(value), nil
+	case []byte:
+		return value, nil
+	case encoding.TextMarshaler:
+		return value.MarshalText()
+	default:
+		return nil, fmt.Errorf("text codec can't encode %T", v)
+	}
+}
+
+// Unmarshal implements contracts.Codec.
+func (textCodec) Unmarshal(data []byte, v interface{}) error {
+	switch target := v.(type) {
+	case *string:
+		*target = string(data)
+	case *[]byte:
+		*target = append((*target)[:0], data...)
+	case *interface{}:
+		*target = string(data)
+	case encoding.TextUnmarshaler:
+		return target.UnmarshalText(data)
+	default:
+		return fmt.Errorf("text codec can't decode into %T", v)
+	}
+	return nil
+}
+
+// SetAccept sets the Accept header to mediaTypes, in order of preference
+// and with optional quality values, e.g. SetAccept("application/json",
+// "application/xml;q=0.9", "text/*;q=0.5"). Responses whose Content-Type
+// matches an accepted type are then decoded by that type: XML (including
+// +xml types) with XMLCodec, text with TextCodec and JSON with the JSON
+// codec. Codecs registered with RegisterCodec take precedence.
+func (c *Client) SetAccept(mediaTypes ...string) *Client {
+	accept := make([]string, 0, len(mediaTypes))
+	for _, mediaType := range mediaTypes {
+		accept = append(accept, baseMediaType(mediaType))
+	}
+
+	c.accept = accept
+	if len(mediaTypes) == 0 {
+		delete(c.state.Load().config.Headers, "Accept")
+		return c
+	}
+	return c.SetHeader("Accept", strings.Join(mediaTypes, ", "))
+}
+
+// negotiatedCodec returns the built-in codec for a response of mediaType
+// if the client accepts it, or nil.
+func (c *Client) negotiatedCodec(mediaType string) contracts.Codec {
+	if !c.accepts(mediaType) {
+		return nil
+	}
+
+	switch {
+	case isJSONMediaType(mediaType):
+		return c.jsonCodec()
+	case mediaType == "application/xml" || mediaType == "text/xml" || strings.HasSuffix(mediaType, "+xml"):
+		return XMLCodec
+	case strings.HasPrefix(mediaType, "text/"):
+		return TextCodec
+	}
+	return nil
+}
+
+// accepts reports whether mediaType matches one of the types set with
+// SetAccept, including wildcards like "text/*".
+func (c *Client) accepts(mediaType string) bool {
+	for _, accepted := range c.accept {
+		if matched, _ := path.Match(accepted, mediaType); matched {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,205 @@
+package infrastructure
+
+import (
+	"context"
+	stderrors "errors"
+	"fmt"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/fourth-ally/gofetch/domain/contracts"
+	"github.com/fourth-ally/gofetch/domain/errors"
+	"github.com/fourth-ally/gofetch/domain/models"
+)
+
+// ErrRequestQueued is returned, joined with the network error, when a
+// mutating request was stored in the offline queue instead of being sent.
+var ErrRequestQueued = stderrors.New("gofetch: request queued until network is available")
+
+// offlineQueue holds mutating requests that could not reach the network.
+type offlineQueue struct {
+	store   contracts.QueueStore
+	options *models.OfflineQueueOptions
+
+	// flushing serializes flushes so requests are replayed in order.
+	flushing sync.Mutex
+}
+
+// SetOfflineQueue enables the offline queue: POST, PUT, PATCH and DELETE
+// requests failing because the network is unreachable are persisted in
+// store with their encoded bodies and replayed in order once connectivity
+// returns: after the next successful request, before the next mutating
+// request, or via FlushOfflineQueue. Mutations made while the queue can't
+// be flushed are queued behind it. Pass a nil store to disable queueing.
+func (c *Client) SetOfflineQueue(store contracts.QueueStore, options *models.OfflineQueueOptions) *Client {
+	if store == nil {
+		c.offlineQueue = nil
+		return c
+	}
+
+	if options == nil {
+		options = &models.OfflineQueueOptions{}
+	}
+
+	c.offlineQueue = &offlineQueue{store: store, options: options}
+	return c
+}
+
+// FlushOfflineQueue replays queued requests in order. It stops at the first
+// request that still cannot reach the network and returns that error.
+func (c *Client) FlushOfflineQueue(ctx context.Context) error {
+	if c.offlineQueue == nil {
+		return nil
+	}
+
+	q := c.offlineQueue
+	q.flushing.Lock()
+	defer q.flushing.Unlock()
+
+	return c.flushOfflineQueue(ctx)
+}
+
+// flushOfflineQueue replays queued requests in order. The caller holds the
+// flushing lock.
+func (c *Client) flushOfflineQueue(ctx context.Context) error {
+	q := c.offlineQueue
+	requests, err := q.store.List()
+	if err != nil {
+		return fmt.Errorf("failed to list offline queue: %w", err)
+	}
+
+	for _, queued := range requests {
+		var body interface{}
+		requestConfig := &models.Config{Headers: queued.Headers}
+		if len(queued.Body) > 0 {
+			body = queued.Body
+			requestConfig.Headers = withContentType(queued.Headers, queued.ContentType)
+		}
+
+		resp, err := c.dispatch(c.withState(ctx), queued.Method, queued.Path, queued.Params, body, nil, requestConfig)
+		if isOffline(err) || ctx.Err() != nil {
+			return err
+		}
+
+		if removeErr := q.store.Remove(queued.ID); removeErr != nil {
+			return fmt.Errorf("failed to remove request from offline queue: %w", removeErr)
+		}
+
+		if q.options.OnFlushed != nil {
+			q.options.OnFlushed(queued, resp, err)
+		}
+	}
+
+	return nil
+}
+
+// executeQueueable sends a mutating request, queueing it if the network is
+// down. Requests already queued are replayed first; while they can't be
+// sent, the request is queued behind them so the server sees mutations in
+// order.
+func (c *Client) executeQueueable(ctx context.Context, method, path string, params map[string]interface{}, body interface{}, target interface{}, requestConfig *models.Config) (*models.Response, error) {
+	q := c.offlineQueue
+	q.flushing.Lock()
+	err := c.flushOfflineQueue(ctx)
+	q.flushing.Unlock()
+	if err != nil && (!isOffline(err) || ctx.Err() != nil) {
+		return nil, err
+	}
+
+	var resp *models.Response
+	if err == nil {
+		resp, err = c.dispatch(ctx, method, path, params, body, target, requestConfig)
+		if !isOffline(err) || ctx.Err() != nil {
+			return resp, err
+		}
+	}
+
+	queued := &models.QueuedRequest{
+		ID:         c.newID(),
+		Method:     method,
+		Path:       path,
+		Params:     params,
+		EnqueuedAt: time.Now(),
+	}
+	config := c.stateFor(ctx).merged(requestConfig)
+	if requestConfig != nil {
+		queued.Headers = requestConfig.Headers
+	}
+	if body != nil {
+		data, contentType, encodeErr := c.encodeBody(body)
+		if encodeErr != nil {
+			return nil, err
+		}
+		queued.Body = data
+		queued.ContentType = resolveContentType(config.ContentType, contentType)
+	}
+
+	if appendErr := q.store.Append(queued); appendErr != nil {
+		return nil, stderrors.Join(err, fmt.Errorf("failed to queue request: %w", appendErr))
+	}
+
+	if q.options.OnEnqueued != nil {
+		q.options.OnEnqueued(queued)
+	}
+
+	return nil, stderrors.Join(ErrRequestQueued, err)
+}
+
+// withContentType returns a copy of headers with Content-Type set to
+// contentType, unless headers set one already.
+func withContentType(headers map[string]string, contentType string) map[string]string {
+	if contentType == "" || headerValue(headers, "Content-Type") != "" {
+		return headers
+	}
+
+	result := make(map[string]string, len(headers)+1)
+	for key, value := range headers {
+		result[key] = value
+	}
+	result["Content-Type"] = contentType
+	return result
+}
+
+// flushOfflineQueueInBackground replays queued requests after connectivity
+// has been observed, unless a flush is already running.
+func (c *Client) flushOfflineQueueInBackground() {
+	q := c.offlineQueue
+	if requests, err := q.store.List(); err != nil || len(requests) == 0 {
+		return
+	}
+
+	go func() {
+		if !q.flushing.TryLock() {
+			return
+		}
+		q.flushing.Unlock()
+
+		c.FlushOfflineQueue(context.Background())
+	}()
+}
+
+// isQueueable reports whether requests with method may be queued offline.
+func isQueueable(method string) bool {
+	switch method {
+	case http.MethodPost, http.MethodPut, http.MethodPatch, http.MethodDelete:
+		return true
+	default:
+		return false
+	}
+}
+
+// isOffline reports whether err means the request never reached a server.
+func isOffline(err error) bool {
+	var transportErr *errors.TransportError
+	if !stderrors.As(err, &transportErr) {
+		return false
+	}
+
+	switch transportErr.Kind {
+	case errors.TransportDNS, errors.TransportConnectionRefused, errors.TransportUnknown:
+		return true
+	default:
+		return false
+	}
+}
@@ -0,0 +1,208 @@
+package infrastructure
+
+import (
+	"context"
+	"io"
+	"net/http/httptrace"
+	"strings"
+	"time"
+
+	"github.com/fourth-ally/gofetch/domain/models"
+)
+
+// RequestOption customizes a single request without changing the client.
+type RequestOption func(*requestOptions)
+
+// requestOptions collects the overrides of a single request.
+type requestOptions struct {
+	config *models.Config
+	params map[string]interface{}
+}
+
+// WithTimeout overrides the client timeout for a single request.
+func WithTimeout(timeout time.Duration) RequestOption {
+	return func(o *requestOptions) {
+		o.config.Timeout = timeout
+	}
+}
+
+// WithHeader sets a header for a single request, overriding the client default.
+func WithHeader(key, value string) RequestOption {
+	return func(o *requestOptions) {
+		o.config.Headers[key] = value
+	}
+}
+
+// WithQuery sets a query or path parameter for a single request,
+// overriding a parameter of the same name passed to the verb method.
+func WithQuery(key string, value interface{}) RequestOption {
+	return func(o *requestOptions) {
+		if o.params == nil {
+			o.params = make(map[string]interface{})
+		}
+		o.params[key] = value
+	}
+}
+
+// WithRetryPolicy overrides the client retry options for a single request,
+// e.g. to retry a non-idempotent call that is safe to repeat, or
+// models.RetryOptions{MaxRetries: 0} to disable retries. The client's
+// circuit breaker and backoff still apply.
+func WithRetryPolicy(options *models.RetryOptions) RequestOption {
+	return func(o *requestOptions) {
+		o.config.RetryOptions = options
+	}
+}
+
+// WithCacheControl sets the Cache-Control directives of a single request,
+// e.g. WithCacheControl(models.CacheMaxStale(time.Minute)). The response
+// cache honors them like the cache modes of the fetch API, and they are
+// sent to the server.
+func WithCacheControl(directives ...models.CacheDirective) RequestOption {
+	return func(o *requestOptions) {
+		values := make([]string, len(directives))
+		for i, directive := range directives {
+			values[i] = string(directive)
+		}
+		o.config.Headers["Cache-Control"] = strings.Join(values, ", ")
+	}
+}
+
+// WithStatusValidator overrides the status validator for a single request.
+func WithStatusValidator(validator func(int) bool) RequestOption {
+	return func(o *requestOptions) {
+		o.config.StatusValidator = validator
+	}
+}
+
+// WithPriority sets the scheduling priority of a single request. When the
+// concurrency limit is reached, higher-priority requests are admitted first.
+func WithPriority(priority models.Priority) RequestOption {
+	return func(o *requestOptions) {
+		o.config.Priority = &priority
+	}
+}
+
+// WithContentType overrides the media type of the request body for a
+// single request, as SetContentType does for the client.
+func WithContentType(contentType string) RequestOption {
+	return func(o *requestOptions) {
+		o.config.ContentType = contentType
+	}
+}
+
+// WithBodyTee copies the response body to writers while it is read, so it
+// can be hashed or written to disk in the same pass that decodes it. Only
+// bodies accepted by the status validator are copied. Hedging is disabled
+// for the request; writers see a partial body if reading fails.
+func WithBodyTee(writers ...io.Writer) RequestOption {
+	return func(o *requestOptions) {
+		o.config.BodyTee = append(o.config.BodyTee, writers...)
+	}
+}
+
+// WithRawResponse skips reading the response body and hands it to the
+// caller as Response.Body, e.g. to proxy it or feed a streaming parser.
+// The caller must close it, which also releases the request's bulkhead
+// slot. The client timeout still applies while the body is read. Bodies
+// rejected by the status validator are read into the error as usual.
+// Caching, deduplication and hedging are skipped for the request.
+func WithRawResponse() RequestOption {
+	return func(o *requestOptions) {
+		o.config.RawResponse = true
+	}
+}
+
+// WithClientTrace attaches trace callbacks to a single request, e.g. to
+// log connection reuse or DNS results. They run in addition to the
+// client's timing collection and to any trace already in the context.
+func WithClientTrace(trace *httptrace.ClientTrace) RequestOption {
+	return func(o *requestOptions) {
+		o.config.ClientTrace = trace
+	}
+}
+
+// WithError decodes the body of a response rejected by the status
+// validator into target, a pointer, and attaches it to the returned
+// *errors.HTTPError as Decoded. It takes precedence over SetError.
+func WithError(target interface{}) RequestOption {
+	return func(o *requestOptions) {
+		o.config.ErrorTarget = target
+	}
+}
+
+// WithSampling overrides the client sampling rate for a single request,
+// e.g. WithSampling(1) to always capture a request under investigation.
+func WithSampling(rate float64) RequestOption {
+	return func(o *requestOptions) {
+		o.config.SampleRate = &rate
+	}
+}
+
+// applyOptions builds the per-request config from opts, or nil without options.
+func applyOptions(opts []RequestOption) *models.Config {
+	_, config := resolveOptions(nil, opts)
+	return config
+}
+
+// resolveOptions applies opts, returning params merged with the parameters
+// set by WithQuery and the per-request config, or nil without options. The
+// caller's params map is not modified.
+func resolveOptions(params map[string]interface{}, opts []RequestOption) (map[string]interface{}, *models.Config) {
+	if len(opts) == 0 {
+		return params, nil
+	}
+
+	options := &requestOptions{config: &models.Config{Headers: make(map[string]string)}}
+	for _, opt := range opts {
+		opt(options)
+	}
+	if len(options.params) == 0 {
+		return params, options.config
+	}
+
+	merged := make(map[string]interface{}, len(params)+len(options.params))
+	for key, value := range params {
+		merged[key] = value
+	}
+	for key, value := range options.params {
+		merged[key] = value
+	}
+	return merged, options.config
+}
+
+// executeWithOptions executes a request with per-request options.
+func (c *Client) executeWithOptions(ctx context.Context, method, path string, params map[string]interface{}, body interface{}, target interface{}, opts []RequestOption) (*models.Response, error) {
+	params, requestConfig := resolveOptions(params, opts)
+	return c.execute(ctx, method, path, params, body, target, requestConfig)
+}
+
+// GetWithOptions performs a GET request with per-request options. It is
+// equivalent to Get, which now accepts options too.
+func (c *Client) GetWithOptions(ctx context.Context, path string, params map[string]interface{}, target interface{}, opts ...RequestOption) (*models.Response, error) {
+	return c.Get(ctx, path, params, target, opts...)
+}
+
+// PostWithOptions performs a POST request with per-request options. It is
+// equivalent to Post, which now accepts options too.
+func (c *Client) PostWithOptions(ctx context.Context, path string, params map[string]interface{}, body interface{}, target interface{}, opts ...RequestOption) (*models.Response, error) {
+	return c.Post(ctx, path, params, body, target, opts...)
+}
+
+// PutWithOptions performs a PUT request with per-request options. It is
+// equivalent to Put, which now accepts options too.
+func (c *Client) PutWithOptions(ctx context.Context, path string, params map[string]interface{}, body interface{}, target interface{}, opts ...RequestOption) (*models.Response, error) {
+	return c.Put(ctx, path, params, body, target, opts...)
+}
+
+// PatchWithOptions performs a PATCH request with per-request options. It is
+// equivalent to Patch, which now accepts options too.
+func (c *Client) PatchWithOptions(ctx context.Context, path string, params map[string]interface{}, body interface{}, target interface{}, opts ...RequestOption) (*models.Response, error) {
+	return c.Patch(ctx, path, params, body, target, opts...)
+}
+
+// DeleteWithOptions performs a DELETE request with per-request options. It
+// is equivalent to Delete, which now accepts options too.
+func (c *Client) DeleteWithOptions(ctx context.Context, path string, params map[string]interface{}, target interface{}, opts ...RequestOption) (*models.Response, error) {
+	return c.Delete(ctx, path, params, target, opts...)
+}
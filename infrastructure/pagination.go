@@ -0,0 +1,71 @@
+package infrastructure
+
+import (
+	"context"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"github.com/fourth-ally/gofetch/domain/models"
+)
+
+// Paginate fetches path and then every page linked with rel="next" in the
+// Link header (RFC 8288), decoding each page into target and calling
+// onPage, until there is no next page or onPage returns an error. params
+// only apply to the first page; next links carry their own query.
+func (c *Client) Paginate(ctx context.Context, path string, params map[string]interface{}, target interface{}, onPage func(*models.Response) error) error {
+	var requestConfig *models.Config
+
+	for {
+		resp, err := c.execute(ctx, http.MethodGet, path, params, nil, target, requestConfig)
+		if err != nil {
+			return err
+		}
+
+		if err := onPage(resp); err != nil {
+			return err
+		}
+
+		next := ParseLinkHeader(resp.Headers.Get("Link"))["next"]
+		if next == "" {
+			return nil
+		}
+
+		nextURL, err := url.Parse(next)
+		if err != nil {
+			return err
+		}
+		if nextURL.IsAbs() {
+			requestConfig = &models.Config{BaseURL: nextURL.Scheme + "://" + nextURL.Host}
+		}
+		path = nextURL.RequestURI()
+		params = nil
+	}
+}
+
+// ParseLinkHeader parses a Link header into a map from relation type
+// (e.g. "next", "last") to target URL.
+func ParseLinkHeader(header string) map[string]string {
+	links := make(map[string]string)
+
+	for _, link := range strings.Split(header, ",") {
+		segments := strings.Split(link, ";")
+		target := strings.TrimSpace(segments[0])
+		if !strings.HasPrefix(target, "<") || !strings.HasSuffix(target, ">") {
+			continue
+		}
+		target = target[1 : len(target)-1]
+
+		for _, param := range segments[1:] {
+			key, value, ok := strings.Cut(strings.TrimSpace(param), "=")
+			if !ok || strings.ToLower(strings.TrimSpace(key)) != "rel" {
+				continue
+			}
+			for _, rel := range strings.Fields(strings.Trim(value, `"`)) {
+				links[strings.ToLower(rel)] = target
+			}
+		}
+	}
+
+	return links
+}
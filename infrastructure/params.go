@@ -0,0 +1,52 @@
+package infrastructure
+
+import (
+	"encoding"
+	"fmt"
+	"reflect"
+	"time"
+)
+
+// SetTimeLayout sets the layout time.Time params are formatted with in
+// paths, URI templates and query strings. The default is time.RFC3339;
+// pass "" to restore it.
+func (c *Client) SetTimeLayout(layout string) *Client {
+	c.timeLayout = layout
+	return c
+}
+
+// formatParam formats a param value for a URL: time.Time values with
+// layout (time.RFC3339 if empty), then encoding.TextMarshaler and
+// fmt.Stringer implementations, and anything else with %v.
+func formatParam(value interface{}, layout string) string {
+	if formatted, ok := formatTypedParam(value, layout); ok {
+		return formatted
+	}
+	return fmt.Sprintf("%v", value)
+}
+
+// formatTypedParam formats values with a time, text or string
+// representation, reporting false for other values. Callers use it
+// before expanding slices, so types such as net.IP stay a single value.
+func formatTypedParam(value interface{}, layout string) (string, bool) {
+	if v := reflect.ValueOf(value); v.Kind() == reflect.Pointer && v.IsNil() {
+		return "", false
+	}
+	if layout == "" {
+		layout = time.RFC3339
+	}
+
+	switch v := value.(type) {
+	case time.Time:
+		return v.Format(layout), true
+	case *time.Time:
+		return v.Format(layout), true
+	case encoding.TextMarshaler:
+		if text, err := v.MarshalText(); err == nil {
+			return string(text), true
+		}
+	case fmt.Stringer:
+		return v.String(), true
+	}
+	return "", false
+}
@@ -0,0 +1,46 @@
+package infrastructure
+
+import (
+	"context"
+	"io"
+
+	"github.com/fourth-ally/gofetch/domain/models"
+)
+
+// PipedPost starts a POST whose body is written through the returned writer
+// as data becomes available, e.g. CSV rows generated on the fly. The body is
+// sent with chunked transfer encoding, and each write blocks until the
+// transport has taken the data, so a slow server slows the producer down.
+//
+// Close the writer to finish the body; cancel ctx to abort the request.
+// The outcome is delivered on the channel, which receives one value and is
+// then closed. Writes fail once the request has completed. The body can't
+// be resent, so failed attempts are not retried. Options apply as for Post;
+// use WithContentType to describe the body.
+func (c *Client) PipedPost(ctx context.Context, path string, opts ...RequestOption) (io.WriteCloser, <-chan models.PipeResult) {
+	reader, writer := io.Pipe()
+	results := make(chan models.PipeResult, 1)
+
+	// The transport doesn't close streamed bodies, so a cancelled request
+	// would otherwise wait for the producer's next write
+	stop := context.AfterFunc(ctx, func() {
+		reader.CloseWithError(context.Cause(ctx))
+	})
+
+	go func() {
+		defer close(results)
+
+		resp, err := c.Post(ctx, path, nil, reader, nil, opts...)
+		stop()
+
+		// Unblock a producer still writing after the request ended
+		if err != nil {
+			reader.CloseWithError(err)
+		} else {
+			reader.Close()
+		}
+		results <- models.PipeResult{Response: resp, Err: err}
+	}()
+
+	return writer, results
+}
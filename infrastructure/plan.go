@@ -0,0 +1,77 @@
+package infrastructure
+
+import (
+	"context"
+	"fmt"
+	"io"
+
+	"github.com/fourth-ally/gofetch/domain/models"
+)
+
+// Plan builds the request that a call would send, without sending it: the
+// final URL, headers after interceptors and signing, the encoded body and
+// the effective configuration. Use it in tests and when debugging to check
+// exactly what would go on the wire.
+//
+// The host is chosen as the first healthy endpoint or base URL, without
+// affecting load balancing. Request interceptors run as they would for a
+// real request. Canary routing, rate limits and concurrency limits are not
+// applied.
+func (c *Client) Plan(ctx context.Context, method, path string, params map[string]interface{}, body interface{}, opts ...RequestOption) (*models.RequestPlan, error) {
+	params, requestConfig := resolveOptions(params, opts)
+	requestConfig = c.withRequestID(withContextOverrides(ctx, requestConfig))
+	requestConfig = c.withIdempotencyKey(method, requestConfig)
+
+	if baseURL := c.planBaseURL(requestConfig); baseURL != "" {
+		requestConfig = overrideConfig(requestConfig, &models.Config{BaseURL: baseURL})
+	}
+
+	req, err := c.buildRequest(ctx, method, path, params, body, c.mergedConfig(requestConfig))
+	if err != nil {
+		return nil, err
+	}
+
+	plan := &models.RequestPlan{
+		Method:  req.Method,
+		URL:     req.URL.String(),
+		Headers: req.Header,
+		Config:  c.EffectiveConfig(opts...),
+	}
+	plan.Config.BaseURL = c.mergedConfig(requestConfig).BaseURL
+
+	if req.GetBody != nil {
+		reader, err := req.GetBody()
+		if err != nil {
+			return nil, fmt.Errorf("failed to read request body: %w", err)
+		}
+		defer reader.Close()
+
+		if plan.Body, err = io.ReadAll(reader); err != nil {
+			return nil, fmt.Errorf("failed to read request body: %w", err)
+		}
+	}
+
+	return plan, nil
+}
+
+// planBaseURL returns the host a request would be sent to first, or ""
+// when the configured base URL applies.
+func (c *Client) planBaseURL(requestConfig *models.Config) string {
+	if requestConfig != nil && requestConfig.BaseURL != "" {
+		return ""
+	}
+
+	if c.pool != nil {
+		for _, status := range c.pool.statuses() {
+			if status.Healthy && !c.health.isDown(status.URL) {
+				return status.URL
+			}
+		}
+		return c.pool.statuses()[0].URL
+	}
+
+	if baseURLs := c.health.available(c.mergedConfig(requestConfig).BaseURLs); len(baseURLs) > 0 {
+		return baseURLs[0]
+	}
+	return ""
+}
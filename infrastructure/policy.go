@@ -0,0 +1,54 @@
+package infrastructure
+
+import (
+	"github.com/fourth-ally/gofetch/domain/errors"
+	"github.com/fourth-ally/gofetch/domain/models"
+)
+
+// ApplyPolicy applies every non-zero setting of policy to the client.
+// Settings not covered by the policy are left unchanged, so policies can
+// be layered and combined with individual setters.
+func (c *Client) ApplyPolicy(policy *models.Policy) *Client {
+	if policy == nil {
+		return c
+	}
+
+	if policy.Timeout > 0 {
+		c.SetTimeout(policy.Timeout)
+	}
+
+	if policy.DialTimeout > 0 {
+		c.SetDialTimeout(policy.DialTimeout)
+	}
+
+	if policy.TLSHandshakeTimeout > 0 {
+		c.SetTLSHandshakeTimeout(policy.TLSHandshakeTimeout)
+	}
+
+	if policy.RetryOptions != nil {
+		retryOptions := *policy.RetryOptions
+		retryOptions.RetryOnStatusCodes = append([]int(nil), policy.RetryOptions.RetryOnStatusCodes...)
+		retryOptions.RetryOnTransportErrors = append([]errors.TransportErrorKind(nil), policy.RetryOptions.RetryOnTransportErrors...)
+		c.SetRetryOptions(&retryOptions)
+	}
+
+	if policy.TLSPolicy != nil {
+		c.SetTLSPolicy(policy.TLSPolicy)
+	}
+
+	if policy.MinTLSVersion != 0 {
+		if transport := c.transport(); transport != nil {
+			tlsClientConfig(transport).MinVersion = policy.MinTLSVersion
+		}
+	}
+
+	if policy.Logger != nil {
+		c.SetLogger(policy.Logger)
+	}
+
+	for key, value := range policy.Headers {
+		c.SetHeader(key, value)
+	}
+
+	return c
+}
@@ -25,3 +25,9 @@ func (pr *progressReader) Read(p []byte) (int, error) {
 
 	return n, err
 }
+
+// Close closes the wrapped reader if it is an io.Closer, so request bodies
+// such as lazily opened files are released when the transport is done.
+func (pr *progressReader) Close() error {
+	return closeReader(pr.reader)
+}
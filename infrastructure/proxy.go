@@ -0,0 +1,158 @@
+package infrastructure
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+	"sync"
+	"time"
+
+	"github.com/fourth-ally/gofetch/domain/models"
+)
+
+// proxyFailover routes requests through the first healthy proxy of a list.
+type proxyFailover struct {
+	options *models.ProxyOptions
+
+	mu      sync.Mutex
+	proxies []*proxyState
+}
+
+// proxyState tracks the health of a single proxy.
+type proxyState struct {
+	name                string
+	url                 *url.URL
+	consecutiveFailures int
+	downUntil           time.Time
+}
+
+// proxyChoiceKey is the context key recording the proxy used by a request.
+type proxyChoiceKey struct{}
+
+// SetProxy routes all requests through the given proxy URL.
+func (c *Client) SetProxy(proxyURL string) *Client {
+	return c.SetProxies([]string{proxyURL}, nil)
+}
+
+// SetProxies routes requests through the first healthy proxy in priority
+// order. A proxy whose connections keep failing is bypassed for a cooldown
+// period; use models.ProxyDirect as a list entry to fall back to a direct
+// connection. Invalid proxy URLs are ignored. Pass an empty list to restore
+// the environment proxy settings.
+func (c *Client) SetProxies(proxies []string, options *models.ProxyOptions) *Client {
+	transport := c.transport()
+	if transport == nil {
+		return c
+	}
+
+	if len(proxies) == 0 {
+		c.proxies = nil
+		transport.Proxy = http.ProxyFromEnvironment
+		return c
+	}
+
+	if options == nil {
+		options = models.NewProxyOptions()
+	}
+
+	failover := &proxyFailover{options: options}
+	for _, proxy := range proxies {
+		state := &proxyState{name: proxy}
+		if proxy != models.ProxyDirect {
+			parsed, err := url.Parse(proxy)
+			if err != nil {
+				continue
+			}
+			state.url = parsed
+		}
+		failover.proxies = append(failover.proxies, state)
+	}
+
+	c.proxies = failover
+	transport.Proxy = failover.proxy
+	return c
+}
+
+// ProxyStatus reports whether each configured proxy is currently in use
+// or bypassed after failures. It returns nil without SetProxies.
+func (c *Client) ProxyStatus() map[string]bool {
+	if c.proxies == nil {
+		return nil
+	}
+
+	c.proxies.mu.Lock()
+	defer c.proxies.mu.Unlock()
+
+	now := time.Now()
+	status := make(map[string]bool, len(c.proxies.proxies))
+	for _, state := range c.proxies.proxies {
+		status[state.name] = now.After(state.downUntil)
+	}
+	return status
+}
+
+// do sends req and records the outcome against the proxy it used.
+func (p *proxyFailover) do(req *http.Request, send func(*http.Request) (*http.Response, error)) (*http.Response, error) {
+	if p == nil {
+		return send(req)
+	}
+
+	var chosen *proxyState
+	req = req.WithContext(context.WithValue(req.Context(), proxyChoiceKey{}, &chosen))
+
+	resp, err := send(req)
+	if chosen != nil && req.Context().Err() == nil {
+		p.record(chosen, err == nil)
+	}
+	return resp, err
+}
+
+// proxy implements http.Transport.Proxy.
+func (p *proxyFailover) proxy(req *http.Request) (*url.URL, error) {
+	state := p.pick()
+	if state == nil {
+		return nil, fmt.Errorf("no usable proxy configured")
+	}
+
+	if chosen, ok := req.Context().Value(proxyChoiceKey{}).(**proxyState); ok {
+		*chosen = state
+	}
+	return state.url, nil
+}
+
+// pick returns the first proxy not in cooldown, or the first proxy if all
+// of them are down.
+func (p *proxyFailover) pick() *proxyState {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if len(p.proxies) == 0 {
+		return nil
+	}
+
+	now := time.Now()
+	for _, state := range p.proxies {
+		if now.After(state.downUntil) {
+			return state
+		}
+	}
+	return p.proxies[0]
+}
+
+// record updates proxy health after a request.
+func (p *proxyFailover) record(state *proxyState, success bool) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if success {
+		state.consecutiveFailures = 0
+		return
+	}
+
+	state.consecutiveFailures++
+	if state.consecutiveFailures >= p.options.FailureThreshold {
+		state.downUntil = time.Now().Add(p.options.Cooldown)
+		state.consecutiveFailures = 0
+	}
+}
@@ -0,0 +1,109 @@
+package infrastructure
+
+import (
+	"net/url"
+	"reflect"
+	"strings"
+
+	"github.com/fourth-ally/gofetch/domain/contracts"
+	"github.com/fourth-ally/gofetch/domain/models"
+)
+
+// SetQueryArrayFormat selects how slice and array query parameters are
+// encoded. The default, models.ArrayRepeat, repeats the key per element.
+func (c *Client) SetQueryArrayFormat(format models.ArrayFormat) *Client {
+	c.queryArrayFormat = format
+	return c
+}
+
+// SetQueryEncoder takes over encoding the params that are not path
+// parameters into the query string, e.g. for nested PHP-style brackets or
+// JSON-encoded filters. A custom encoder can delegate params it doesn't
+// handle to DefaultQueryEncoder. Pass nil to use the default encoder again.
+func (c *Client) SetQueryEncoder(encoder contracts.QueryEncoder) *Client {
+	c.queryEncoder = encoder
+	return c
+}
+
+// DefaultQueryEncoder returns the encoder used without SetQueryEncoder:
+// keys are sorted, time.Time values formatted as RFC 3339, other values
+// with their MarshalText or String method or %v, and slices and arrays
+// expanded in format.
+func DefaultQueryEncoder(format models.ArrayFormat) contracts.QueryEncoder {
+	return defaultQueryEncoder{format: format}
+}
+
+// defaultQueryEncoder implements contracts.QueryEncoder with url.Values.
+type defaultQueryEncoder struct {
+	format     models.ArrayFormat
+	timeLayout string
+}
+
+// EncodeQuery implements contracts.QueryEncoder.
+func (e defaultQueryEncoder) EncodeQuery(params map[string]interface{}) (string, error) {
+	query := url.Values{}
+	for key, value := range params {
+		addQueryParam(query, key, value, e.format, e.timeLayout)
+	}
+	return query.Encode(), nil
+}
+
+// encodeQuery encodes params with the client's query encoder.
+func (c *Client) encodeQuery(params map[string]interface{}) (string, error) {
+	if len(params) == 0 {
+		return "", nil
+	}
+	if c.queryEncoder != nil {
+		return c.queryEncoder.EncodeQuery(params)
+	}
+	return defaultQueryEncoder{format: c.queryArrayFormat, timeLayout: c.timeLayout}.EncodeQuery(params)
+}
+
+// addQueryParam adds value to query, expanding slices and arrays in
+// format and formatting times with timeLayout. Empty slices add nothing.
+func addQueryParam(query url.Values, key string, value interface{}, format models.ArrayFormat, timeLayout string) {
+	elements, ok := queryElements(value, timeLayout)
+	if !ok {
+		query.Add(key, formatParam(value, timeLayout))
+		return
+	}
+	if len(elements) == 0 {
+		return
+	}
+
+	switch format {
+	case models.ArrayComma:
+		query.Add(key, strings.Join(elements, ","))
+	case models.ArrayBrackets:
+		for _, element := range elements {
+			query.Add(key+"[]", element)
+		}
+	default:
+		for _, element := range elements {
+			query.Add(key, element)
+		}
+	}
+}
+
+// queryElements formats the elements of a slice or array value, reporting
+// false for other values. Byte slices and slices with their own text
+// representation, such as net.IP, are not expanded.
+func queryElements(value interface{}, timeLayout string) ([]string, bool) {
+	if _, ok := value.([]byte); ok {
+		return nil, false
+	}
+	if _, ok := formatTypedParam(value, timeLayout); ok {
+		return nil, false
+	}
+
+	v := reflect.ValueOf(value)
+	if v.Kind() != reflect.Slice && v.Kind() != reflect.Array {
+		return nil, false
+	}
+
+	elements := make([]string, v.Len())
+	for i := range elements {
+		elements[i] = formatParam(v.Index(i).Interface(), timeLayout)
+	}
+	return elements, true
+}
@@ -0,0 +1,139 @@
+package infrastructure
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"sync"
+
+	"github.com/fourth-ally/gofetch/domain/models"
+)
+
+// MemoryQueueStore is an in-memory implementation of contracts.QueueStore.
+// Queued requests are lost when the process exits.
+type MemoryQueueStore struct {
+	mu       sync.Mutex
+	requests []*models.QueuedRequest
+}
+
+// NewMemoryQueueStore creates an empty in-memory queue store.
+func NewMemoryQueueStore() *MemoryQueueStore {
+	return &MemoryQueueStore{}
+}
+
+// Append adds a request to the end of the queue.
+func (s *MemoryQueueStore) Append(req *models.QueuedRequest) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.requests = append(s.requests, req)
+	return nil
+}
+
+// List returns all queued requests, oldest first.
+func (s *MemoryQueueStore) List() ([]*models.QueuedRequest, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return append([]*models.QueuedRequest(nil), s.requests...), nil
+}
+
+// Remove deletes the request with the given ID.
+func (s *MemoryQueueStore) Remove(id string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.requests = removeQueued(s.requests, id)
+	return nil
+}
+
+// FileQueueStore is a contracts.QueueStore persisted as a JSON file, so
+// queued requests survive restarts. Every change rewrites the file atomically.
+type FileQueueStore struct {
+	mu       sync.Mutex
+	path     string
+	requests []*models.QueuedRequest
+}
+
+// NewFileQueueStore opens the queue stored at path, creating it on first write.
+func NewFileQueueStore(path string) (*FileQueueStore, error) {
+	store := &FileQueueStore{path: path}
+
+	data, err := os.ReadFile(path)
+	if os.IsNotExist(err) {
+		return store, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	if len(data) > 0 {
+		if err := json.Unmarshal(data, &store.requests); err != nil {
+			return nil, err
+		}
+	}
+	return store, nil
+}
+
+// Append adds a request to the end of the queue.
+func (s *FileQueueStore) Append(req *models.QueuedRequest) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return s.save(append(s.requests, req))
+}
+
+// List returns all queued requests, oldest first.
+func (s *FileQueueStore) List() ([]*models.QueuedRequest, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return append([]*models.QueuedRequest(nil), s.requests...), nil
+}
+
+// Remove deletes the request with the given ID.
+func (s *FileQueueStore) Remove(id string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return s.save(removeQueued(append([]*models.QueuedRequest(nil), s.requests...), id))
+}
+
+// save writes requests to disk and keeps them in memory on success.
+func (s *FileQueueStore) save(requests []*models.QueuedRequest) error {
+	data, err := json.Marshal(requests)
+	if err != nil {
+		return err
+	}
+
+	tmp, err := os.CreateTemp(filepath.Dir(s.path), filepath.Base(s.path)+".*.tmp")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmp.Name())
+
+	if _, err := tmp.Write(data); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		return err
+	}
+
+	if err := os.Rename(tmp.Name(), s.path); err != nil {
+		return err
+	}
+
+	s.requests = requests
+	return nil
+}
+
+// removeQueued returns requests without the one with the given ID.
+func removeQueued(requests []*models.QueuedRequest, id string) []*models.QueuedRequest {
+	for i, req := range requests {
+		if req.ID == id {
+			return append(requests[:i], requests[i+1:]...)
+		}
+	}
+	return requests
+}
@@ -0,0 +1,169 @@
+package infrastructure
+
+import (
+	"context"
+	"path"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/fourth-ally/gofetch/domain/models"
+)
+
+// rateLimiter throttles requests with a default bucket and per-host rules.
+type rateLimiter struct {
+	fallback *tokenBucket
+	rules    []hostRateRule
+
+	mu      sync.Mutex
+	buckets map[string]*tokenBucket
+}
+
+// hostRateRule applies a rate limit to hosts matching pattern.
+type hostRateRule struct {
+	pattern string
+	limit   models.RateLimit
+}
+
+// tokenBucket is a mutex-protected token bucket.
+type tokenBucket struct {
+	mu       sync.Mutex
+	rate     float64
+	capacity float64
+	tokens   float64
+	last     time.Time
+}
+
+// SetRateLimit throttles all requests of the client to the given rate.
+// Requests wait for a token rather than failing. Hosts matching a rule from
+// SetHostRateLimits use that rule instead. A nil limit removes it.
+func (c *Client) SetRateLimit(limit *models.RateLimit) *Client {
+	limiter := c.ensureRateLimiter()
+	limiter.fallback = nil
+	if limit != nil {
+		limiter.fallback = newTokenBucket(*limit)
+	}
+	return c
+}
+
+// SetHostRateLimits sets independent rate limits per host, keyed by host
+// pattern, e.g. "api.github.com" or "*.example.com". Patterns are matched
+// with path.Match against the request host (including any port); the most
+// specific (longest) matching pattern wins, and each matching host gets its
+// own bucket.
+func (c *Client) SetHostRateLimits(limits map[string]models.RateLimit) *Client {
+	limiter := c.ensureRateLimiter()
+
+	rules := make([]hostRateRule, 0, len(limits))
+	for pattern, limit := range limits {
+		rules = append(rules, hostRateRule{pattern: pattern, limit: limit})
+	}
+	sort.Slice(rules, func(i, j int) bool {
+		return len(rules[i].pattern) > len(rules[j].pattern)
+	})
+
+	limiter.mu.Lock()
+	limiter.rules = rules
+	limiter.buckets = make(map[string]*tokenBucket)
+	limiter.mu.Unlock()
+
+	return c
+}
+
+// ensureRateLimiter returns the client's rate limiter, creating it if needed.
+func (c *Client) ensureRateLimiter() *rateLimiter {
+	if c.rateLimiter == nil {
+		c.rateLimiter = &rateLimiter{buckets: make(map[string]*tokenBucket)}
+	}
+	return c.rateLimiter
+}
+
+// wait blocks until the request to host may proceed or ctx is done.
+func (l *rateLimiter) wait(ctx context.Context, host string) error {
+	if l == nil {
+		return nil
+	}
+
+	bucket := l.bucketFor(host)
+	if bucket == nil {
+		return nil
+	}
+	return bucket.wait(ctx)
+}
+
+// bucketFor returns the bucket governing host, or nil if it is unlimited.
+func (l *rateLimiter) bucketFor(host string) *tokenBucket {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	if bucket, ok := l.buckets[host]; ok {
+		return bucket
+	}
+
+	for _, rule := range l.rules {
+		if matched, _ := path.Match(rule.pattern, host); matched {
+			bucket := newTokenBucket(rule.limit)
+			l.buckets[host] = bucket
+			return bucket
+		}
+	}
+
+	return l.fallback
+}
+
+// newTokenBucket creates a full bucket for limit.
+func newTokenBucket(limit models.RateLimit) *tokenBucket {
+	capacity := float64(limit.Burst)
+	if capacity < 1 {
+		capacity = 1
+	}
+
+	return &tokenBucket{
+		rate:     limit.RequestsPerSecond,
+		capacity: capacity,
+		tokens:   capacity,
+		last:     time.Now(),
+	}
+}
+
+// wait takes a token, sleeping until one is available.
+func (b *tokenBucket) wait(ctx context.Context) error {
+	for {
+		delay := b.reserve()
+		if delay == 0 {
+			return nil
+		}
+
+		timer := time.NewTimer(delay)
+		select {
+		case <-timer.C:
+		case <-ctx.Done():
+			timer.Stop()
+			return ctx.Err()
+		}
+	}
+}
+
+// reserve takes a token if one is available and otherwise returns how
+// long until the next token is due.
+func (b *tokenBucket) reserve() time.Duration {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	now := time.Now()
+	b.tokens += now.Sub(b.last).Seconds() * b.rate
+	if b.tokens > b.capacity {
+		b.tokens = b.capacity
+	}
+	b.last = now
+
+	if b.tokens >= 1 {
+		b.tokens--
+		return 0
+	}
+
+	if b.rate <= 0 {
+		return time.Hour
+	}
+	return time.Duration((1 - b.tokens) / b.rate * float64(time.Second))
+}
@@ -0,0 +1,53 @@
+package infrastructure
+
+import (
+	"io"
+	"sync"
+
+	"github.com/fourth-ally/gofetch/domain/models"
+)
+
+// cleanupStack collects functions that release the resources of a request,
+// run in reverse order like deferred calls.
+type cleanupStack []func()
+
+// push adds a cleanup function.
+func (s *cleanupStack) push(cleanup func()) {
+	*s = append(*s, cleanup)
+}
+
+// pushCloser adds closing closer as a cleanup.
+func (s *cleanupStack) pushCloser(closer io.Closer) {
+	s.push(func() { closer.Close() })
+}
+
+// run calls the cleanups, most recently added first.
+func (s cleanupStack) run() {
+	for i := len(s) - 1; i >= 0; i-- {
+		s[i]()
+	}
+}
+
+// rawBody is a response body handed to the caller unread. Closing it
+// releases everything the request held, such as its bulkhead slot.
+type rawBody struct {
+	reader   io.Reader
+	cleanups cleanupStack
+	once     sync.Once
+}
+
+// Read implements io.Reader.
+func (b *rawBody) Read(p []byte) (int, error) {
+	return b.reader.Read(p)
+}
+
+// Close implements io.Closer.
+func (b *rawBody) Close() error {
+	b.once.Do(b.cleanups.run)
+	return nil
+}
+
+// isRawResponse reports whether the request hands back an unread body.
+func isRawResponse(requestConfig *models.Config) bool {
+	return requestConfig != nil && requestConfig.RawResponse
+}
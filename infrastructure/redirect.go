@@ -0,0 +1,54 @@
+package infrastructure
+
+import (
+	"context"
+	stderrors "errors"
+	"net/http"
+	"sync"
+
+	"github.com/fourth-ally/gofetch/domain/models"
+)
+
+// maxRedirects matches the net/http default redirect limit.
+const maxRedirects = 10
+
+// redirectRecorderKey is the context key for the redirect chain of a request.
+type redirectRecorderKey struct{}
+
+// redirectRecorder collects the hops followed by a single request.
+type redirectRecorder struct {
+	mu   sync.Mutex
+	hops []models.Redirect
+}
+
+// withRedirectRecorder attaches a fresh redirect recorder to ctx.
+func withRedirectRecorder(ctx context.Context) (context.Context, *redirectRecorder) {
+	recorder := &redirectRecorder{}
+	return context.WithValue(ctx, redirectRecorderKey{}, recorder), recorder
+}
+
+// checkRedirect records each hop and applies the default redirect limit.
+// It is installed as the http.Client CheckRedirect policy.
+func checkRedirect(req *http.Request, via []*http.Request) error {
+	if recorder, ok := req.Context().Value(redirectRecorderKey{}).(*redirectRecorder); ok && req.Response != nil {
+		recorder.mu.Lock()
+		recorder.hops = append(recorder.hops, models.Redirect{
+			URL:        via[len(via)-1].URL.String(),
+			StatusCode: req.Response.StatusCode,
+			Location:   req.Response.Header.Get("Location"),
+		})
+		recorder.mu.Unlock()
+	}
+
+	if len(via) >= maxRedirects {
+		return stderrors.New("stopped after 10 redirects")
+	}
+	return nil
+}
+
+// redirects returns the recorded hops, or nil if none were followed.
+func (r *redirectRecorder) redirects() []models.Redirect {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.hops
+}
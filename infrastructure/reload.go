@@ -0,0 +1,214 @@
+package infrastructure
+
+import (
+	"context"
+	"net/http"
+	"os"
+	"time"
+
+	"github.com/fourth-ally/gofetch/domain/errors"
+	"github.com/fourth-ally/gofetch/domain/models"
+)
+
+// clientState holds the settings Reload replaces together. It is
+// published as one unit so a request never pairs a new configuration with
+// an old HTTP client or retry settings.
+type clientState struct {
+	config       *models.Config
+	httpClient   *http.Client
+	retryManager *RetryManager
+}
+
+// merged returns the configuration of s with per-request overrides applied.
+func (s *clientState) merged(requestConfig *models.Config) *models.Config {
+	if requestConfig == nil {
+		return s.config
+	}
+	return s.config.Merge(requestConfig)
+}
+
+// stateKey is the context key of the state a request was started with.
+type stateKey struct{ client *Client }
+
+// withState pins the current state of c to ctx, unless a state is pinned
+// already, so every stage of a request uses the same settings.
+func (c *Client) withState(ctx context.Context) context.Context {
+	if _, ok := ctx.Value(stateKey{c}).(*clientState); ok {
+		return ctx
+	}
+	return context.WithValue(ctx, stateKey{c}, c.state.Load())
+}
+
+// stateFor returns the state pinned to ctx, or the current state.
+func (c *Client) stateFor(ctx context.Context) *clientState {
+	if state, ok := ctx.Value(stateKey{c}).(*clientState); ok {
+		return state
+	}
+	return c.state.Load()
+}
+
+// Reload atomically swaps base URLs, headers, timeouts, TLS material and
+// policy settings into a running client. The new settings are built on
+// copies and published at once, so concurrent requests see either the old
+// or the new configuration, never a mix of both. In-flight requests finish
+// with the settings they started with; connections opened with old TLS
+// material are closed once idle.
+//
+// Reloading TLS settings requires the default transport or an
+// *http.Transport; with a custom RoundTripper a ConfigError is returned
+// and nothing is changed.
+func (c *Client) Reload(options *models.ReloadOptions) error {
+	if options == nil {
+		return nil
+	}
+
+	c.reloadMu.Lock()
+	defer c.reloadMu.Unlock()
+
+	current := c.state.Load()
+	config := current.config.Clone()
+	httpClient := *current.httpClient
+	policy := options.Policy
+	if policy == nil {
+		policy = &models.Policy{}
+	}
+
+	if options.BaseURL != "" {
+		config.BaseURL = options.BaseURL
+		config.BaseURLs = nil
+	}
+	if len(options.BaseURLs) > 0 {
+		config.BaseURLs = append([]string(nil), options.BaseURLs...)
+	}
+
+	if options.Headers != nil {
+		config.Headers = make(map[string]string, len(options.Headers))
+		for key, value := range options.Headers {
+			config.Headers[key] = value
+		}
+	}
+	for key, value := range policy.Headers {
+		config.Headers[key] = value
+	}
+
+	for _, timeout := range []time.Duration{options.Timeout, policy.Timeout} {
+		if timeout > 0 {
+			config.Timeout = timeout
+			httpClient.Timeout = timeout
+		}
+	}
+
+	tlsChanged := len(options.Certificates) > 0 || options.RootCAs != nil ||
+		policy.TLSPolicy != nil || policy.MinTLSVersion != 0 || policy.TLSHandshakeTimeout > 0
+	if tlsChanged {
+		transport, err := c.reloadTransport(options, policy)
+		if err != nil {
+			return err
+		}
+		httpClient.Transport = transport
+	}
+
+	retryManager := current.retryManager
+	if policy.RetryOptions != nil {
+		retryOptions := *policy.RetryOptions
+		retryOptions.RetryOnStatusCodes = append([]int(nil), policy.RetryOptions.RetryOnStatusCodes...)
+		retryOptions.RetryOnTransportErrors = append([]errors.TransportErrorKind(nil), policy.RetryOptions.RetryOnTransportErrors...)
+		config.RetryOptions = &retryOptions
+
+		retryManager = NewRetryManager(&retryOptions)
+		retryManager.backoff = c.retryBackoff
+	}
+
+	c.state.Store(&clientState{config: config, httpClient: &httpClient, retryManager: retryManager})
+
+	if tlsChanged {
+		current.httpClient.CloseIdleConnections()
+	}
+	return nil
+}
+
+// reloadTransport returns a copy of the current transport with the new TLS
+// settings applied.
+func (c *Client) reloadTransport(options *models.ReloadOptions, policy *models.Policy) (*http.Transport, error) {
+	current := c.inspectTransport()
+	if current == nil {
+		return nil, &errors.ConfigError{Field: "Transport", Message: "TLS settings can't be reloaded with a custom RoundTripper"}
+	}
+
+	transport := current.Clone()
+	tlsConfig := tlsClientConfig(transport)
+	if len(options.Certificates) > 0 {
+		tlsConfig.Certificates = options.Certificates
+	}
+	if options.RootCAs != nil {
+		tlsConfig.RootCAs = options.RootCAs
+	}
+	if policy.TLSPolicy != nil {
+		applyTLSPolicy(tlsConfig, policy.TLSPolicy)
+	}
+	if policy.MinTLSVersion != 0 {
+		tlsConfig.MinVersion = policy.MinTLSVersion
+	}
+	if policy.TLSHandshakeTimeout > 0 {
+		transport.TLSHandshakeTimeout = policy.TLSHandshakeTimeout
+	}
+
+	return transport, nil
+}
+
+// WatchConfigFile reloads the client whenever the file at path changes,
+// checking its modification time every interval until ctx is cancelled.
+// parse turns the file contents into reload options. Errors reading,
+// parsing or applying the file are passed to onError, if set, and the
+// previous configuration stays in effect. The file is not loaded initially.
+func (c *Client) WatchConfigFile(ctx context.Context, path string, interval time.Duration, parse func([]byte) (*models.ReloadOptions, error), onError func(error)) *Client {
+	report := func(err error) {
+		if onError != nil {
+			onError(err)
+		}
+	}
+
+	var lastModified time.Time
+	if info, err := os.Stat(path); err == nil {
+		lastModified = info.ModTime()
+	}
+
+	go func() {
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+			}
+
+			info, err := os.Stat(path)
+			if err != nil {
+				report(err)
+				continue
+			}
+			if !info.ModTime().After(lastModified) {
+				continue
+			}
+			lastModified = info.ModTime()
+
+			data, err := os.ReadFile(path)
+			if err != nil {
+				report(err)
+				continue
+			}
+
+			options, err := parse(data)
+			if err == nil {
+				err = c.Reload(options)
+			}
+			if err != nil {
+				report(err)
+			}
+		}
+	}()
+
+	return c
+}
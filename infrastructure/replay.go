@@ -0,0 +1,211 @@
+package infrastructure
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/fourth-ally/gofetch/domain/models"
+)
+
+// Replay re-sends recorded exchanges, e.g. from LoadJournal or LoadHAR,
+// and compares each response with the recording. With a base URL set,
+// requests go to the same path and query on the client's base URL, so a
+// journal recorded against one environment can validate another;
+// otherwise the recorded URLs are used. Redacted credential headers are
+// replaced by the client's own. Responses are never served from the
+// cache. Replay returns ctx.Err() with a partial report if ctx is done.
+func (c *Client) Replay(ctx context.Context, exchanges []models.RecordedExchange, options *models.ReplayOptions) (*models.ReplayReport, error) {
+	if options == nil {
+		options = models.NewReplayOptions()
+	}
+
+	report := &models.ReplayReport{Results: make([]models.ReplayResult, len(exchanges))}
+	indexes := make(chan int)
+	var mu sync.Mutex
+	var wg sync.WaitGroup
+
+	for i := 0; i < max(options.Concurrency, 1); i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for index := range indexes {
+				result := c.replayOne(ctx, &exchanges[index], options)
+
+				mu.Lock()
+				report.Results[index] = result
+				if result.Err != nil {
+					report.Failures++
+				} else if result.Mismatch() {
+					report.Mismatches++
+				}
+				mu.Unlock()
+
+				if options.OnResult != nil {
+					options.OnResult(&result)
+				}
+			}
+		}()
+	}
+
+	var err error
+send:
+	for i := range exchanges {
+		select {
+		case indexes <- i:
+		case <-ctx.Done():
+			err = ctx.Err()
+			break send
+		}
+	}
+	close(indexes)
+	wg.Wait()
+
+	return report, err
+}
+
+// replayOne sends one recorded exchange and compares the outcome.
+func (c *Client) replayOne(ctx context.Context, exchange *models.RecordedExchange, options *models.ReplayOptions) models.ReplayResult {
+	result := models.ReplayResult{Exchange: exchange}
+
+	target := exchange.URL
+	if c.state.Load().config.BaseURL != "" {
+		if parsed, err := url.Parse(exchange.URL); err == nil {
+			target = parsed.RequestURI()
+		}
+	}
+
+	headers := make(map[string]string, len(exchange.Headers))
+	for key, value := range exchange.Headers {
+		if !models.IsRedacted(value) && !unreplayableHeader(key) {
+			headers[key] = value
+		}
+	}
+
+	var body interface{}
+	if len(exchange.Body) > 0 {
+		body = exchange.Body
+	}
+
+	started := time.Now()
+	resp, err := c.executeUncached(ctx, exchange.Method, target, nil, body, nil, &models.Config{Headers: headers})
+	result.Duration = time.Since(started)
+
+	status, respBody, ok := responseOutcome(resp, err)
+	if !ok {
+		result.Err = err
+		return result
+	}
+
+	result.Status, result.Body = status, respBody
+	result.StatusMismatch = exchange.Status != 0 && exchange.Status != status
+	result.BodyMismatch = exchange.ResponseBody != nil && !bodiesEqual(exchange.ResponseBody, respBody, options.Normalize)
+	return result
+}
+
+// unreplayableHeader reports whether a recorded header describes the
+// original connection rather than the request.
+func unreplayableHeader(name string) bool {
+	switch strings.ToLower(name) {
+	case "host", "content-length", "connection", "accept-encoding":
+		return true
+	}
+	return strings.HasPrefix(name, ":")
+}
+
+// LoadJournal reads exchanges written by Client.SetJournal.
+func LoadJournal(r io.Reader) ([]models.RecordedExchange, error) {
+	var exchanges []models.RecordedExchange
+	decoder := json.NewDecoder(r)
+	for {
+		var exchange models.RecordedExchange
+		err := decoder.Decode(&exchange)
+		if err == io.EOF {
+			return exchanges, nil
+		}
+		if err != nil {
+			return nil, fmt.Errorf("failed to read journal entry %d: %w", len(exchanges)+1, err)
+		}
+		exchanges = append(exchanges, exchange)
+	}
+}
+
+// harFile is the subset of the HAR 1.2 format LoadHAR reads.
+type harFile struct {
+	Log struct {
+		Entries []struct {
+			StartedDateTime time.Time `json:"startedDateTime"`
+			Request         struct {
+				Method   string      `json:"method"`
+				URL      string      `json:"url"`
+				Headers  []harHeader `json:"headers"`
+				PostData *struct {
+					Text string `json:"text"`
+				} `json:"postData"`
+			} `json:"request"`
+			Response struct {
+				Status  int `json:"status"`
+				Content struct {
+					Text     *string `json:"text"`
+					Encoding string  `json:"encoding"`
+				} `json:"content"`
+			} `json:"response"`
+		} `json:"entries"`
+	} `json:"log"`
+}
+
+// harHeader is a HAR name/value pair.
+type harHeader struct {
+	Name  string `json:"name"`
+	Value string `json:"value"`
+}
+
+// LoadHAR reads the exchanges of an HTTP Archive, as exported by browser
+// developer tools or proxies. Credential headers are redacted.
+func LoadHAR(r io.Reader) ([]models.RecordedExchange, error) {
+	var har harFile
+	if err := json.NewDecoder(r).Decode(&har); err != nil {
+		return nil, fmt.Errorf("failed to read HAR: %w", err)
+	}
+
+	exchanges := make([]models.RecordedExchange, 0, len(har.Log.Entries))
+	for i, entry := range har.Log.Entries {
+		headers := make(map[string]string, len(entry.Request.Headers))
+		for _, header := range entry.Request.Headers {
+			headers[http.CanonicalHeaderKey(header.Name)] = header.Value
+		}
+
+		exchange := models.RecordedExchange{
+			Method:     entry.Request.Method,
+			URL:        entry.Request.URL,
+			Headers:    models.RedactHeaders(headers),
+			Status:     entry.Response.Status,
+			RecordedAt: entry.StartedDateTime,
+		}
+		if entry.Request.PostData != nil && entry.Request.PostData.Text != "" {
+			exchange.Body = []byte(entry.Request.PostData.Text)
+		}
+
+		if content := entry.Response.Content; content.Text != nil {
+			exchange.ResponseBody = []byte(*content.Text)
+			if content.Encoding == "base64" {
+				decoded, err := base64.StdEncoding.DecodeString(*content.Text)
+				if err != nil {
+					return nil, fmt.Errorf("failed to decode body of HAR entry %d: %w", i+1, err)
+				}
+				exchange.ResponseBody = decoded
+			}
+		}
+
+		exchanges = append(exchanges, exchange)
+	}
+
+	return exchanges, nil
+}
@@ -0,0 +1,144 @@
+package infrastructure
+
+import (
+	"context"
+	"net/http"
+	"time"
+
+	"github.com/fourth-ally/gofetch/domain/models"
+)
+
+// Request builds a single request fluently, as an alternative to the
+// positional Get/Post methods and RequestOption lists:
+//
+//	var user User
+//	resp, err := client.R().
+//	    SetHeader("X-Request-Source", "admin").
+//	    SetPathParam("id", 7).
+//	    SetQuery("expand", "teams").
+//	    SetResult(&user).
+//	    Get(ctx, "/users/:id")
+//
+// A Request is not safe for concurrent use. It can be sent more than once.
+type Request struct {
+	client *Client
+	params map[string]interface{}
+	body   interface{}
+	result interface{}
+	opts   []RequestOption
+}
+
+// R starts building a request with the client's configuration.
+func (c *Client) R() *Request {
+	return &Request{client: c, params: make(map[string]interface{})}
+}
+
+// SetHeader sets a header for this request.
+func (r *Request) SetHeader(key, value string) *Request {
+	r.opts = append(r.opts, WithHeader(key, value))
+	return r
+}
+
+// SetHeaders sets several headers for this request.
+func (r *Request) SetHeaders(headers map[string]string) *Request {
+	for key, value := range headers {
+		r.SetHeader(key, value)
+	}
+	return r
+}
+
+// SetQuery adds a query parameter.
+func (r *Request) SetQuery(key string, value interface{}) *Request {
+	r.params[key] = value
+	return r
+}
+
+// SetQueryParams adds several query parameters.
+func (r *Request) SetQueryParams(params map[string]interface{}) *Request {
+	for key, value := range params {
+		r.params[key] = value
+	}
+	return r
+}
+
+// SetPathParam fills the :key placeholder of the path.
+func (r *Request) SetPathParam(key string, value interface{}) *Request {
+	r.params[key] = value
+	return r
+}
+
+// SetBody sets the request body, encoded like the body of Post.
+func (r *Request) SetBody(body interface{}) *Request {
+	r.body = body
+	return r
+}
+
+// SetResult sets the target the response body is decoded into.
+func (r *Request) SetResult(target interface{}) *Request {
+	r.result = target
+	return r
+}
+
+// SetError sets the target a rejected response body is decoded into, as
+// WithError does.
+func (r *Request) SetError(target interface{}) *Request {
+	r.opts = append(r.opts, WithError(target))
+	return r
+}
+
+// SetTimeout overrides the client timeout for this request.
+func (r *Request) SetTimeout(timeout time.Duration) *Request {
+	r.opts = append(r.opts, WithTimeout(timeout))
+	return r
+}
+
+// SetContentType overrides the Content-Type of the encoded body.
+func (r *Request) SetContentType(contentType string) *Request {
+	r.opts = append(r.opts, WithContentType(contentType))
+	return r
+}
+
+// SetOptions applies RequestOptions, for settings without a setter.
+func (r *Request) SetOptions(opts ...RequestOption) *Request {
+	r.opts = append(r.opts, opts...)
+	return r
+}
+
+// Get sends the request as a GET.
+func (r *Request) Get(ctx context.Context, path string) (*models.Response, error) {
+	return r.Send(ctx, http.MethodGet, path)
+}
+
+// Post sends the request as a POST.
+func (r *Request) Post(ctx context.Context, path string) (*models.Response, error) {
+	return r.Send(ctx, http.MethodPost, path)
+}
+
+// Put sends the request as a PUT.
+func (r *Request) Put(ctx context.Context, path string) (*models.Response, error) {
+	return r.Send(ctx, http.MethodPut, path)
+}
+
+// Patch sends the request as a PATCH.
+func (r *Request) Patch(ctx context.Context, path string) (*models.Response, error) {
+	return r.Send(ctx, http.MethodPatch, path)
+}
+
+// Delete sends the request as a DELETE.
+func (r *Request) Delete(ctx context.Context, path string) (*models.Response, error) {
+	return r.Send(ctx, http.MethodDelete, path)
+}
+
+// Head sends the request as a HEAD.
+func (r *Request) Head(ctx context.Context, path string) (*models.Response, error) {
+	return r.Send(ctx, http.MethodHead, path)
+}
+
+// Send sends the request with any method.
+func (r *Request) Send(ctx context.Context, method, path string) (*models.Response, error) {
+	var params map[string]interface{}
+	if len(r.params) > 0 {
+		params = r.params
+	}
+	return r.client.executeWithOptions(ctx, method, path, params, r.body, r.result, r.opts)
+}
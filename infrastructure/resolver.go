@@ -0,0 +1,247 @@
+package infrastructure
+
+import (
+	"context"
+	"encoding/json"
+	stderrors "errors"
+	"fmt"
+	"net"
+	"net/http"
+	"net/http/httptrace"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/fourth-ally/gofetch/domain/contracts"
+)
+
+// SetResolvers resolves host names through resolvers in order, falling
+// back to the next one when a resolver fails or finds no addresses, before
+// failing the request with a DNS error. This keeps requests working when
+// container DNS is flaky, e.g. with SetResolvers(SystemResolver(),
+// NewDoHResolver("https://1.1.1.1/dns-query", nil)). The resolver that
+// answered is recorded in Timings.Resolver. Call it without resolvers to
+// use only the system resolver again. It has no effect with a Unix socket
+// or a custom RoundTripper.
+func (c *Client) SetResolvers(resolvers ...contracts.Resolver) *Client {
+	transport := c.transport()
+	if transport == nil {
+		return c
+	}
+
+	c.resolvers = append([]contracts.Resolver(nil), resolvers...)
+	transport.DialContext = c.dialContext()
+	return c
+}
+
+// SystemResolver returns the resolver of the operating system, named "system".
+func SystemResolver() contracts.Resolver {
+	return systemResolver{}
+}
+
+// StaticHosts returns a resolver answering from a fixed map of host names
+// to IP addresses, like /etc/hosts, named "hosts".
+func StaticHosts(hosts map[string][]string) contracts.Resolver {
+	table := make(map[string][]string, len(hosts))
+	for host, addrs := range hosts {
+		table[strings.ToLower(host)] = append([]string(nil), addrs...)
+	}
+	return staticHosts(table)
+}
+
+// NewDoHResolver returns a resolver querying a DNS-over-HTTPS endpoint
+// with the JSON API offered by public resolvers such as
+// https://1.1.1.1/dns-query and https://dns.google/resolve, named "doh".
+// A nil httpClient uses a client with a five second timeout. The endpoint
+// should be an IP address or be resolvable without the resolver itself.
+func NewDoHResolver(endpoint string, httpClient *http.Client) contracts.Resolver {
+	if httpClient == nil {
+		httpClient = &http.Client{Timeout: 5 * time.Second}
+	}
+	return &dohResolver{endpoint: endpoint, client: httpClient}
+}
+
+// systemResolver implements contracts.Resolver with net.DefaultResolver.
+type systemResolver struct{}
+
+// Name implements contracts.Resolver.
+func (systemResolver) Name() string { return "system" }
+
+// LookupHost implements contracts.Resolver.
+func (systemResolver) LookupHost(ctx context.Context, host string) ([]string, error) {
+	return net.DefaultResolver.LookupHost(ctx, host)
+}
+
+// staticHosts implements contracts.Resolver with a fixed table.
+type staticHosts map[string][]string
+
+// Name implements contracts.Resolver.
+func (staticHosts) Name() string { return "hosts" }
+
+// LookupHost implements contracts.Resolver.
+func (h staticHosts) LookupHost(_ context.Context, host string) ([]string, error) {
+	addrs, ok := h[strings.ToLower(host)]
+	if !ok {
+		return nil, fmt.Errorf("%s is not in the hosts table", host)
+	}
+	return addrs, nil
+}
+
+// dohResolver implements contracts.Resolver with the DNS-over-HTTPS JSON API.
+type dohResolver struct {
+	endpoint string
+	client   *http.Client
+}
+
+// DNS record types queried over DoH.
+const (
+	dnsTypeA    = 1
+	dnsTypeAAAA = 28
+)
+
+// Name implements contracts.Resolver.
+func (r *dohResolver) Name() string { return "doh" }
+
+// LookupHost implements contracts.Resolver, querying A and AAAA records.
+func (r *dohResolver) LookupHost(ctx context.Context, host string) ([]string, error) {
+	var addrs []string
+	var errs []error
+	for _, recordType := range []int{dnsTypeA, dnsTypeAAAA} {
+		found, err := r.query(ctx, host, recordType)
+		if err != nil {
+			errs = append(errs, err)
+		}
+		addrs = append(addrs, found...)
+	}
+
+	if len(addrs) == 0 {
+		if len(errs) > 0 {
+			return nil, stderrors.Join(errs...)
+		}
+		return nil, fmt.Errorf("no addresses for %s", host)
+	}
+	return addrs, nil
+}
+
+// query asks the endpoint for the records of one type.
+func (r *dohResolver) query(ctx context.Context, host string, recordType int) ([]string, error) {
+	query := url.Values{"name": {host}, "type": {fmt.Sprint(recordType)}}
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, r.endpoint+"?"+query.Encode(), nil)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Accept", "application/dns-json")
+
+	resp, err := r.client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("DoH endpoint returned HTTP %d", resp.StatusCode)
+	}
+
+	var answer struct {
+		Status int
+		Answer []struct {
+			Type int    `json:"type"`
+			Data string `json:"data"`
+		}
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&answer); err != nil {
+		return nil, fmt.Errorf("invalid DoH response: %w", err)
+	}
+	if answer.Status != 0 {
+		return nil, fmt.Errorf("DoH query for %s failed with DNS status %d", host, answer.Status)
+	}
+
+	var addrs []string
+	for _, record := range answer.Answer {
+		if record.Type == recordType && net.ParseIP(record.Data) != nil {
+			addrs = append(addrs, record.Data)
+		}
+	}
+	return addrs, nil
+}
+
+// resolvingDial returns a dial function that resolves host names through
+// resolvers and dials the addresses found in order.
+func resolvingDial(dialer *net.Dialer, resolvers []contracts.Resolver) func(ctx context.Context, network, addr string) (net.Conn, error) {
+	return func(ctx context.Context, network, addr string) (net.Conn, error) {
+		host, port, err := net.SplitHostPort(addr)
+		if err != nil || net.ParseIP(host) != nil {
+			return dialer.DialContext(ctx, network, addr)
+		}
+
+		addrs, err := resolveHost(ctx, host, resolvers)
+		if err != nil {
+			return nil, err
+		}
+
+		var firstErr error
+		for _, ip := range addrs {
+			conn, err := dialer.DialContext(ctx, network, net.JoinHostPort(ip, port))
+			if err == nil {
+				return conn, nil
+			}
+			if firstErr == nil {
+				firstErr = err
+			}
+			if ctx.Err() != nil {
+				break
+			}
+		}
+		return nil, firstErr
+	}
+}
+
+// resolveHost tries resolvers in order, reporting the lookup to the
+// request's trace hooks as the system resolver would.
+func resolveHost(ctx context.Context, host string, resolvers []contracts.Resolver) ([]string, error) {
+	trace := httptrace.ContextClientTrace(ctx)
+	if trace != nil && trace.DNSStart != nil {
+		trace.DNSStart(httptrace.DNSStartInfo{Host: host})
+	}
+
+	var failures []string
+	for _, resolver := range resolvers {
+		addrs, err := resolver.LookupHost(ctx, host)
+		if err == nil && len(addrs) > 0 {
+			recordResolver(ctx, resolver.Name())
+			if trace != nil && trace.DNSDone != nil {
+				trace.DNSDone(httptrace.DNSDoneInfo{Addrs: ipAddrs(addrs)})
+			}
+			return addrs, nil
+		}
+
+		if err == nil {
+			err = fmt.Errorf("no addresses")
+		}
+		failures = append(failures, fmt.Sprintf("%s: %v", resolver.Name(), err))
+		if ctx.Err() != nil {
+			break
+		}
+	}
+
+	dnsErr := &net.DNSError{
+		Err:       strings.Join(failures, "; "),
+		Name:      host,
+		IsTimeout: stderrors.Is(ctx.Err(), context.DeadlineExceeded),
+	}
+	if trace != nil && trace.DNSDone != nil {
+		trace.DNSDone(httptrace.DNSDoneInfo{Err: dnsErr})
+	}
+	return nil, dnsErr
+}
+
+// ipAddrs converts textual addresses for httptrace.DNSDoneInfo.
+func ipAddrs(addrs []string) []net.IPAddr {
+	ips := make([]net.IPAddr, 0, len(addrs))
+	for _, addr := range addrs {
+		if ip := net.ParseIP(addr); ip != nil {
+			ips = append(ips, net.IPAddr{IP: ip})
+		}
+	}
+	return ips
+}
@@ -0,0 +1,40 @@
+package infrastructure
+
+import (
+	"io"
+
+	"github.com/fourth-ally/gofetch/domain/errors"
+)
+
+// SetMaxResponseSize caps the size of response bodies read into memory.
+// Responses declaring a larger Content-Length fail before their body is
+// read; others fail as soon as the limit is crossed while reading. Both
+// return *errors.ResponseTooLargeError. The cap applies to decompressed
+// bodies, but not to Download, streams or raw responses, which don't
+// buffer. Zero disables it.
+func (c *Client) SetMaxResponseSize(limit int64) *Client {
+	c.maxResponseSize = limit
+	return c
+}
+
+// sizeLimitedReader fails with a *errors.ResponseTooLargeError once more
+// than limit bytes were read.
+type sizeLimitedReader struct {
+	reader io.Reader
+	limit  int64
+	read   int64
+}
+
+// Read implements io.Reader.
+func (r *sizeLimitedReader) Read(p []byte) (int, error) {
+	if remaining := r.limit - r.read + 1; int64(len(p)) > remaining {
+		p = p[:remaining]
+	}
+
+	n, err := r.reader.Read(p)
+	r.read += int64(n)
+	if r.read > r.limit {
+		return n, &errors.ResponseTooLargeError{Limit: r.limit, ContentLength: -1}
+	}
+	return n, err
+}
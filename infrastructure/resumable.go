@@ -0,0 +1,139 @@
+package infrastructure
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+)
+
+// resumableBody wraps a GET response body and, when the connection dies
+// mid-stream, reissues the request with a Range header starting at the
+// bytes already received so the caller sees one uninterrupted stream.
+type resumableBody struct {
+	ctx        context.Context
+	httpClient *http.Client
+	req        *http.Request
+	body       io.ReadCloser
+
+	offset     int64
+	validator  string
+	resumes    int
+	maxResumes int
+}
+
+// SetBodyResumption enables transparent resumption of GET response bodies
+// interrupted by connection errors, using up to maxResumes Range requests
+// per response. Compressed responses are not resumed. Zero disables
+// resumption.
+func (c *Client) SetBodyResumption(maxResumes int) *Client {
+	c.maxBodyResumes = maxResumes
+	return c
+}
+
+// newResumableBody wraps resp.Body if the response can be resumed.
+func (c *Client) newResumableBody(ctx context.Context, req *http.Request, resp *http.Response) io.ReadCloser {
+	if c.maxBodyResumes <= 0 || req.Method != http.MethodGet || resp.StatusCode != http.StatusOK {
+		return resp.Body
+	}
+
+	if resp.Header.Get("Accept-Ranges") == "none" {
+		return resp.Body
+	}
+
+	// Ranges count bytes of the encoded body, not of the decoded stream
+	// the caller reads, so compressed responses can't be resumed
+	if resp.Uncompressed || resp.Header.Get("Content-Encoding") != "" {
+		return resp.Body
+	}
+
+	return &resumableBody{
+		ctx:        ctx,
+		httpClient: c.stateFor(ctx).httpClient,
+		req:        req,
+		body:       resp.Body,
+		validator:  rangeValidator(resp.Header),
+		maxResumes: c.maxBodyResumes,
+	}
+}
+
+// rangeValidator returns the If-Range value for a response: its strong
+// ETag or, failing that, its Last-Modified date, as If-Range requires.
+func rangeValidator(header http.Header) string {
+	validator := header.Get("ETag")
+	if validator == "" || strings.HasPrefix(validator, "W/") {
+		validator = header.Get("Last-Modified")
+	}
+	return validator
+}
+
+// Read implements io.Reader, resuming the download on transport errors.
+func (r *resumableBody) Read(p []byte) (int, error) {
+	for {
+		n, err := r.body.Read(p)
+		r.offset += int64(n)
+
+		if err == nil || err == io.EOF {
+			return n, err
+		}
+
+		if r.ctx.Err() != nil || r.resumes >= r.maxResumes {
+			return n, err
+		}
+
+		if resumeErr := r.resume(); resumeErr != nil {
+			return n, fmt.Errorf("%w (resume failed: %v)", err, resumeErr)
+		}
+
+		if n > 0 {
+			return n, nil
+		}
+	}
+}
+
+// Close closes the current underlying body.
+func (r *resumableBody) Close() error {
+	return r.body.Close()
+}
+
+// resume requests the remainder of the body starting at the current offset.
+func (r *resumableBody) resume() error {
+	r.body.Close()
+	r.resumes++
+
+	req := r.req.Clone(r.ctx)
+	req.Header.Set("Range", fmt.Sprintf("bytes=%d-", r.offset))
+	if r.validator != "" {
+		req.Header.Set("If-Range", r.validator)
+	}
+
+	resp, err := r.httpClient.Do(req)
+	if err != nil {
+		return err
+	}
+
+	if resp.StatusCode != http.StatusPartialContent || rangeStart(resp.Header.Get("Content-Range")) != r.offset {
+		resp.Body.Close()
+		return fmt.Errorf("server did not resume at byte %d (status %d)", r.offset, resp.StatusCode)
+	}
+
+	r.body = resp.Body
+	return nil
+}
+
+// rangeStart parses the first byte position from a Content-Range header.
+func rangeStart(contentRange string) int64 {
+	spec, ok := strings.CutPrefix(contentRange, "bytes ")
+	if !ok {
+		return -1
+	}
+
+	start, _, _ := strings.Cut(spec, "-")
+	value, err := strconv.ParseInt(start, 10, 64)
+	if err != nil {
+		return -1
+	}
+	return value
+}
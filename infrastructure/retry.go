@@ -3,14 +3,18 @@ package infrastructure
 import (
 	"math"
 	"math/rand"
+	"net/http"
+	"strconv"
 	"time"
 
+	"github.com/fourth-ally/gofetch/domain/contracts"
 	"github.com/fourth-ally/gofetch/domain/models"
 )
 
 // RetryManager handles retry logic with backoff strategies.
 type RetryManager struct {
 	options *models.RetryOptions
+	backoff contracts.Backoff
 	rng     *rand.Rand
 }
 
@@ -33,9 +37,9 @@ func (rm *RetryManager) ShouldRetry(attempt int, statusCode int, err error) bool
 		return false
 	}
 
-	// Retry on network errors
+	// Retry on network errors the policy considers retryable
 	if err != nil {
-		return true
+		return rm.options.ShouldRetryError(err)
 	}
 
 	// Retry on configured status codes
@@ -44,6 +48,17 @@ func (rm *RetryManager) ShouldRetry(attempt int, statusCode int, err error) bool
 
 // CalculateDelay calculates the delay before the next retry attempt.
 func (rm *RetryManager) CalculateDelay(attempt int) time.Duration {
+	return rm.NextDelay(attempt, 0)
+}
+
+// NextDelay calculates the delay before the next retry attempt, given the
+// delay before the previous one. A custom backoff set with SetRetryBackoff
+// takes precedence over the built-in strategies.
+func (rm *RetryManager) NextDelay(attempt int, previous time.Duration) time.Duration {
+	if rm.backoff != nil {
+		return rm.backoff.Delay(attempt, previous)
+	}
+
 	var delay time.Duration
 
 	switch rm.options.Backoff {
@@ -109,3 +124,24 @@ func (rm *RetryManager) Wait(attempt int) {
 	delay := rm.CalculateDelay(attempt)
 	time.Sleep(delay)
 }
+
+// rateLimitDelay returns how long a rate-limited response asks the client
+// to wait, from Retry-After or an exhausted X-RateLimit-Reset.
+func rateLimitDelay(headers http.Header) (time.Duration, bool) {
+	if retryAfter := headers.Get("Retry-After"); retryAfter != "" {
+		if seconds, err := strconv.Atoi(retryAfter); err == nil {
+			return time.Duration(seconds) * time.Second, true
+		}
+		if at, err := http.ParseTime(retryAfter); err == nil {
+			return max(time.Until(at), 0), true
+		}
+	}
+
+	if headers.Get("X-RateLimit-Remaining") == "0" {
+		if reset, err := strconv.ParseInt(headers.Get("X-RateLimit-Reset"), 10, 64); err == nil {
+			return max(time.Until(time.Unix(reset, 0)), 0), true
+		}
+	}
+
+	return 0, false
+}
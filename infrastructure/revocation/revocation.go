@@ -0,0 +1,162 @@
+// Package revocation provides certificate revocation checks for use with
+// Client.SetCertVerifier: OCSP staples sent during the handshake, and CRLs
+// downloaded from the certificates' distribution points.
+package revocation
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"sync"
+	"time"
+
+	"golang.org/x/crypto/ocsp"
+
+	"github.com/fourth-ally/gofetch/domain/contracts"
+)
+
+// ErrCertificateRevoked is returned when a peer certificate has been revoked.
+var ErrCertificateRevoked = errors.New("certificate has been revoked")
+
+// ErrNoStaple is returned when an OCSP staple is required but missing.
+var ErrNoStaple = errors.New("server did not staple an OCSP response")
+
+// OCSPStapleVerifier checks the OCSP response stapled by the server during
+// the handshake. When requireStaple is false, connections without a staple
+// are accepted.
+func OCSPStapleVerifier(requireStaple bool) contracts.CertVerifier {
+	return func(state tls.ConnectionState) error {
+		if len(state.OCSPResponse) == 0 {
+			if requireStaple {
+				return ErrNoStaple
+			}
+			return nil
+		}
+
+		leaf, issuer, err := leafAndIssuer(state)
+		if err != nil {
+			return err
+		}
+
+		resp, err := ocsp.ParseResponseForCert(state.OCSPResponse, leaf, issuer)
+		if err != nil {
+			return fmt.Errorf("invalid OCSP staple: %w", err)
+		}
+
+		if resp.Status == ocsp.Revoked {
+			return fmt.Errorf("%w: serial %s revoked at %s", ErrCertificateRevoked, leaf.SerialNumber, resp.RevokedAt.Format(time.RFC3339))
+		}
+
+		if !resp.NextUpdate.IsZero() && time.Now().After(resp.NextUpdate) {
+			return fmt.Errorf("stale OCSP staple: next update was %s", resp.NextUpdate.Format(time.RFC3339))
+		}
+
+		return nil
+	}
+}
+
+// CRLVerifier checks every non-root certificate of the verified chain
+// against the CRLs listed in its distribution points. Downloaded CRLs are
+// cached until their NextUpdate time.
+type CRLVerifier struct {
+	mu sync.Mutex
+
+	httpClient *http.Client
+	cache      map[string]*x509.RevocationList
+}
+
+// NewCRLVerifier creates a CRL verifier that downloads CRLs with httpClient.
+// A nil httpClient uses a client with a 10 second timeout.
+func NewCRLVerifier(httpClient *http.Client) *CRLVerifier {
+	if httpClient == nil {
+		httpClient = &http.Client{Timeout: 10 * time.Second}
+	}
+
+	return &CRLVerifier{
+		httpClient: httpClient,
+		cache:      make(map[string]*x509.RevocationList),
+	}
+}
+
+// Verify implements contracts.CertVerifier.
+func (v *CRLVerifier) Verify(state tls.ConnectionState) error {
+	if len(state.VerifiedChains) == 0 {
+		return nil
+	}
+
+	chain := state.VerifiedChains[0]
+	for i := 0; i < len(chain)-1; i++ {
+		cert, issuer := chain[i], chain[i+1]
+		for _, url := range cert.CRLDistributionPoints {
+			crl, err := v.fetch(url, issuer)
+			if err != nil {
+				return err
+			}
+
+			for _, entry := range crl.RevokedCertificateEntries {
+				if entry.SerialNumber.Cmp(cert.SerialNumber) == 0 {
+					return fmt.Errorf("%w: serial %s listed in %s", ErrCertificateRevoked, cert.SerialNumber, url)
+				}
+			}
+		}
+	}
+
+	return nil
+}
+
+// fetch returns the CRL at url, downloading and verifying it if not cached.
+func (v *CRLVerifier) fetch(url string, issuer *x509.Certificate) (*x509.RevocationList, error) {
+	v.mu.Lock()
+	cached, ok := v.cache[url]
+	v.mu.Unlock()
+
+	if ok && time.Now().Before(cached.NextUpdate) {
+		return cached, nil
+	}
+
+	resp, err := v.httpClient.Get(url)
+	if err != nil {
+		return nil, fmt.Errorf("failed to download CRL %s: %w", url, err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("failed to download CRL %s: HTTP %d", url, resp.StatusCode)
+	}
+
+	der, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read CRL %s: %w", url, err)
+	}
+
+	crl, err := x509.ParseRevocationList(der)
+	if err != nil {
+		return nil, fmt.Errorf("invalid CRL %s: %w", url, err)
+	}
+
+	if err := crl.CheckSignatureFrom(issuer); err != nil {
+		return nil, fmt.Errorf("invalid CRL signature %s: %w", url, err)
+	}
+
+	v.mu.Lock()
+	v.cache[url] = crl
+	v.mu.Unlock()
+
+	return crl, nil
+}
+
+// leafAndIssuer returns the peer certificate and its issuer.
+func leafAndIssuer(state tls.ConnectionState) (*x509.Certificate, *x509.Certificate, error) {
+	if len(state.VerifiedChains) > 0 && len(state.VerifiedChains[0]) > 1 {
+		return state.VerifiedChains[0][0], state.VerifiedChains[0][1], nil
+	}
+
+	if len(state.PeerCertificates) > 1 {
+		return state.PeerCertificates[0], state.PeerCertificates[1], nil
+	}
+
+	return nil, nil, errors.New("cannot check OCSP staple: issuer certificate unavailable")
+}
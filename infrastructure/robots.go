@@ -0,0 +1,148 @@
+package infrastructure
+
+import (
+	"bufio"
+	"bytes"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// maxRobotsSize is the robots.txt size crawlers must parse at least
+// (RFC 9309); content past it is ignored.
+const maxRobotsSize = 500 << 10
+
+// robotsRules are the robots.txt rules that apply to one user agent.
+type robotsRules struct {
+	rules      []robotsRule
+	crawlDelay time.Duration
+}
+
+// robotsRule allows or disallows paths matching pattern.
+type robotsRule struct {
+	allow   bool
+	pattern string
+}
+
+// parseRobots extracts the rules of the group matching the product token
+// of userAgent from a robots.txt file, falling back to the "*" group.
+// Groups naming the same agent are combined.
+func parseRobots(data []byte, userAgent string) *robotsRules {
+	token := strings.ToLower(userAgent)
+	if i := strings.IndexAny(token, "/ "); i >= 0 {
+		token = token[:i]
+	}
+
+	var specific, wildcard robotsRules
+	var agents []string
+	matchedSpecific := false
+	inRules := false
+
+	scanner := bufio.NewScanner(bytes.NewReader(data))
+	for scanner.Scan() {
+		line := scanner.Text()
+		if i := strings.IndexByte(line, '#'); i >= 0 {
+			line = line[:i]
+		}
+
+		key, value, ok := strings.Cut(line, ":")
+		if !ok {
+			continue
+		}
+		key = strings.ToLower(strings.TrimSpace(key))
+		value = strings.TrimSpace(value)
+
+		if key == "user-agent" {
+			// A user-agent line after rules starts a new group
+			if inRules {
+				agents, inRules = nil, false
+			}
+			agent := strings.ToLower(value)
+			agents = append(agents, agent)
+			if token != "" && agent == token {
+				matchedSpecific = true
+			}
+			continue
+		}
+
+		inRules = true
+		for _, target := range robotsTargets(agents, token, &specific, &wildcard) {
+			switch key {
+			case "allow", "disallow":
+				if value != "" {
+					target.rules = append(target.rules, robotsRule{allow: key == "allow", pattern: value})
+				}
+			case "crawl-delay":
+				if seconds, err := strconv.ParseFloat(value, 64); err == nil && seconds > 0 {
+					target.crawlDelay = time.Duration(seconds * float64(time.Second))
+				}
+			}
+		}
+	}
+
+	if matchedSpecific {
+		return &specific
+	}
+	return &wildcard
+}
+
+// robotsTargets returns the rule sets a line of the group naming agents
+// belongs to.
+func robotsTargets(agents []string, token string, specific, wildcard *robotsRules) []*robotsRules {
+	var targets []*robotsRules
+	for _, agent := range agents {
+		if token != "" && agent == token {
+			targets = append(targets, specific)
+			break
+		}
+	}
+	for _, agent := range agents {
+		if agent == "*" {
+			targets = append(targets, wildcard)
+			break
+		}
+	}
+	return targets
+}
+
+// allowed reports whether path (including any query) may be crawled: the
+// longest matching rule wins and allow wins ties.
+func (r *robotsRules) allowed(path string) bool {
+	allow := true
+	longest := -1
+	for _, rule := range r.rules {
+		if !robotsMatch(rule.pattern, path) {
+			continue
+		}
+		if len(rule.pattern) > longest || (len(rule.pattern) == longest && rule.allow) {
+			allow, longest = rule.allow, len(rule.pattern)
+		}
+	}
+	return allow
+}
+
+// robotsMatch matches path against a robots.txt pattern, where "*" matches
+// any sequence of characters and a trailing "$" anchors the end.
+func robotsMatch(pattern, path string) bool {
+	anchored := strings.HasSuffix(pattern, "$")
+	pattern = strings.TrimSuffix(pattern, "$")
+
+	parts := strings.Split(pattern, "*")
+	if !strings.HasPrefix(path, parts[0]) {
+		return false
+	}
+	pos := len(parts[0])
+
+	for i, part := range parts[1:] {
+		if anchored && i == len(parts)-2 {
+			return len(path)-pos >= len(part) && strings.HasSuffix(path, part)
+		}
+		index := strings.Index(path[pos:], part)
+		if index < 0 {
+			return false
+		}
+		pos += index + len(part)
+	}
+
+	return !anchored || pos == len(path)
+}
@@ -0,0 +1,37 @@
+package infrastructure
+
+import (
+	"context"
+	"math/rand"
+
+	"github.com/fourth-ally/gofetch/domain/models"
+)
+
+// samplingKey is the context key of the sampling decision of a request.
+type samplingKey struct{}
+
+// SetSampling limits debug logging, the request journal and ClientTrace
+// hooks to a random fraction of requests, e.g. 0.01 for one in a hundred,
+// to keep observability cheap in high-traffic services. Requests are
+// always sent; only their capture is skipped. WithSampling overrides the
+// rate per request.
+func (c *Client) SetSampling(rate float64) *Client {
+	c.state.Load().config.SampleRate = &rate
+	return c
+}
+
+// withSamplingDecision records in ctx whether the request is sampled at
+// the rate in config.
+func withSamplingDecision(ctx context.Context, config *models.Config) context.Context {
+	if config.SampleRate == nil {
+		return ctx
+	}
+	return context.WithValue(ctx, samplingKey{}, rand.Float64() < *config.SampleRate)
+}
+
+// sampled reports whether the request of ctx was sampled. Requests made
+// without a sampling rate always are.
+func sampled(ctx context.Context) bool {
+	decision, ok := ctx.Value(samplingKey{}).(bool)
+	return !ok || decision
+}
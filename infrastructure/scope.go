@@ -0,0 +1,137 @@
+package infrastructure
+
+import (
+	"context"
+	stderrors "errors"
+	"net/http"
+	"sync"
+)
+
+// Scope groups concurrent requests that share a context, a concurrency
+// limit and error aggregation. The first failing request cancels the rest.
+//
+// Example:
+//
+//	scope := client.Scope(ctx).SetLimit(4)
+//	scope.Get("/users/1", nil, &user)
+//	scope.Get("/users/1/posts", nil, &posts)
+//	if err := scope.Wait(); err != nil { ... }
+type Scope struct {
+	client *Client
+	ctx    context.Context
+	cancel context.CancelFunc
+
+	wg  sync.WaitGroup
+	sem chan struct{}
+
+	mu   sync.Mutex
+	errs []error
+}
+
+// Scope creates a request scope derived from ctx.
+func (c *Client) Scope(ctx context.Context) *Scope {
+	scopeCtx, cancel := context.WithCancel(ctx)
+	return &Scope{
+		client: c,
+		ctx:    scopeCtx,
+		cancel: cancel,
+	}
+}
+
+// SetLimit caps the number of requests running at once. It must be called
+// before any request is launched. A non-positive limit means no limit.
+func (s *Scope) SetLimit(n int) *Scope {
+	if n > 0 {
+		s.sem = make(chan struct{}, n)
+	} else {
+		s.sem = nil
+	}
+	return s
+}
+
+// Context returns the scope's context, cancelled on first failure or Wait.
+func (s *Scope) Context() context.Context {
+	return s.ctx
+}
+
+// Go runs fn in the scope. It blocks while the concurrency limit is reached.
+func (s *Scope) Go(fn func(ctx context.Context, client *Client) error) {
+	if s.sem != nil {
+		select {
+		case s.sem <- struct{}{}:
+		case <-s.ctx.Done():
+			s.fail(s.ctx.Err())
+			return
+		}
+	}
+
+	s.wg.Add(1)
+	go func() {
+		defer s.wg.Done()
+		if s.sem != nil {
+			defer func() { <-s.sem }()
+		}
+
+		if err := fn(s.ctx, s.client); err != nil {
+			s.fail(err)
+		}
+	}()
+}
+
+// Get launches a GET request in the scope.
+func (s *Scope) Get(path string, params map[string]interface{}, target interface{}) {
+	s.launch(http.MethodGet, path, params, nil, target)
+}
+
+// Post launches a POST request in the scope.
+func (s *Scope) Post(path string, params map[string]interface{}, body interface{}, target interface{}) {
+	s.launch(http.MethodPost, path, params, body, target)
+}
+
+// Put launches a PUT request in the scope.
+func (s *Scope) Put(path string, params map[string]interface{}, body interface{}, target interface{}) {
+	s.launch(http.MethodPut, path, params, body, target)
+}
+
+// Patch launches a PATCH request in the scope.
+func (s *Scope) Patch(path string, params map[string]interface{}, body interface{}, target interface{}) {
+	s.launch(http.MethodPatch, path, params, body, target)
+}
+
+// Delete launches a DELETE request in the scope.
+func (s *Scope) Delete(path string, params map[string]interface{}, target interface{}) {
+	s.launch(http.MethodDelete, path, params, nil, target)
+}
+
+// Wait blocks until all requests in the scope finish and returns their
+// errors joined together, or nil if all succeeded.
+func (s *Scope) Wait() error {
+	s.wg.Wait()
+	s.cancel()
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return stderrors.Join(s.errs...)
+}
+
+// launch runs a single HTTP request in the scope.
+func (s *Scope) launch(method, path string, params map[string]interface{}, body interface{}, target interface{}) {
+	s.Go(func(ctx context.Context, client *Client) error {
+		_, err := client.execute(ctx, method, path, params, body, target, nil)
+		return err
+	})
+}
+
+// fail records an error and cancels the remaining requests. Cancellation
+// errors caused by an earlier failure are not recorded.
+func (s *Scope) fail(err error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if len(s.errs) > 0 && stderrors.Is(err, context.Canceled) {
+		return
+	}
+
+	s.errs = append(s.errs, err)
+	s.cancel()
+}
@@ -0,0 +1,166 @@
+package infrastructure
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	stderrors "errors"
+	"fmt"
+	"io"
+	"net/http"
+
+	"github.com/fourth-ally/gofetch/domain/errors"
+	"github.com/fourth-ally/gofetch/domain/models"
+)
+
+// SetShadow mirrors requests to a shadow backend in the background. Only
+// safe methods are mirrored unless ShadowOptions.MirrorUnsafe is set, and
+// requests with streamed bodies, such as files and pipes, are not
+// mirrored, since the body can only be sent once. Shadow requests bypass
+// the bulkhead, rate limiter, crawl delay and journal, so they neither
+// hold up nor show up as primary traffic. Pass nil to disable mirroring.
+func (c *Client) SetShadow(options *models.ShadowOptions) *Client {
+	c.shadow = options
+	return c
+}
+
+// mirror replays a request against the shadow backend and, if enabled,
+// compares the shadow response with the primary one.
+func (c *Client) mirror(ctx context.Context, method, path string, params map[string]interface{}, body interface{}, requestConfig *models.Config, primaryResp *models.Response, primaryErr error) {
+	shadow := c.shadow
+	if !isSafe(method) && !shadow.MirrorUnsafe {
+		return
+	}
+
+	// A streamed body belongs to the primary request
+	if isStreamBody(body) {
+		return
+	}
+
+	// Nothing to compare against if the primary never got a response
+	primaryStatus, primaryBody, ok := responseOutcome(primaryResp, primaryErr)
+	if !ok && shadow.Compare {
+		return
+	}
+
+	shadowConfig := overrideConfig(requestConfig, &models.Config{BaseURL: shadow.BaseURL})
+
+	// Detached from the caller's context so cancellation of the primary
+	// call doesn't abort the mirrored request
+	ctx = context.WithoutCancel(ctx)
+
+	go func() {
+		status, respBody, err := c.sendShadow(ctx, method, path, params, body, shadowConfig)
+		if !shadow.Compare || shadow.OnMismatch == nil {
+			return
+		}
+
+		mismatch := &models.ShadowMismatch{
+			Method:        method,
+			Path:          path,
+			PrimaryStatus: primaryStatus,
+			PrimaryBody:   primaryBody,
+		}
+
+		if err != nil {
+			mismatch.ShadowErr = err
+			shadow.OnMismatch(mismatch)
+			return
+		}
+
+		mismatch.ShadowStatus = status
+		mismatch.ShadowBody = respBody
+		mismatch.StatusMismatch = primaryStatus != status
+		mismatch.BodyMismatch = !bodiesEqual(primaryBody, respBody, shadow.Normalize)
+
+		if mismatch.StatusMismatch || mismatch.BodyMismatch {
+			shadow.OnMismatch(mismatch)
+		}
+	}()
+}
+
+// sendShadow sends a mirrored request and returns its status and body. It
+// goes straight to the shadow backend, skipping the bulkhead, rate
+// limiter, crawl delay, response interceptors, trace hooks, body tees and
+// journal of primary requests.
+func (c *Client) sendShadow(ctx context.Context, method, path string, params map[string]interface{}, body interface{}, shadowConfig *models.Config) (int, []byte, error) {
+	state := c.stateFor(ctx)
+	config := state.merged(shadowConfig)
+	req, err := c.buildRequest(ctx, method, path, params, body, config)
+	if err != nil {
+		return 0, nil, err
+	}
+
+	resp, err := c.httpClientFor(state, shadowConfig).Do(req)
+	if err != nil {
+		return 0, nil, fmt.Errorf("request execution error: %w", errors.ClassifyTransportError(err))
+	}
+	defer resp.Body.Close()
+
+	decompressed, err := c.decompressBody(resp)
+	if err != nil {
+		return 0, nil, err
+	}
+	if decompressed != nil {
+		defer decompressed.Close()
+	}
+
+	var reader io.Reader = resp.Body
+	if c.maxResponseSize > 0 {
+		reader = &sizeLimitedReader{reader: reader, limit: c.maxResponseSize}
+	}
+	respBody, err := io.ReadAll(reader)
+	if err != nil {
+		return 0, nil, fmt.Errorf("failed to read response body: %w", errors.ClassifyTransportError(err))
+	}
+
+	// Compare like with like: primary bodies are transformed too
+	if c.dataTransformer != nil && config.StatusValidator(resp.StatusCode) {
+		if respBody, err = c.dataTransformer(respBody); err != nil {
+			return 0, nil, fmt.Errorf("data transformer error: %w", err)
+		}
+	}
+
+	return resp.StatusCode, respBody, nil
+}
+
+// isSafe reports whether method is safe, i.e. read-only.
+func isSafe(method string) bool {
+	switch method {
+	case http.MethodGet, http.MethodHead, http.MethodOptions, http.MethodTrace:
+		return true
+	default:
+		return false
+	}
+}
+
+// responseOutcome extracts status and body from a response or HTTP error.
+func responseOutcome(resp *models.Response, err error) (int, []byte, bool) {
+	if err == nil && resp != nil {
+		return resp.StatusCode, resp.RawBody, true
+	}
+
+	var httpErr *errors.HTTPError
+	if stderrors.As(err, &httpErr) {
+		return httpErr.StatusCode, httpErr.Body, true
+	}
+
+	return 0, nil, false
+}
+
+// bodiesEqual compares two bodies after normalization. Without a custom
+// normalizer, valid JSON bodies are compared structurally.
+func bodiesEqual(a, b []byte, normalize func([]byte) []byte) bool {
+	if normalize != nil {
+		return bytes.Equal(normalize(a), normalize(b))
+	}
+
+	var aJSON, bJSON interface{}
+	if json.Unmarshal(a, &aJSON) == nil && json.Unmarshal(b, &bJSON) == nil {
+		aCanonical, _ := json.Marshal(aJSON)
+		bCanonical, _ := json.Marshal(bJSON)
+		return bytes.Equal(aCanonical, bCanonical)
+	}
+
+	return bytes.Equal(a, b)
+}
@@ -0,0 +1,124 @@
+package infrastructure
+
+import (
+	"crypto"
+	"crypto/ed25519"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"encoding/base64"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/fourth-ally/gofetch/domain/models"
+)
+
+// SetRequestSigner signs every outgoing request with an HTTP Signature
+// header after request interceptors have run. Pass nil to stop signing.
+//
+// Example (Mastodon):
+//
+//	client.SetRequestSigner(models.NewMastodonSignatureOptions(
+//		"https://example.social/users/alice#main-key", privateKey))
+func (c *Client) SetRequestSigner(options *models.SignatureOptions) *Client {
+	c.signature = options
+	return c
+}
+
+// signRequest adds the Date, Digest and Signature headers to req.
+func signRequest(req *http.Request, options *models.SignatureOptions) error {
+	algorithm, err := signatureAlgorithm(options.Key)
+	if err != nil {
+		return err
+	}
+
+	if req.Header.Get("Date") == "" {
+		req.Header.Set("Date", time.Now().UTC().Format(http.TimeFormat))
+	}
+
+	var signed, lines []string
+	for _, name := range options.Headers {
+		name = strings.ToLower(name)
+
+		var value string
+		switch name {
+		case "(request-target)":
+			value = strings.ToLower(req.Method) + " " + req.URL.RequestURI()
+		case "host":
+			value = req.Host
+			if value == "" {
+				value = req.URL.Host
+			}
+		case "digest":
+			digest, ok, err := bodyDigest(req)
+			if err != nil {
+				return err
+			}
+			if !ok {
+				continue
+			}
+			req.Header.Set("Digest", digest)
+			value = digest
+		default:
+			value = req.Header.Get(name)
+		}
+
+		signed = append(signed, name)
+		lines = append(lines, name+": "+value)
+	}
+
+	signature, err := sign(options.Key, []byte(strings.Join(lines, "\n")))
+	if err != nil {
+		return fmt.Errorf("failed to sign request: %w", err)
+	}
+
+	req.Header.Set("Signature", fmt.Sprintf(`keyId="%s",algorithm="%s",headers="%s",signature="%s"`,
+		options.KeyID, algorithm, strings.Join(signed, " "), base64.StdEncoding.EncodeToString(signature)))
+	return nil
+}
+
+// bodyDigest returns the SHA-256 Digest header value of the request body.
+// It reports false for requests without a body.
+func bodyDigest(req *http.Request) (string, bool, error) {
+	if req.Body == nil || req.Body == http.NoBody || req.GetBody == nil {
+		return "", false, nil
+	}
+
+	body, err := req.GetBody()
+	if err != nil {
+		return "", false, fmt.Errorf("failed to read body for digest: %w", err)
+	}
+	defer body.Close()
+
+	hash := sha256.New()
+	if _, err := io.Copy(hash, body); err != nil {
+		return "", false, fmt.Errorf("failed to read body for digest: %w", err)
+	}
+
+	return "SHA-256=" + base64.StdEncoding.EncodeToString(hash.Sum(nil)), true, nil
+}
+
+// signatureAlgorithm names the algorithm parameter for key.
+func signatureAlgorithm(key crypto.Signer) (string, error) {
+	switch key.(type) {
+	case *rsa.PrivateKey:
+		return "rsa-sha256", nil
+	case ed25519.PrivateKey:
+		return "ed25519", nil
+	default:
+		return "", fmt.Errorf("unsupported signing key type %T", key)
+	}
+}
+
+// sign signs message with key.
+func sign(key crypto.Signer, message []byte) ([]byte, error) {
+	if _, ok := key.(ed25519.PrivateKey); ok {
+		return key.Sign(rand.Reader, message, crypto.Hash(0))
+	}
+
+	digest := sha256.Sum256(message)
+	return key.Sign(rand.Reader, digest[:], crypto.SHA256)
+}
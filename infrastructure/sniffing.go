@@ -0,0 +1,74 @@
+package infrastructure
+
+import (
+	"bytes"
+	"mime"
+	"net/http"
+	"strings"
+
+	"github.com/fourth-ally/gofetch/domain/errors"
+)
+
+// SetContentSniffing enables a guard that checks the leading bytes of every
+// successful response body against its declared Content-Type and fails
+// with *errors.ContentMismatchError when they disagree, e.g. an HTML proxy
+// error page declared as JSON.
+func (c *Client) SetContentSniffing(enabled bool) *Client {
+	c.contentSniffing = enabled
+	return c
+}
+
+// checkContentType verifies that body plausibly matches contentType.
+func checkContentType(contentType string, body []byte) error {
+	if contentType == "" || len(body) == 0 {
+		return nil
+	}
+
+	declared, _, err := mime.ParseMediaType(contentType)
+	if err != nil {
+		return nil
+	}
+
+	detected, _, _ := mime.ParseMediaType(http.DetectContentType(body))
+
+	if isJSONMediaType(declared) {
+		if looksLikeJSON(body) {
+			return nil
+		}
+	} else if family, _, _ := strings.Cut(declared, "/"); family == "image" || family == "audio" || family == "video" {
+		// Binary types are only checked when the sniffer recognises a different family
+		if detected == "application/octet-stream" || strings.HasPrefix(detected, family+"/") {
+			return nil
+		}
+	} else {
+		return nil
+	}
+
+	return &errors.ContentMismatchError{
+		Declared: declared,
+		Detected: detected,
+		Body:     body,
+	}
+}
+
+// isJSONMediaType reports whether mediaType is JSON or a +json suffix type.
+func isJSONMediaType(mediaType string) bool {
+	return mediaType == "application/json" || strings.HasSuffix(mediaType, "+json")
+}
+
+// looksLikeJSON reports whether body starts like a JSON value.
+func looksLikeJSON(body []byte) bool {
+	trimmed := bytes.TrimLeft(body, " \t\r\n\ufeff")
+	if len(trimmed) == 0 {
+		return true
+	}
+
+	switch c := trimmed[0]; {
+	case c == '{', c == '[', c == '"', c == '-', c >= '0' && c <= '9':
+		return true
+	default:
+		return bytes.HasPrefix(trimmed, []byte("true")) ||
+			bytes.HasPrefix(trimmed, []byte("false")) ||
+			bytes.HasPrefix(trimmed, []byte("null"))
+	}
+}
@@ -0,0 +1,191 @@
+package infrastructure
+
+import (
+	"bufio"
+	"context"
+	stderrors "errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/fourth-ally/gofetch/domain/errors"
+	"github.com/fourth-ally/gofetch/domain/models"
+)
+
+// Subscribe opens a server-sent events stream at path and calls onEvent
+// with each event until ctx is cancelled or onEvent returns an error.
+// Unlike a browser EventSource it sends the client's headers, so it works
+// with authenticated streams. When the stream ends or the connection fails
+// it waits options.ReconnectDelay (or the server's retry field) and
+// reconnects with the Last-Event-ID header set to the last event id seen.
+// Only clean stream ends, transport errors and 429 or 5xx responses are
+// reconnected; other errors are returned, with rejected responses as
+// *errors.HTTPError. A 204 No Content response ends the subscription.
+func (c *Client) Subscribe(ctx context.Context, path string, params map[string]interface{}, options *models.SSEOptions, onEvent func(models.SSEEvent) error) error {
+	if options == nil {
+		options = models.NewSSEOptions()
+	}
+
+	lastEventID := options.LastEventID
+	delay := options.ReconnectDelay
+	reconnects := 0
+
+	for {
+		state := sseState{lastEventID: lastEventID, retry: delay}
+		done, err := c.subscribeOnce(ctx, path, params, &state, onEvent)
+		if state.handlerErr != nil {
+			return state.handlerErr
+		}
+		if ctx.Err() != nil {
+			return ctx.Err()
+		}
+		if done || !sseReconnectable(err) {
+			return err
+		}
+
+		lastEventID, delay = state.lastEventID, state.retry
+		if state.received {
+			reconnects = 0
+		}
+		reconnects++
+		if options.MaxReconnects < 0 || (options.MaxReconnects > 0 && reconnects > options.MaxReconnects) {
+			return err
+		}
+
+		if err != nil && options.OnError != nil {
+			options.OnError(err)
+		}
+
+		timer := time.NewTimer(delay)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return ctx.Err()
+		case <-timer.C:
+		}
+	}
+}
+
+// sseState is the state of one SSE connection carried across reconnects.
+type sseState struct {
+	lastEventID string
+	retry       time.Duration
+	received    bool
+	handlerErr  error
+}
+
+// subscribeOnce reads one SSE connection until it ends. done reports that
+// the server asked the client not to reconnect.
+func (c *Client) subscribeOnce(ctx context.Context, path string, params map[string]interface{}, state *sseState, onEvent func(models.SSEEvent) error) (done bool, err error) {
+	headers := map[string]string{
+		"Accept":        "text/event-stream",
+		"Cache-Control": "no-cache",
+	}
+	if state.lastEventID != "" {
+		headers["Last-Event-ID"] = state.lastEventID
+	}
+
+	resp, err := c.openStream(ctx, http.MethodGet, path, params, nil, &models.Config{Headers: headers})
+	if err != nil {
+		return false, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode == http.StatusNoContent {
+		return true, nil
+	}
+
+	err = parseSSE(resp.Body, state, func(event models.SSEEvent) error {
+		state.received = true
+		if err := onEvent(event); err != nil {
+			state.handlerErr = err
+			return err
+		}
+		return nil
+	})
+	if err != nil && state.handlerErr == nil {
+		err = fmt.Errorf("failed to read event stream: %w", errors.ClassifyTransportError(err))
+	}
+	return false, err
+}
+
+// parseSSE reads events from r as specified for text/event-stream and
+// calls dispatch with each complete event.
+func parseSSE(r io.Reader, state *sseState, dispatch func(models.SSEEvent) error) error {
+	reader := bufio.NewReader(r)
+	var data strings.Builder
+	var eventType string
+	var retry time.Duration
+	hasData := false
+
+	for {
+		line, err := reader.ReadString('\n')
+		if err != nil && (err != io.EOF || line == "") {
+			if err == io.EOF {
+				return nil
+			}
+			return err
+		}
+		line = strings.TrimRight(line, "\r\n")
+
+		if line == "" {
+			if hasData {
+				event := models.SSEEvent{ID: state.lastEventID, Event: eventType, Data: data.String(), Retry: retry}
+				if err := dispatch(event); err != nil {
+					return err
+				}
+			}
+			data.Reset()
+			eventType, retry, hasData = "", 0, false
+			continue
+		}
+		if strings.HasPrefix(line, ":") {
+			continue
+		}
+
+		field, value, _ := strings.Cut(line, ":")
+		value = strings.TrimPrefix(value, " ")
+
+		switch field {
+		case "data":
+			if hasData {
+				data.WriteByte('\n')
+			}
+			data.WriteString(value)
+			hasData = true
+		case "event":
+			eventType = value
+		case "id":
+			if !strings.ContainsRune(value, 0) {
+				state.lastEventID = value
+			}
+		case "retry":
+			if ms, err := strconv.ParseUint(value, 10, 63); err == nil {
+				retry = time.Duration(ms) * time.Millisecond
+				state.retry = retry
+			}
+		}
+	}
+}
+
+// sseReconnectable reports whether a subscription should reconnect after
+// a connection ended with err.
+func sseReconnectable(err error) bool {
+	if err == nil {
+		return true
+	}
+
+	var transportErr *errors.TransportError
+	if stderrors.As(err, &transportErr) {
+		return true
+	}
+
+	var httpErr *errors.HTTPError
+	if stderrors.As(err, &httpErr) {
+		return httpErr.StatusCode == http.StatusTooManyRequests || httpErr.StatusCode >= 500
+	}
+	return false
+}
@@ -0,0 +1,102 @@
+package infrastructure
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/fourth-ally/gofetch/domain/models"
+)
+
+// staleGuard tracks host activity to spot pooled connections that have
+// been idle long enough for the server to have closed them.
+type staleGuard struct {
+	options *models.StaleConnectionOptions
+
+	mu       sync.Mutex
+	lastUsed map[string]time.Time
+}
+
+// SetStaleConnectionCheck protects non-idempotent requests (POST, PATCH
+// and others without an Idempotency-Key header) from failing on a pooled
+// connection the server closed while it was idle. The transport safely
+// retries idempotent requests on such connections but not these, since
+// the server may already have acted on them. Before sending one to a host
+// that has been idle for options.IdleThreshold, the client either pings
+// the host with an OPTIONS request, which flushes dead connections from
+// the pool, or with options.Redial closes idle connections so a new one
+// is dialed. Pass nil to turn the check off.
+func (c *Client) SetStaleConnectionCheck(options *models.StaleConnectionOptions) *Client {
+	if options == nil {
+		c.staleGuard = nil
+		return c
+	}
+
+	// Own the transport so redialing doesn't touch other clients' pools
+	c.transport()
+	c.staleGuard = &staleGuard{
+		options:  options,
+		lastUsed: make(map[string]time.Time),
+	}
+	return c
+}
+
+// prepare checks the connections to the host of req before it is sent.
+// It does nothing on a nil guard.
+func (g *staleGuard) prepare(ctx context.Context, c *Client, req *http.Request) {
+	if g == nil {
+		return
+	}
+
+	host := req.URL.Host
+	g.mu.Lock()
+	last, seen := g.lastUsed[host]
+	g.lastUsed[host] = time.Now()
+	g.mu.Unlock()
+
+	// Without earlier requests there are no pooled connections to the host
+	if !seen || time.Since(last) < g.options.IdleThreshold || !needsFreshConnection(req) {
+		return
+	}
+
+	httpClient := c.state.Load().httpClient
+	if g.options.Redial {
+		httpClient.CloseIdleConnections()
+		return
+	}
+
+	// Any response proves the connection alive; errors are left for the
+	// request itself to report
+	ping, err := http.NewRequestWithContext(ctx, http.MethodOptions, req.URL.String(), nil)
+	if err != nil {
+		return
+	}
+	pinger := *httpClient
+	pinger.CheckRedirect = func(*http.Request, []*http.Request) error { return http.ErrUseLastResponse }
+	if resp, err := pinger.Do(ping); err == nil {
+		io.Copy(io.Discard, io.LimitReader(resp.Body, 4<<10))
+		resp.Body.Close()
+	}
+}
+
+// done records activity on the host of req after its response arrived.
+func (g *staleGuard) done(req *http.Request) {
+	if g == nil {
+		return
+	}
+
+	g.mu.Lock()
+	g.lastUsed[req.URL.Host] = time.Now()
+	g.mu.Unlock()
+}
+
+// needsFreshConnection reports whether req can't be retried by the
+// transport after failing on a dead connection.
+func needsFreshConnection(req *http.Request) bool {
+	if isIdempotent(req.Method) {
+		return false
+	}
+	return req.Header.Get("Idempotency-Key") == "" && req.Header.Get("X-Idempotency-Key") == ""
+}
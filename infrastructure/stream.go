@@ -0,0 +1,219 @@
+package infrastructure
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptrace"
+	"time"
+
+	"github.com/fourth-ally/gofetch/domain/errors"
+	"github.com/fourth-ally/gofetch/domain/models"
+)
+
+// StreamNDJSON issues a GET request and calls onLine with each non-empty
+// line of the newline-delimited response body as it arrives, until the
+// server ends the stream, ctx is cancelled or onLine returns an error.
+// The client timeout does not apply since streams are long-lived; bound
+// them with ctx instead. Responses rejected by the status validator are
+// returned as *errors.HTTPError. Retries, caching and hedging don't apply.
+func (c *Client) StreamNDJSON(ctx context.Context, path string, params map[string]interface{}, onLine func(line []byte) error) error {
+	return c.StreamNDJSONRequest(ctx, http.MethodGet, path, params, nil, onLine)
+}
+
+// StreamNDJSONRequest is like StreamNDJSON for any method and request
+// body, e.g. for progress streams of long-running POST operations.
+func (c *Client) StreamNDJSONRequest(ctx context.Context, method, path string, params map[string]interface{}, body interface{}, onLine func(line []byte) error) error {
+	resp, err := c.openStream(ctx, method, path, params, body, nil)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	reader := bufio.NewReader(resp.Body)
+	for {
+		line, err := reader.ReadBytes('\n')
+		if line = bytes.TrimSpace(line); len(line) > 0 {
+			if err := onLine(line); err != nil {
+				return err
+			}
+		}
+
+		if err == io.EOF {
+			return nil
+		}
+		if err != nil {
+			if ctx.Err() != nil {
+				return ctx.Err()
+			}
+			return fmt.Errorf("failed to read response stream: %w", errors.ClassifyTransportError(err))
+		}
+	}
+}
+
+// openStream sends a request and returns the response with its body
+// unread. The caller must close the body. Like other requests, streams
+// use the endpoint pool or fail over between base URLs, respect the
+// circuit breaker and hold their bulkhead slot until the body is closed.
+// Failover happens before the stream starts and only for requests
+// without a body, which could not be sent again.
+func (c *Client) openStream(ctx context.Context, method, path string, params map[string]interface{}, body interface{}, requestConfig *models.Config) (*http.Response, error) {
+	requestConfig = withContextOverrides(ctx, requestConfig)
+	ctx = c.withState(ctx)
+
+	// Requests pinned to a specific host are sent as-is
+	if requestConfig != nil && requestConfig.BaseURL != "" {
+		return c.openStreamGuarded(ctx, method, path, params, body, requestConfig)
+	}
+
+	if c.pool != nil {
+		baseURL := c.pool.pick(c.health.isDown)
+		resp, err := c.openStreamGuarded(ctx, method, path, params, body, overrideConfig(requestConfig, &models.Config{BaseURL: baseURL}))
+		if ctx.Err() == nil {
+			c.pool.record(baseURL, !endpointFailed(nil, err))
+		}
+		return resp, err
+	}
+
+	baseURLs := c.health.available(c.stateFor(ctx).merged(requestConfig).BaseURLs)
+	if len(baseURLs) == 0 {
+		return c.openStreamGuarded(ctx, method, path, params, body, requestConfig)
+	}
+	if body != nil {
+		baseURLs = baseURLs[:1]
+	}
+
+	var resp *http.Response
+	var err error
+	for _, baseURL := range baseURLs {
+		resp, err = c.openStreamGuarded(ctx, method, path, params, body, overrideConfig(requestConfig, &models.Config{BaseURL: baseURL}))
+		if !shouldFailover(method, nil, err) || ctx.Err() != nil {
+			return resp, err
+		}
+	}
+	return resp, err
+}
+
+// openStreamGuarded opens a stream unless the circuit breaker is open for
+// its endpoint, and records the outcome with the breaker.
+func (c *Client) openStreamGuarded(ctx context.Context, method, path string, params map[string]interface{}, body interface{}, requestConfig *models.Config) (*http.Response, error) {
+	if c.circuitBreaker == nil {
+		return c.openStreamAttempt(ctx, method, path, params, body, requestConfig)
+	}
+
+	fullURL, err := c.buildURL(c.stateFor(ctx).merged(requestConfig).BaseURL, path, params)
+	if err != nil {
+		return nil, err
+	}
+	if c.circuitBreaker.IsOpen(fullURL) {
+		return nil, &errors.CircuitOpenError{Endpoint: fullURL}
+	}
+	if !c.circuitBreaker.CanAttempt(fullURL) {
+		return nil, &errors.CircuitOpenError{Endpoint: fullURL, HalfOpen: true}
+	}
+
+	resp, err := c.openStreamAttempt(ctx, method, path, params, body, requestConfig)
+	if err == nil && resp.StatusCode < 500 {
+		c.circuitBreaker.RecordSuccess(fullURL)
+	} else {
+		c.circuitBreaker.RecordFailure(fullURL)
+	}
+	return resp, err
+}
+
+// openStreamAttempt sends a single stream request. The client timeout
+// doesn't apply, but a per-request TLS server name does.
+func (c *Client) openStreamAttempt(ctx context.Context, method, path string, params map[string]interface{}, body interface{}, requestConfig *models.Config) (*http.Response, error) {
+	state := c.stateFor(ctx)
+	config := state.merged(requestConfig)
+
+	ctx = withSamplingDecision(ctx, config)
+	if config.ClientTrace != nil && sampled(ctx) {
+		ctx = httptrace.WithClientTrace(ctx, config.ClientTrace)
+	}
+
+	// Release the request's slots on failure; on success they are held
+	// until the caller closes the body
+	var cleanups cleanupStack
+	handedOff := false
+	defer func() {
+		if !handedOff {
+			cleanups.run()
+		}
+	}()
+
+	req, err := c.buildRequest(ctx, method, path, params, body, config)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := c.rateLimiter.wait(ctx, req.URL.Host); err != nil {
+		return nil, fmt.Errorf("rate limit wait cancelled: %w", err)
+	}
+
+	releaseCrawl, err := c.crawler.acquire(ctx, c, req)
+	if err != nil {
+		return nil, err
+	}
+	cleanups.push(releaseCrawl)
+
+	release, err := c.bulkhead.acquire(ctx, req.URL.Host, requestPriority(config))
+	if err != nil {
+		return nil, err
+	}
+	cleanups.push(release)
+
+	httpClient := *c.httpClientFor(state, requestConfig)
+	httpClient.Timeout = 0
+
+	c.staleGuard.prepare(ctx, c, req)
+
+	started := time.Now()
+	resp, err := c.proxies.do(req, func(req *http.Request) (*http.Response, error) {
+		return c.faults.do(req, httpClient.Do)
+	})
+	c.logRoundTrip(ctx, req, resp, started, err)
+	c.staleGuard.done(req)
+	if err != nil {
+		return nil, fmt.Errorf("request execution error: %w", errors.ClassifyTransportError(c.headerSizeError(err)))
+	}
+
+	if err := c.checkHeaderCount(resp); err != nil {
+		resp.Body.Close()
+		return nil, err
+	}
+
+	for _, interceptor := range c.responseInterceptors {
+		intercepted, err := interceptor(resp)
+		if err != nil {
+			resp.Body.Close()
+			return nil, fmt.Errorf("response interceptor error: %w", err)
+		}
+		resp = intercepted
+	}
+
+	if _, err := c.decompressBody(resp); err != nil {
+		resp.Body.Close()
+		return nil, err
+	}
+
+	if !config.StatusValidator(resp.StatusCode) {
+		defer resp.Body.Close()
+		if c.errorBody != nil {
+			return nil, c.decodeError(c.readErrorBody(resp), config)
+		}
+		body, _ := io.ReadAll(resp.Body)
+		return nil, c.decodeError(errors.NewHTTPError(resp, body, ""), config)
+	}
+
+	// Hold the crawl and bulkhead slots until the caller closes the body
+	if c.crawler != nil || c.bulkhead != nil {
+		cleanups.pushCloser(resp.Body)
+		resp.Body = &rawBody{reader: resp.Body, cleanups: cleanups}
+		handedOff = true
+	}
+	return resp, nil
+}
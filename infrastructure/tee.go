@@ -0,0 +1,24 @@
+package infrastructure
+
+import "io"
+
+// teeReader copies everything read from reader to writer. Unlike
+// io.TeeReader it keeps write errors apart from read errors, so a failing
+// sink isn't mistaken for a transport failure.
+type teeReader struct {
+	reader io.Reader
+	writer io.Writer
+	err    error
+}
+
+// Read implements io.Reader, stopping at the first write error.
+func (t *teeReader) Read(p []byte) (int, error) {
+	n, err := t.reader.Read(p)
+	if n > 0 && t.err == nil {
+		if _, writeErr := t.writer.Write(p[:n]); writeErr != nil {
+			t.err = writeErr
+			return n, writeErr
+		}
+	}
+	return n, err
+}
@@ -0,0 +1,88 @@
+package infrastructure
+
+import (
+	"context"
+	stderrors "errors"
+	"io"
+	"sync/atomic"
+	"time"
+
+	"github.com/fourth-ally/gofetch/domain/errors"
+)
+
+// ErrIdleReadTimeout is returned when a response body stalls for longer
+// than the idle read timeout.
+var ErrIdleReadTimeout = stderrors.New("gofetch: idle read timeout exceeded")
+
+// SetDialTimeout limits how long establishing a TCP connection may take.
+// Zero restores the default of 30 seconds.
+func (c *Client) SetDialTimeout(timeout time.Duration) *Client {
+	transport := c.transport()
+	if transport == nil {
+		return c
+	}
+
+	c.dialTimeout = timeout
+	transport.DialContext = c.dialContext()
+	return c
+}
+
+// SetTLSHandshakeTimeout limits how long the TLS handshake may take.
+func (c *Client) SetTLSHandshakeTimeout(timeout time.Duration) *Client {
+	if transport := c.transport(); transport != nil {
+		transport.TLSHandshakeTimeout = timeout
+	}
+	return c
+}
+
+// SetResponseHeaderTimeout limits how long to wait for the response headers
+// after the request has been written.
+func (c *Client) SetResponseHeaderTimeout(timeout time.Duration) *Client {
+	if transport := c.transport(); transport != nil {
+		transport.ResponseHeaderTimeout = timeout
+	}
+	return c
+}
+
+// SetIdleReadTimeout aborts a request when no response body bytes arrive
+// for the given duration, catching stalled streams that the overall
+// timeout would only detect much later. Zero disables it.
+func (c *Client) SetIdleReadTimeout(timeout time.Duration) *Client {
+	c.idleReadTimeout = timeout
+	return c
+}
+
+// idleTimeoutBody cancels the request when reads stall.
+type idleTimeoutBody struct {
+	body     io.ReadCloser
+	timeout  time.Duration
+	timer    *time.Timer
+	timedOut atomic.Bool
+}
+
+// newIdleTimeoutBody wraps body, calling cancel if it stays idle for timeout.
+func newIdleTimeoutBody(body io.ReadCloser, timeout time.Duration, cancel context.CancelFunc) *idleTimeoutBody {
+	b := &idleTimeoutBody{body: body, timeout: timeout}
+	b.timer = time.AfterFunc(timeout, func() {
+		b.timedOut.Store(true)
+		cancel()
+	})
+	return b
+}
+
+// Read implements io.Reader, restarting the idle timer on every read.
+func (b *idleTimeoutBody) Read(p []byte) (int, error) {
+	n, err := b.body.Read(p)
+	if b.timedOut.Load() {
+		return n, &errors.TransportError{Kind: errors.TransportTimeout, Err: ErrIdleReadTimeout}
+	}
+
+	b.timer.Reset(b.timeout)
+	return n, err
+}
+
+// Close stops the idle timer and closes the body.
+func (b *idleTimeoutBody) Close() error {
+	b.timer.Stop()
+	return b.body.Close()
+}
@@ -0,0 +1,134 @@
+package infrastructure
+
+import (
+	"context"
+	"crypto/tls"
+	"net/http/httptrace"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/fourth-ally/gofetch/domain/models"
+)
+
+// timingCollector gathers request phase timings from httptrace callbacks.
+type timingCollector struct {
+	mu sync.Mutex
+
+	start        time.Time
+	dnsStart     time.Time
+	connectStart time.Time
+	tlsStart     time.Time
+
+	timings models.Timings
+}
+
+// tlsCounters aggregates TLS handshake statistics for a client.
+type tlsCounters struct {
+	handshakes int64
+	resumed    int64
+}
+
+// newTimingCollector starts collecting timings for a request.
+func newTimingCollector() *timingCollector {
+	return &timingCollector{start: time.Now()}
+}
+
+// trace returns the httptrace hooks feeding the collector.
+func (tc *timingCollector) trace() *httptrace.ClientTrace {
+	return &httptrace.ClientTrace{
+		GotConn: func(info httptrace.GotConnInfo) {
+			tc.mu.Lock()
+			defer tc.mu.Unlock()
+			tc.timings.ConnReused = info.Reused
+		},
+		DNSStart: func(httptrace.DNSStartInfo) {
+			tc.mu.Lock()
+			defer tc.mu.Unlock()
+			tc.dnsStart = time.Now()
+		},
+		DNSDone: func(httptrace.DNSDoneInfo) {
+			tc.mu.Lock()
+			defer tc.mu.Unlock()
+			tc.timings.DNSLookup = time.Since(tc.dnsStart)
+		},
+		ConnectStart: func(string, string) {
+			tc.mu.Lock()
+			defer tc.mu.Unlock()
+			if tc.connectStart.IsZero() {
+				tc.connectStart = time.Now()
+			}
+		},
+		ConnectDone: func(string, string, error) {
+			tc.mu.Lock()
+			defer tc.mu.Unlock()
+			tc.timings.Connect = time.Since(tc.connectStart)
+		},
+		TLSHandshakeStart: func() {
+			tc.mu.Lock()
+			defer tc.mu.Unlock()
+			tc.tlsStart = time.Now()
+		},
+		TLSHandshakeDone: func(state tls.ConnectionState, err error) {
+			tc.mu.Lock()
+			defer tc.mu.Unlock()
+			tc.timings.TLSHandshake = time.Since(tc.tlsStart)
+			if err == nil {
+				tc.timings.TLSResumed = state.DidResume
+				tc.timings.TLSVersion = state.Version
+			}
+		},
+		GotFirstResponseByte: func() {
+			tc.mu.Lock()
+			defer tc.mu.Unlock()
+			tc.timings.TimeToFirstByte = time.Since(tc.start)
+		},
+	}
+}
+
+// timingCollectorKey carries the request's collector to the dialer.
+type timingCollectorKey struct{}
+
+// withTimingCollector makes tc available to the dialer through ctx.
+func withTimingCollector(ctx context.Context, tc *timingCollector) context.Context {
+	return context.WithValue(ctx, timingCollectorKey{}, tc)
+}
+
+// recordResolver notes which resolver resolved the request's host.
+func recordResolver(ctx context.Context, name string) {
+	if tc, ok := ctx.Value(timingCollectorKey{}).(*timingCollector); ok {
+		tc.mu.Lock()
+		defer tc.mu.Unlock()
+		tc.timings.Resolver = name
+	}
+}
+
+// finish stops the clock and returns the collected timings.
+func (tc *timingCollector) finish() *models.Timings {
+	tc.mu.Lock()
+	defer tc.mu.Unlock()
+
+	timings := tc.timings
+	timings.Total = time.Since(tc.start)
+	return &timings
+}
+
+// record adds a request's TLS handshake to the counters.
+func (t *tlsCounters) record(timings *models.Timings) {
+	if timings.TLSVersion == 0 {
+		return
+	}
+
+	atomic.AddInt64(&t.handshakes, 1)
+	if timings.TLSResumed {
+		atomic.AddInt64(&t.resumed, 1)
+	}
+}
+
+// TLSStats returns TLS handshake counters for requests made by the client.
+func (c *Client) TLSStats() models.TLSStats {
+	return models.TLSStats{
+		Handshakes: atomic.LoadInt64(&c.tlsCounters.handshakes),
+		Resumed:    atomic.LoadInt64(&c.tlsCounters.resumed),
+	}
+}
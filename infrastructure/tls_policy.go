@@ -0,0 +1,64 @@
+package infrastructure
+
+import (
+	"crypto/tls"
+	"fmt"
+
+	"github.com/fourth-ally/gofetch/domain/errors"
+	"github.com/fourth-ally/gofetch/domain/models"
+)
+
+// SetTLSPolicy restricts the TLS versions, cipher suites and curves the
+// client negotiates, e.g. SetTLSPolicy(models.TLSPolicyModern()). It
+// replaces all four settings, so a nil policy restores Go's defaults. It
+// has no effect with a custom RoundTripper.
+func (c *Client) SetTLSPolicy(policy *models.TLSPolicy) *Client {
+	transport := c.transport()
+	if transport == nil {
+		return c
+	}
+
+	applyTLSPolicy(tlsClientConfig(transport), policy)
+	return c
+}
+
+// applyTLSPolicy copies policy into config.
+func applyTLSPolicy(config *tls.Config, policy *models.TLSPolicy) {
+	if policy == nil {
+		policy = &models.TLSPolicy{}
+	}
+
+	config.MinVersion = policy.MinVersion
+	config.MaxVersion = policy.MaxVersion
+	config.CipherSuites = append([]uint16(nil), policy.CipherSuites...)
+	config.CurvePreferences = append([]tls.CurveID(nil), policy.CurvePreferences...)
+}
+
+// validateTLS reports insecure cipher suites and a minimum version above
+// the maximum in the transport's TLS config.
+func validateTLS(config *tls.Config) []error {
+	if config == nil {
+		return nil
+	}
+
+	var problems []error
+	for _, insecure := range tls.InsecureCipherSuites() {
+		for _, id := range config.CipherSuites {
+			if id == insecure.ID {
+				problems = append(problems, &errors.ConfigError{
+					Field:   "TLSPolicy.CipherSuites",
+					Message: fmt.Sprintf("%s is insecure", insecure.Name),
+				})
+			}
+		}
+	}
+
+	if config.MaxVersion != 0 && config.MinVersion > config.MaxVersion {
+		problems = append(problems, &errors.ConfigError{
+			Field:   "TLSPolicy.MinVersion",
+			Message: fmt.Sprintf("%s exceeds MaxVersion %s", tls.VersionName(config.MinVersion), tls.VersionName(config.MaxVersion)),
+		})
+	}
+
+	return problems
+}
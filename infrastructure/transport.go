@@ -0,0 +1,81 @@
+package infrastructure
+
+import (
+	"crypto/tls"
+	"net/http"
+
+	"github.com/fourth-ally/gofetch/domain/contracts"
+)
+
+// SetTransport replaces the underlying HTTP transport. An *http.Transport is
+// cloned so that later transport settings don't affect the caller's copy.
+func (c *Client) SetTransport(transport http.RoundTripper) *Client {
+	if t, ok := transport.(*http.Transport); ok {
+		transport = t.Clone()
+	}
+	c.state.Load().httpClient.Transport = transport
+	return c
+}
+
+// SetTLSSessionCache enables TLS session resumption with an LRU session
+// cache holding up to size sessions (0 uses the default capacity).
+// A negative size disables session resumption.
+func (c *Client) SetTLSSessionCache(size int) *Client {
+	transport := c.transport()
+	if transport == nil {
+		return c
+	}
+
+	tlsConfig := tlsClientConfig(transport)
+	if size < 0 {
+		tlsConfig.ClientSessionCache = nil
+	} else {
+		tlsConfig.ClientSessionCache = tls.NewLRUClientSessionCache(size)
+	}
+	return c
+}
+
+// transport returns the client's *http.Transport, creating one from the
+// default transport on first use. It returns nil when a custom
+// RoundTripper is installed, in which case transport settings don't apply.
+func (c *Client) transport() *http.Transport {
+	switch t := c.state.Load().httpClient.Transport.(type) {
+	case nil:
+		transport := http.DefaultTransport.(*http.Transport).Clone()
+		c.state.Load().httpClient.Transport = transport
+		return transport
+	case *http.Transport:
+		return t
+	default:
+		return nil
+	}
+}
+
+// tlsClientConfig returns the transport's TLS config, creating it if needed.
+func tlsClientConfig(transport *http.Transport) *tls.Config {
+	if transport.TLSClientConfig == nil {
+		transport.TLSClientConfig = &tls.Config{}
+	}
+	return transport.TLSClientConfig
+}
+
+// cloneTransport copies a round tripper for a derived client.
+func cloneTransport(transport http.RoundTripper) http.RoundTripper {
+	if t, ok := transport.(*http.Transport); ok {
+		return t.Clone()
+	}
+	return transport
+}
+
+// SetCertVerifier installs an additional check run on every TLS handshake
+// after standard chain verification, e.g. for revocation checking.
+// Pass nil to remove it.
+func (c *Client) SetCertVerifier(verifier contracts.CertVerifier) *Client {
+	transport := c.transport()
+	if transport == nil {
+		return c
+	}
+
+	tlsClientConfig(transport).VerifyConnection = verifier
+	return c
+}
@@ -0,0 +1,57 @@
+package infrastructure
+
+import (
+	"context"
+	"net"
+	"time"
+)
+
+// SetUnixSocket sends every request over the Unix domain socket at path
+// instead of TCP, as needed for local daemons such as the Docker Engine.
+// The host of the base URL is still sent in the Host header but not used
+// for dialing, so any placeholder like "http://localhost" works. Pass an
+// empty path to dial TCP again.
+func (c *Client) SetUnixSocket(path string) *Client {
+	transport := c.transport()
+	if transport == nil {
+		return c
+	}
+
+	c.unixSocket = path
+	transport.DialContext = c.dialContext()
+	return c
+}
+
+// defaultDialTimeout matches the connect timeout of http.DefaultTransport,
+// which a custom dial function would otherwise drop.
+const defaultDialTimeout = 30 * time.Second
+
+// dialContext returns the transport dial function for the configured dial
+// timeout, Unix socket, resolvers and host mapping.
+func (c *Client) dialContext() func(ctx context.Context, network, addr string) (net.Conn, error) {
+	timeout := c.dialTimeout
+	if timeout == 0 {
+		timeout = defaultDialTimeout
+	}
+
+	dialer := &net.Dialer{
+		Timeout:   timeout,
+		KeepAlive: 30 * time.Second,
+	}
+
+	socket := c.unixSocket
+	if socket != "" {
+		return func(ctx context.Context, _, _ string) (net.Conn, error) {
+			return dialer.DialContext(ctx, "unix", socket)
+		}
+	}
+
+	dial := dialer.DialContext
+	if len(c.resolvers) > 0 {
+		dial = resolvingDial(dialer, c.resolvers)
+	}
+	if len(c.hostMapping) > 0 {
+		dial = mappedDial(dial, c.hostMapping)
+	}
+	return dial
+}
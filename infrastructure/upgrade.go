@@ -0,0 +1,39 @@
+package infrastructure
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+
+	"github.com/fourth-ally/gofetch/domain/models"
+)
+
+// Upgrade sends a request asking the server to switch the connection to
+// protocol, e.g. "tcp" for Docker attach and exec, and returns the raw
+// bidirectional connection once the server answers 101 Switching
+// Protocols. Any other status is returned as *errors.HTTPError. The caller
+// must close the connection.
+func (c *Client) Upgrade(ctx context.Context, method, path string, params map[string]interface{}, body interface{}, protocol string) (io.ReadWriteCloser, error) {
+	requestConfig := &models.Config{
+		Headers: map[string]string{
+			"Connection": "Upgrade",
+			"Upgrade":    protocol,
+		},
+		StatusValidator: func(statusCode int) bool {
+			return statusCode == http.StatusSwitchingProtocols
+		},
+	}
+
+	resp, err := c.openStream(ctx, method, path, params, body, requestConfig)
+	if err != nil {
+		return nil, err
+	}
+
+	conn, ok := resp.Body.(io.ReadWriteCloser)
+	if !ok {
+		resp.Body.Close()
+		return nil, fmt.Errorf("connection upgrade to %s failed: response body is not writable", protocol)
+	}
+	return conn, nil
+}
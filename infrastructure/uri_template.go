@@ -0,0 +1,292 @@
+package infrastructure
+
+import (
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+	"unicode/utf8"
+)
+
+// templateOperator describes how an RFC 6570 expression operator expands
+// (RFC 6570 appendix A).
+type templateOperator struct {
+	first    string
+	sep      string
+	named    bool
+	ifEmpty  string
+	reserved bool
+}
+
+var templateOperators = map[byte]templateOperator{
+	'+': {first: "", sep: ",", reserved: true},
+	'#': {first: "#", sep: ",", reserved: true},
+	'.': {first: ".", sep: "."},
+	'/': {first: "/", sep: "/"},
+	';': {first: ";", sep: ";", named: true},
+	'?': {first: "?", sep: "&", named: true, ifEmpty: "="},
+	'&': {first: "&", sep: "&", named: true, ifEmpty: "="},
+}
+
+// expandURITemplate expands the RFC 6570 expressions in template, such as
+// /users/{id}/posts{?page,limit}, with values from params. It returns the
+// names of the variables the template references, so the caller can leave
+// them out of the query string. Undefined variables expand to nothing, and
+// time.Time values are formatted with timeLayout.
+func expandURITemplate(template string, params map[string]interface{}, timeLayout string) (string, map[string]bool, error) {
+	var out strings.Builder
+	used := make(map[string]bool)
+
+	for {
+		start := strings.IndexByte(template, '{')
+		if start < 0 {
+			out.WriteString(template)
+			return out.String(), used, nil
+		}
+		end := strings.IndexByte(template[start:], '}')
+		if end < 0 {
+			return "", nil, fmt.Errorf("unclosed expression in URI template %q", template)
+		}
+
+		out.WriteString(template[:start])
+		if err := expandExpression(&out, template[start+1:start+end], params, timeLayout, used); err != nil {
+			return "", nil, err
+		}
+		template = template[start+end+1:]
+	}
+}
+
+// expandExpression writes the expansion of a single {expression}.
+func expandExpression(out *strings.Builder, expression string, params map[string]interface{}, timeLayout string, used map[string]bool) error {
+	if expression == "" {
+		return fmt.Errorf("empty expression in URI template")
+	}
+
+	op := templateOperator{sep: ","}
+	if operator, ok := templateOperators[expression[0]]; ok {
+		op = operator
+		expression = expression[1:]
+	}
+
+	first := true
+	for _, spec := range strings.Split(expression, ",") {
+		name, explode, prefix, err := parseVarSpec(spec)
+		if err != nil {
+			return err
+		}
+		used[name] = true
+
+		value, ok := templateValue(params[name], timeLayout)
+		if !ok {
+			continue
+		}
+		if op.pathSegments() && hasDotSegment(value, prefix) {
+			return fmt.Errorf("URI template variable %q is a dot segment", name)
+		}
+
+		if first {
+			out.WriteString(op.first)
+			first = false
+		} else {
+			out.WriteString(op.sep)
+		}
+		expandValue(out, op, name, value, explode, prefix)
+	}
+	return nil
+}
+
+// pathSegments reports whether the operator expands values unescaped into
+// path segments, where "." and ".." would move the request to another path.
+func (op templateOperator) pathSegments() bool {
+	return !op.reserved && !op.named && op.first != "."
+}
+
+// hasDotSegment reports whether value, or one of its elements, is "." or
+// ".." once the prefix modifier is applied.
+func hasDotSegment(value interface{}, prefix int) bool {
+	isDot := func(s string) bool { return s == "." || s == ".." }
+
+	switch value := value.(type) {
+	case string:
+		if prefix > 0 && utf8.RuneCountInString(value) > prefix {
+			value = string([]rune(value)[:prefix])
+		}
+		return isDot(value)
+	case []string:
+		for _, item := range value {
+			if isDot(item) {
+				return true
+			}
+		}
+	case [][2]string:
+		for _, pair := range value {
+			if isDot(pair[0]) || isDot(pair[1]) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// parseVarSpec splits a varspec such as "id", "list*" or "name:3".
+func parseVarSpec(spec string) (name string, explode bool, prefix int, err error) {
+	name = spec
+	if strings.HasSuffix(name, "*") {
+		name, explode = strings.TrimSuffix(name, "*"), true
+	} else if i := strings.IndexByte(name, ':'); i >= 0 {
+		if _, err := fmt.Sscanf(name[i+1:], "%d", &prefix); err != nil || prefix <= 0 || prefix >= 10000 {
+			return "", false, 0, fmt.Errorf("invalid prefix modifier in URI template variable %q", spec)
+		}
+		name = name[:i]
+	}
+
+	if name == "" {
+		return "", false, 0, fmt.Errorf("empty variable name in URI template")
+	}
+	return name, explode, prefix, nil
+}
+
+// templateValue converts a param into a string, a list ([]string) or an
+// associative array ([][2]string, sorted by key). It reports false for
+// undefined values: nil, empty lists and empty maps.
+func templateValue(value interface{}, timeLayout string) (interface{}, bool) {
+	if value == nil {
+		return nil, false
+	}
+	if formatted, ok := formatTypedParam(value, timeLayout); ok {
+		return formatted, true
+	}
+
+	v := reflect.ValueOf(value)
+	switch v.Kind() {
+	case reflect.Slice, reflect.Array:
+		if raw, ok := value.([]byte); ok {
+			return string(raw), true
+		}
+		list := make([]string, v.Len())
+		for i := range list {
+			list[i] = formatParam(v.Index(i).Interface(), timeLayout)
+		}
+		return list, len(list) > 0
+	case reflect.Map:
+		pairs := make([][2]string, 0, v.Len())
+		for _, key := range v.MapKeys() {
+			pairs = append(pairs, [2]string{formatParam(key.Interface(), timeLayout), formatParam(v.MapIndex(key).Interface(), timeLayout)})
+		}
+		sort.Slice(pairs, func(i, j int) bool { return pairs[i][0] < pairs[j][0] })
+		return pairs, len(pairs) > 0
+	case reflect.Pointer:
+		if v.IsNil() {
+			return nil, false
+		}
+	}
+
+	return formatParam(value, timeLayout), true
+}
+
+// expandValue writes a defined variable following RFC 6570 section 3.2.1.
+func expandValue(out *strings.Builder, op templateOperator, name string, value interface{}, explode bool, prefix int) {
+	named := func(key, value string) {
+		out.WriteString(encodeTemplate(key, true))
+		if value == "" {
+			out.WriteString(op.ifEmpty)
+			return
+		}
+		out.WriteString("=")
+		out.WriteString(encodeTemplate(value, op.reserved))
+	}
+
+	switch value := value.(type) {
+	case string:
+		if prefix > 0 && utf8.RuneCountInString(value) > prefix {
+			value = string([]rune(value)[:prefix])
+		}
+		if op.named {
+			named(name, value)
+		} else {
+			out.WriteString(encodeTemplate(value, op.reserved))
+		}
+
+	case []string:
+		if explode {
+			for i, item := range value {
+				if i > 0 {
+					out.WriteString(op.sep)
+				}
+				if op.named {
+					named(name, item)
+				} else {
+					out.WriteString(encodeTemplate(item, op.reserved))
+				}
+			}
+			return
+		}
+
+		encoded := make([]string, len(value))
+		for i, item := range value {
+			encoded[i] = encodeTemplate(item, op.reserved)
+		}
+		if op.named {
+			out.WriteString(name + "=")
+		}
+		out.WriteString(strings.Join(encoded, ","))
+
+	case [][2]string:
+		if explode {
+			for i, pair := range value {
+				if i > 0 {
+					out.WriteString(op.sep)
+				}
+				if op.named {
+					named(pair[0], pair[1])
+				} else {
+					out.WriteString(encodeTemplate(pair[0], op.reserved) + "=" + encodeTemplate(pair[1], op.reserved))
+				}
+			}
+			return
+		}
+
+		encoded := make([]string, 0, 2*len(value))
+		for _, pair := range value {
+			encoded = append(encoded, encodeTemplate(pair[0], op.reserved), encodeTemplate(pair[1], op.reserved))
+		}
+		if op.named {
+			out.WriteString(name + "=")
+		}
+		out.WriteString(strings.Join(encoded, ","))
+	}
+}
+
+// encodeTemplate percent-encodes s, keeping unreserved characters and, if
+// reserved is set, reserved characters and existing percent-encodings.
+func encodeTemplate(s string, reserved bool) string {
+	const hex = "0123456789ABCDEF"
+
+	var out strings.Builder
+	for i := 0; i < len(s); i++ {
+		c := s[i]
+		switch {
+		case isUnreserved(c):
+			out.WriteByte(c)
+		case reserved && strings.IndexByte(":/?#[]@!$&'()*+,;=", c) >= 0:
+			out.WriteByte(c)
+		case reserved && c == '%' && i+2 < len(s) && isHex(s[i+1]) && isHex(s[i+2]):
+			out.WriteString(s[i : i+3])
+			i += 2
+		default:
+			out.WriteByte('%')
+			out.WriteByte(hex[c>>4])
+			out.WriteByte(hex[c&0x0f])
+		}
+	}
+	return out.String()
+}
+
+func isUnreserved(c byte) bool {
+	return 'a' <= c && c <= 'z' || 'A' <= c && c <= 'Z' || '0' <= c && c <= '9' ||
+		c == '-' || c == '.' || c == '_' || c == '~'
+}
+
+func isHex(c byte) bool {
+	return '0' <= c && c <= '9' || 'a' <= c && c <= 'f' || 'A' <= c && c <= 'F'
+}
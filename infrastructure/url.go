@@ -0,0 +1,128 @@
+package infrastructure
+
+import (
+	"fmt"
+	"net/url"
+	"sort"
+	"strings"
+
+	"github.com/fourth-ally/gofetch/domain/errors"
+	"github.com/fourth-ally/gofetch/domain/models"
+)
+
+// SetLegacyURLBuilding restores the URL building of earlier releases: path
+// parameters are substituted unescaped and the base URL and path are
+// concatenated without validation. Enable it only for servers that rely on
+// parameters containing slashes or other reserved characters.
+func (c *Client) SetLegacyURLBuilding(enabled bool) *Client {
+	c.legacyURLs = enabled
+	return c
+}
+
+// SetURLJoinPolicy selects how request paths are combined with the base
+// URL. The default, models.JoinAppend, keeps the base URL path.
+func (c *Client) SetURLJoinPolicy(policy models.URLJoinPolicy) *Client {
+	c.urlJoinPolicy = policy
+	return c
+}
+
+// sortedParamKeys returns the keys of params, longest first.
+func sortedParamKeys(params map[string]interface{}) []string {
+	keys := make([]string, 0, len(params))
+	for key := range params {
+		keys = append(keys, key)
+	}
+	sort.Slice(keys, func(i, j int) bool {
+		if len(keys[i]) != len(keys[j]) {
+			return len(keys[i]) > len(keys[j])
+		}
+		return keys[i] < keys[j]
+	})
+	return keys
+}
+
+// substitutePathParam replaces the :key placeholders in path with the
+// formatted value, path-escaped, or query-escaped where the placeholder is
+// in the query. Values that are dot segments are rejected, since they
+// would move the request to another path.
+func substitutePathParam(path, key, formatted string) (string, error) {
+	if formatted == "." || formatted == ".." {
+		return "", &errors.URLError{URL: path, Reason: fmt.Sprintf("path parameter %q is a dot segment", key)}
+	}
+
+	pathPart, query, hasQuery := strings.Cut(path, "?")
+	pathPart = strings.ReplaceAll(pathPart, ":"+key, url.PathEscape(formatted))
+	if !hasQuery {
+		return pathPart, nil
+	}
+	return pathPart + "?" + strings.ReplaceAll(query, ":"+key, url.QueryEscape(formatted)), nil
+}
+
+// joinURL combines baseURL with path, which may carry a query, following
+// policy. Without a base URL the path is used as is and must be a valid URL.
+func joinURL(baseURL, path string, policy models.URLJoinPolicy) (string, error) {
+	if baseURL == "" {
+		if _, err := url.Parse(path); err != nil {
+			return "", &errors.URLError{URL: path, Reason: "malformed URL", Err: err}
+		}
+		return path, nil
+	}
+
+	base, err := url.Parse(baseURL)
+	if err != nil {
+		return "", &errors.URLError{URL: baseURL, Reason: "malformed base URL", Err: err}
+	}
+	if path == "" {
+		return base.String(), nil
+	}
+
+	pathPart, query, hasQuery := strings.Cut(path, "?")
+	if _, err := url.PathUnescape(pathPart); err != nil {
+		return "", &errors.URLError{URL: path, Reason: "malformed path", Err: err}
+	}
+
+	if policy == models.JoinResolve {
+		ref, err := url.Parse(path)
+		if err != nil {
+			return "", &errors.URLError{URL: path, Reason: "malformed path", Err: err}
+		}
+		return base.ResolveReference(ref).String(), nil
+	}
+
+	joined := base
+	if pathPart != "" {
+		joined = base.JoinPath(pathPart)
+	}
+	if hasQuery {
+		if joined.RawQuery != "" {
+			query = joined.RawQuery + "&" + query
+		}
+		joined.RawQuery = query
+	}
+	return joined.String(), nil
+}
+
+// buildLegacyURL builds a URL the way releases before escaping did.
+func (c *Client) buildLegacyURL(baseURL, path string, params map[string]interface{}) string {
+	queryParams := url.Values{}
+	for key, value := range params {
+		placeholder := ":" + key
+		if strings.Contains(path, placeholder) {
+			path = strings.ReplaceAll(path, placeholder, formatParam(value, c.timeLayout))
+		} else {
+			addQueryParam(queryParams, key, value, c.queryArrayFormat, c.timeLayout)
+		}
+	}
+
+	fullURL := baseURL
+	if fullURL != "" && path != "" {
+		fullURL = strings.TrimRight(fullURL, "/") + "/" + strings.TrimLeft(path, "/")
+	} else if path != "" {
+		fullURL = path
+	}
+
+	if len(queryParams) > 0 {
+		fullURL += "?" + queryParams.Encode()
+	}
+	return fullURL
+}
@@ -0,0 +1,122 @@
+package infrastructure
+
+import (
+	"context"
+	stderrors "errors"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/fourth-ally/gofetch/domain/errors"
+)
+
+// Validate checks the client's timeouts for inconsistencies that would make
+// some settings impossible to reach, such as a TLS handshake timeout longer
+// than the overall request timeout, and the TLS settings for insecure
+// cipher suites. It returns every problem found, joined,
+// as *errors.ConfigError values, or nil if the configuration is consistent.
+func (c *Client) Validate() error {
+	var problems []error
+	report := func(field, format string, args ...interface{}) {
+		problems = append(problems, &errors.ConfigError{Field: field, Message: fmt.Sprintf(format, args...)})
+	}
+
+	timeout := c.state.Load().config.Timeout
+	if timeout < 0 {
+		report("Timeout", "must not be negative, got %v", timeout)
+	}
+
+	if transport := c.inspectTransport(); transport != nil && timeout > 0 {
+		phases := []struct {
+			field string
+			value time.Duration
+		}{
+			{"DialTimeout", c.dialTimeout},
+			{"TLSHandshakeTimeout", transport.TLSHandshakeTimeout},
+			{"ResponseHeaderTimeout", transport.ResponseHeaderTimeout},
+			{"ExpectContinueTimeout", transport.ExpectContinueTimeout},
+		}
+		for _, phase := range phases {
+			if phase.value > timeout {
+				report(phase.field, "%v exceeds the overall timeout of %v", phase.value, timeout)
+			}
+		}
+	}
+
+	if transport := c.inspectTransport(); transport != nil {
+		problems = append(problems, validateTLS(transport.TLSClientConfig)...)
+	}
+
+	if timeout > 0 && c.idleReadTimeout > timeout {
+		report("IdleReadTimeout", "%v exceeds the overall timeout of %v", c.idleReadTimeout, timeout)
+	}
+
+	if hedging := c.state.Load().config.HedgingOptions; hedging != nil && timeout > 0 && hedging.Delay >= timeout {
+		report("HedgingOptions.Delay", "%v is not below the timeout of %v, hedges never fire", hedging.Delay, timeout)
+	}
+
+	if retry := c.state.Load().config.RetryOptions; retry != nil {
+		if retry.MaxRetries < 0 {
+			report("RetryOptions.MaxRetries", "must not be negative, got %d", retry.MaxRetries)
+		}
+		if retry.MaxDelay > 0 && retry.InitialDelay > retry.MaxDelay {
+			report("RetryOptions.InitialDelay", "%v exceeds MaxDelay of %v", retry.InitialDelay, retry.MaxDelay)
+		}
+	}
+
+	return stderrors.Join(problems...)
+}
+
+// ValidateContext runs Validate and additionally checks that the worst-case
+// retry budget (every attempt timing out plus all backoff delays) fits into
+// the deadline of ctx.
+func (c *Client) ValidateContext(ctx context.Context) error {
+	err := c.Validate()
+
+	deadline, ok := ctx.Deadline()
+	if !ok {
+		return err
+	}
+
+	budget := c.retryBudget()
+	if remaining := time.Until(deadline); budget > remaining {
+		err = stderrors.Join(err, &errors.ConfigError{
+			Field:   "RetryOptions",
+			Message: fmt.Sprintf("worst-case retry budget of %v exceeds the context deadline in %v", budget, remaining.Round(time.Millisecond)),
+		})
+	}
+
+	return err
+}
+
+// retryBudget returns the worst-case duration of a request including all
+// retries, ignoring jitter.
+func (c *Client) retryBudget() time.Duration {
+	retry := c.state.Load().config.RetryOptions
+	if retry == nil || retry.MaxRetries <= 0 {
+		return c.state.Load().config.Timeout
+	}
+
+	options := *retry
+	options.Jitter = false
+	manager := NewRetryManager(&options)
+
+	budget := time.Duration(retry.MaxRetries+1) * c.state.Load().config.Timeout
+	for attempt := 0; attempt < retry.MaxRetries; attempt++ {
+		budget += manager.CalculateDelay(attempt)
+	}
+	return budget
+}
+
+// inspectTransport returns the *http.Transport in effect without creating
+// one, or nil for custom round trippers.
+func (c *Client) inspectTransport() *http.Transport {
+	switch t := c.state.Load().httpClient.Transport.(type) {
+	case nil:
+		return http.DefaultTransport.(*http.Transport)
+	case *http.Transport:
+		return t
+	default:
+		return nil
+	}
+}
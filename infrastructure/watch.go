@@ -0,0 +1,112 @@
+package infrastructure
+
+import (
+	"bytes"
+	"context"
+	"math/rand"
+	"net/http"
+	"time"
+
+	"github.com/fourth-ally/gofetch/domain/models"
+)
+
+// Watch polls path with conditional GETs until ctx is done or onChange
+// returns an error, calling onChange with every new representation
+// (decoded into target when non-nil). The first successful poll always
+// counts as a change.
+//
+// The interval adapts to how often the resource changes: it halves after
+// a change and grows after each 304 Not Modified, unchanged body or
+// failed poll, staying between MinInterval and MaxInterval. Every interval
+// is jittered. Watch returns ctx.Err() when ctx is done.
+func (c *Client) Watch(ctx context.Context, path string, params map[string]interface{}, target interface{}, options *models.WatchOptions, onChange func(*models.Response) error) error {
+	if options == nil {
+		options = models.NewWatchOptions()
+	}
+
+	growth := options.Growth
+	if growth < 1 {
+		growth = 1.5
+	}
+
+	minInterval := options.MinInterval
+	if minInterval <= 0 {
+		minInterval = models.NewWatchOptions().MinInterval
+	}
+
+	var validators http.Header
+	var lastBody []byte
+	interval := minInterval
+
+	for {
+		resp, err := c.executeUncached(ctx, http.MethodGet, path, params, nil, nil, c.watchConfig(validators))
+		if ctx.Err() != nil {
+			return ctx.Err()
+		}
+
+		changed := err == nil && resp.StatusCode != http.StatusNotModified &&
+			(lastBody == nil || !bytes.Equal(resp.RawBody, lastBody))
+
+		if changed {
+			validators = resp.Headers
+			lastBody = resp.RawBody
+			if lastBody == nil {
+				lastBody = []byte{}
+			}
+
+			if err := c.unmarshalTarget(resp.RawBody, resp.Headers, target); err != nil {
+				return err
+			}
+			resp.Data = target
+
+			if err := onChange(resp); err != nil {
+				return err
+			}
+			interval /= 2
+		} else {
+			interval = time.Duration(float64(interval) * growth)
+		}
+
+		interval = clampInterval(interval, minInterval, options.MaxInterval)
+
+		timer := time.NewTimer(jitterInterval(interval, options.Jitter))
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return ctx.Err()
+		case <-timer.C:
+		}
+	}
+}
+
+// watchConfig builds the request config revalidating the last representation.
+func (c *Client) watchConfig(validators http.Header) *models.Config {
+	if !hasValidators(validators) {
+		return nil
+	}
+	return conditionalConfig(nil, c.state.Load().config.StatusValidator, validators)
+}
+
+// clampInterval keeps interval within [min, max].
+func clampInterval(interval, min, max time.Duration) time.Duration {
+	if interval < min {
+		return min
+	}
+	if max > 0 && interval > max {
+		return max
+	}
+	return interval
+}
+
+// jitterInterval randomizes interval by up to ±fraction of its length.
+func jitterInterval(interval time.Duration, fraction float64) time.Duration {
+	if fraction <= 0 {
+		return interval
+	}
+	if fraction > 1 {
+		fraction = 1
+	}
+
+	spread := float64(interval) * fraction
+	return time.Duration(float64(interval) + (rand.Float64()*2-1)*spread)
+}
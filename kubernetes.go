@@ -0,0 +1,57 @@
+package gofetch
+
+import (
+	"crypto/tls"
+	"time"
+
+	"github.com/fourth-ally/gofetch/domain/models"
+	"github.com/fourth-ally/gofetch/infrastructure"
+)
+
+// PolicyKubernetes suits the Kubernetes API server: JSON media types and
+// retries that honour the Retry-After header sent with 429 responses by
+// API priority and fairness.
+var PolicyKubernetes = &models.Policy{
+	Name:                "kubernetes",
+	Timeout:             30 * time.Second,
+	DialTimeout:         10 * time.Second,
+	TLSHandshakeTimeout: 10 * time.Second,
+	MinTLSVersion:       tls.VersionTLS12,
+	RetryOptions: &models.RetryOptions{
+		MaxRetries:         3,
+		InitialDelay:       500 * time.Millisecond,
+		MaxDelay:           30 * time.Second,
+		Backoff:            models.BackoffExponential,
+		Jitter:             true,
+		JitterFraction:     0.2,
+		RetryOnStatusCodes: []int{429, 503},
+		RespectRateLimits:  true,
+	},
+	Headers: map[string]string{
+		"Accept": "application/json",
+	},
+}
+
+// NewKubernetesClient creates a client for the Kubernetes API server at
+// host (e.g. "https://10.0.0.1:6443") authenticated with a bearer token
+// (omitted when empty), with PolicyKubernetes applied. Stream changes with
+// Client.WatchKubernetes.
+//
+// Example:
+//
+//	client := gofetch.NewKubernetesClient(host, token)
+//	options := models.NewKubeWatchOptions()
+//	options.LabelSelector = infrastructure.FormatSelector(map[string]string{"app": "web"})
+//	err := client.WatchKubernetes(ctx, "/api/v1/namespaces/:namespace/pods",
+//	    map[string]interface{}{"namespace": "default"}, options,
+//	    func(event models.KubeWatchEvent) error { return handle(event) })
+func NewKubernetesClient(host, token string) *infrastructure.Client {
+	client := infrastructure.NewClient().
+		ApplyPolicy(PolicyKubernetes).
+		SetBaseURL(host)
+
+	if token != "" {
+		client.SetHeader("Authorization", "Bearer "+token)
+	}
+	return client
+}
@@ -0,0 +1,51 @@
+package gofetch
+
+import (
+	"crypto/tls"
+	"time"
+
+	"github.com/fourth-ally/gofetch/domain/models"
+)
+
+// PolicyStrictInternal suits service-to-service calls inside a trusted
+// network: short timeouts, TLS 1.3 only and quick, bounded retries so
+// failures surface fast instead of piling up.
+//
+// Example:
+//
+//	client := gofetch.NewClient().ApplyPolicy(gofetch.PolicyStrictInternal)
+var PolicyStrictInternal = &models.Policy{
+	Name:                "strict-internal",
+	Timeout:             5 * time.Second,
+	DialTimeout:         time.Second,
+	TLSHandshakeTimeout: time.Second,
+	MinTLSVersion:       tls.VersionTLS13,
+	RetryOptions: &models.RetryOptions{
+		MaxRetries:     2,
+		InitialDelay:   50 * time.Millisecond,
+		MaxDelay:       500 * time.Millisecond,
+		Backoff:        models.BackoffExponential,
+		Jitter:         true,
+		JitterFraction: 0.3,
+	},
+}
+
+// PolicyPublicAPI suits calls to third-party APIs over the internet:
+// generous timeouts, TLS 1.2 or newer and patient retries that also cover
+// rate limiting (429).
+var PolicyPublicAPI = &models.Policy{
+	Name:                "public-api",
+	Timeout:             30 * time.Second,
+	DialTimeout:         10 * time.Second,
+	TLSHandshakeTimeout: 10 * time.Second,
+	MinTLSVersion:       tls.VersionTLS12,
+	RetryOptions: &models.RetryOptions{
+		MaxRetries:         4,
+		InitialDelay:       500 * time.Millisecond,
+		MaxDelay:           30 * time.Second,
+		Backoff:            models.BackoffExponential,
+		Jitter:             true,
+		JitterFraction:     0.3,
+		RetryOnStatusCodes: []int{429},
+	},
+}
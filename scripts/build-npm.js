@@ -189,6 +189,11 @@ export async function setHeader(key, value) {
   return gf.setHeader(key, value);
 }
 
+export async function subscribe(url, onEvent, onError, options) {
+  const gf = await initGoFetch();
+  return gf.subscribe(url, onEvent, onError, options);
+}
+
 // Default export
 export default {
   newClient,
@@ -199,7 +204,8 @@ export default {
   delete: del,
   setBaseURL,
   setTimeout,
-  setHeader
+  setHeader,
+  subscribe
 };
 `;
 
@@ -218,6 +224,22 @@ export interface GoFetchResponse {
   rawBody: string;
 }
 
+export interface GoFetchEvent {
+  id: string;
+  event: string;
+  data: string;
+  retry: number;
+}
+
+export interface GoFetchSubscribeOptions {
+  params?: Record<string, any>;
+  lastEventId?: string;
+  reconnectDelay?: number;
+  maxReconnects?: number;
+}
+
+export type GoFetchUnsubscribe = () => void;
+
 export interface GoFetchClient {
   get(path: string, params?: Record<string, any>): Promise<GoFetchResponse>;
   post(path: string, params?: Record<string, any>, body?: any): Promise<GoFetchResponse>;
@@ -227,6 +249,7 @@ export interface GoFetchClient {
   setBaseURL(url: string): GoFetchClient;
   setTimeout(ms: number): GoFetchClient;
   setHeader(key: string, value: string): GoFetchClient;
+  subscribe(path: string, onEvent: (event: GoFetchEvent) => void, onError?: (message: string, reconnecting: boolean) => void, options?: GoFetchSubscribeOptions): GoFetchUnsubscribe;
   newInstance(): GoFetchClient;
 }
 
@@ -239,6 +262,7 @@ export function del(url: string, params?: Record<string, any>): Promise<GoFetchR
 export function setBaseURL(url: string): Promise<void>;
 export function setTimeout(ms: number): Promise<void>;
 export function setHeader(key: string, value: string): Promise<void>;
+export function subscribe(url: string, onEvent: (event: GoFetchEvent) => void, onError?: (message: string, reconnecting: boolean) => void, options?: GoFetchSubscribeOptions): Promise<GoFetchUnsubscribe>;
 
 declare const gofetch: {
   newClient: typeof newClient;
@@ -250,6 +274,7 @@ declare const gofetch: {
   setBaseURL: typeof setBaseURL;
   setTimeout: typeof setTimeout;
   setHeader: typeof setHeader;
+  subscribe: typeof subscribe;
 };
 
 export default gofetch;
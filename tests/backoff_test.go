@@ -0,0 +1,81 @@
+package tests
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/fourth-ally/gofetch/domain/contracts"
+	"github.com/fourth-ally/gofetch/domain/models"
+	"github.com/fourth-ally/gofetch/infrastructure"
+)
+
+func TestBuiltinBackoffs(t *testing.T) {
+	constant := infrastructure.NewConstantBackoff(50 * time.Millisecond)
+	if got := constant.Delay(5, time.Second); got != 50*time.Millisecond {
+		t.Errorf("Expected constant delay, got %v", got)
+	}
+
+	exponential := infrastructure.NewExponentialBackoff(10*time.Millisecond, 50*time.Millisecond)
+	for attempt, want := range []time.Duration{10 * time.Millisecond, 20 * time.Millisecond, 40 * time.Millisecond, 50 * time.Millisecond} {
+		if got := exponential.Delay(attempt, 0); got != want {
+			t.Errorf("Attempt %d: expected %v, got %v", attempt, want, got)
+		}
+	}
+
+	fullJitter := infrastructure.NewFullJitterBackoff(10*time.Millisecond, time.Second)
+	for i := 0; i < 100; i++ {
+		if got := fullJitter.Delay(2, 0); got < 0 || got > 40*time.Millisecond {
+			t.Fatalf("Expected full jitter delay in [0, 40ms], got %v", got)
+		}
+	}
+
+	decorrelated := infrastructure.NewDecorrelatedJitterBackoff(10*time.Millisecond, 100*time.Millisecond)
+	previous := time.Duration(0)
+	for i := 0; i < 100; i++ {
+		got := decorrelated.Delay(i, previous)
+		if got < 10*time.Millisecond || got > 100*time.Millisecond {
+			t.Fatalf("Expected decorrelated delay in [10ms, 100ms], got %v", got)
+		}
+		previous = got
+	}
+}
+
+func TestCustomBackoffUsedForRetries(t *testing.T) {
+	attempts := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		attempts++
+		if attempts < 3 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		w.Write([]byte(`{}`))
+	}))
+	defer server.Close()
+
+	var seen []time.Duration
+	backoff := contracts.BackoffFunc(func(attempt int, previous time.Duration) time.Duration {
+		seen = append(seen, previous)
+		return time.Duration(attempt+1) * time.Millisecond
+	})
+
+	client := infrastructure.NewClient().
+		SetBaseURL(server.URL).
+		SetRetryBackoff(backoff).
+		SetRetryOptions(&models.RetryOptions{
+			MaxRetries:   3,
+			InitialDelay: time.Hour,
+			MaxDelay:     time.Hour,
+			Backoff:      models.BackoffFixed,
+		})
+
+	if _, err := client.Get(context.Background(), "/test", nil, nil); err != nil {
+		t.Fatalf("Expected retries to succeed, got %v", err)
+	}
+
+	if len(seen) != 2 || seen[0] != 0 || seen[1] != time.Millisecond {
+		t.Errorf("Expected backoff to receive previous delays [0 1ms], got %v", seen)
+	}
+}
@@ -0,0 +1,404 @@
+package tests
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/fourth-ally/gofetch"
+	"github.com/fourth-ally/gofetch/domain/models"
+	"github.com/fourth-ally/gofetch/infrastructure"
+)
+
+func TestPostFormSendsURLEncodedBody(t *testing.T) {
+	var contentType, grantType, scope string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		contentType = r.Header.Get("Content-Type")
+		r.ParseForm()
+		grantType = r.PostForm.Get("grant_type")
+		scope = r.PostForm.Get("scope")
+		w.Write([]byte(`{"access_token":"abc"}`))
+	}))
+	defer server.Close()
+
+	client := infrastructure.NewClient().SetBaseURL(server.URL)
+
+	var token struct {
+		AccessToken string `json:"access_token"`
+	}
+	form := url.Values{"grant_type": {"client_credentials"}, "scope": {"read write"}}
+	if _, err := client.PostForm(context.Background(), "/oauth/token", form, &token); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	if contentType != "application/x-www-form-urlencoded" {
+		t.Errorf("Expected form Content-Type, got %q", contentType)
+	}
+	if grantType != "client_credentials" || scope != "read write" {
+		t.Errorf("Expected form fields to be decoded, got grant_type=%q scope=%q", grantType, scope)
+	}
+	if token.AccessToken != "abc" {
+		t.Errorf("Expected JSON response to be decoded, got %+v", token)
+	}
+}
+
+func TestURLValuesBodyOnPut(t *testing.T) {
+	var contentType, name string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		contentType = r.Header.Get("Content-Type")
+		r.ParseForm()
+		name = r.PostForm.Get("name")
+	}))
+	defer server.Close()
+
+	client := infrastructure.NewClient().SetBaseURL(server.URL)
+	if _, err := client.Put(context.Background(), "/profile", nil, url.Values{"name": {"Ada"}}, nil); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	if contentType != "application/x-www-form-urlencoded" || name != "Ada" {
+		t.Errorf("Expected form-encoded PUT, got Content-Type %q and name %q", contentType, name)
+	}
+}
+
+func TestContentTypeDefaultsAndOverrides(t *testing.T) {
+	var contentType string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		contentType = r.Header.Get("Content-Type")
+	}))
+	defer server.Close()
+
+	client := infrastructure.NewClient().SetBaseURL(server.URL)
+	body := map[string]string{"name": "Ada"}
+
+	client.Post(context.Background(), "/", nil, body, nil)
+	if contentType != "application/json; charset=utf-8" {
+		t.Errorf("Expected JSON Content-Type with charset, got %q", contentType)
+	}
+
+	client.SetContentType("application/vnd.company+json")
+	client.Post(context.Background(), "/", nil, body, nil)
+	if contentType != "application/vnd.company+json; charset=utf-8" {
+		t.Errorf("Expected client vendor type to inherit charset, got %q", contentType)
+	}
+
+	client.PostWithOptions(context.Background(), "/", nil, body, nil,
+		infrastructure.WithContentType("application/vnd.company.v2+json; version=2"))
+	if contentType != "application/vnd.company.v2+json; version=2" {
+		t.Errorf("Expected per-request type to be used as is, got %q", contentType)
+	}
+}
+
+func TestRawBodiesAreSentVerbatim(t *testing.T) {
+	var contentType, received string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		contentType = r.Header.Get("Content-Type")
+		data, _ := io.ReadAll(r.Body)
+		received = string(data)
+	}))
+	defer server.Close()
+
+	client := infrastructure.NewClient().SetBaseURL(server.URL)
+
+	tests := []struct {
+		name        string
+		body        interface{}
+		contentType string
+	}{
+		{"bytes", []byte{0x00, 0xff, 'a'}, "application/octet-stream"},
+		{"string", "hello", "text/plain; charset=utf-8"},
+		{"reader", strings.NewReader("streamed"), "application/octet-stream"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := client.Post(context.Background(), "/upload", nil, tt.body, nil); err != nil {
+				t.Fatalf("Expected no error, got %v", err)
+			}
+
+			want := map[string]string{"bytes": "\x00\xffa", "string": "hello", "reader": "streamed"}[tt.name]
+			if received != want {
+				t.Errorf("Expected body %q, got %q", want, received)
+			}
+			if contentType != tt.contentType {
+				t.Errorf("Expected Content-Type %q, got %q", tt.contentType, contentType)
+			}
+		})
+	}
+
+	if _, err := client.PostWithOptions(context.Background(), "/upload", nil, []byte("<a/>"), nil,
+		infrastructure.WithContentType("application/xml")); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if contentType != "application/xml" {
+		t.Errorf("Expected explicit Content-Type, got %q", contentType)
+	}
+}
+
+func TestReaderBodyIsResentOnRetry(t *testing.T) {
+	var bodies []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		data, _ := io.ReadAll(r.Body)
+		bodies = append(bodies, string(data))
+		if len(bodies) == 1 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+		}
+	}))
+	defer server.Close()
+
+	client := infrastructure.NewClient().
+		SetBaseURL(server.URL).
+		SetRetryOptions(&models.RetryOptions{MaxRetries: 1, InitialDelay: time.Millisecond, Backoff: models.BackoffFixed})
+
+	if _, err := client.Post(context.Background(), "/", nil, bytes.NewBufferString("payload"), nil); err != nil {
+		t.Fatalf("Expected retry to succeed, got %v", err)
+	}
+	if len(bodies) != 2 || bodies[1] != "payload" {
+		t.Errorf("Expected the body to be resent, got %q", bodies)
+	}
+}
+
+func TestUnknownSizeReaderIsStreamedChunked(t *testing.T) {
+	var contentLength int64
+	var received int
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		contentLength = r.ContentLength
+		data, _ := io.ReadAll(r.Body)
+		received = len(data)
+	}))
+	defer server.Close()
+
+	const size = 4 << 20
+	pipeReader, pipeWriter := io.Pipe()
+	go func() {
+		pipeWriter.Write(bytes.Repeat([]byte("x"), size))
+		pipeWriter.Close()
+	}()
+
+	var transferred, total int64
+	client := infrastructure.NewClient().
+		SetBaseURL(server.URL).
+		SetUploadProgress(func(n, t int64) { transferred, total = n, t })
+
+	if _, err := client.Post(context.Background(), "/upload", nil, pipeReader, nil); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	if contentLength != -1 {
+		t.Errorf("Expected chunked upload, got Content-Length %d", contentLength)
+	}
+	if received != size {
+		t.Errorf("Expected %d bytes, got %d", size, received)
+	}
+	if transferred != size || total != -1 {
+		t.Errorf("Expected progress %d of unknown total, got %d of %d", size, transferred, total)
+	}
+}
+
+func TestStreamedBodyRetries(t *testing.T) {
+	var bodies []string
+	var contentLengths []int64
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		data, _ := io.ReadAll(r.Body)
+		bodies = append(bodies, string(data))
+		contentLengths = append(contentLengths, r.ContentLength)
+		if len(bodies) == 1 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+		}
+	}))
+	defer server.Close()
+
+	client := infrastructure.NewClient().
+		SetBaseURL(server.URL).
+		SetRetryOptions(&models.RetryOptions{MaxRetries: 2, InitialDelay: time.Millisecond, Backoff: models.BackoffFixed})
+
+	file, err := os.CreateTemp(t.TempDir(), "upload")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer file.Close()
+	file.WriteString("file contents")
+	file.Seek(0, io.SeekStart)
+
+	if _, err := client.Post(context.Background(), "/", nil, file, nil); err != nil {
+		t.Fatalf("Expected seekable body to be resent, got %v", err)
+	}
+	if len(bodies) != 2 || bodies[1] != "file contents" || contentLengths[1] != int64(len("file contents")) {
+		t.Errorf("Expected file to be rewound and resent with its length, got %q %v", bodies, contentLengths)
+	}
+	if _, err := file.Stat(); err != nil {
+		t.Errorf("Expected the caller's file to stay open, got %v", err)
+	}
+
+	bodies = nil
+	pipeReader, pipeWriter := io.Pipe()
+	go func() {
+		pipeWriter.Write([]byte("once"))
+		pipeWriter.Close()
+	}()
+
+	if _, err := client.Post(context.Background(), "/", nil, pipeReader, nil); err == nil {
+		t.Error("Expected the failed attempt to be returned")
+	}
+	if len(bodies) != 1 {
+		t.Errorf("Expected no retry of a body that can't be rewound, got %d attempts", len(bodies))
+	}
+}
+
+func TestFileBodyStreamsFromDisk(t *testing.T) {
+	var bodies []string
+	var contentType string
+	var contentLength int64
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		data, _ := io.ReadAll(r.Body)
+		bodies = append(bodies, string(data))
+		contentType, contentLength = r.Header.Get("Content-Type"), r.ContentLength
+		if len(bodies) == 1 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+		}
+	}))
+	defer server.Close()
+
+	path := filepath.Join(t.TempDir(), "report.json")
+	payload := `{"rows": 3}`
+	if err := os.WriteFile(path, []byte(payload), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	var transferred, total int64
+	client := gofetch.NewClient().
+		SetBaseURL(server.URL).
+		SetRetryOptions(&models.RetryOptions{MaxRetries: 1, InitialDelay: time.Millisecond, Backoff: models.BackoffFixed}).
+		SetUploadProgress(func(n, t int64) { transferred, total = n, t })
+
+	if _, err := client.Put(context.Background(), "/upload", nil, gofetch.FileBody(path), nil); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if len(bodies) != 2 || bodies[1] != payload {
+		t.Errorf("Expected the file to be reopened and resent, got %q", bodies)
+	}
+	if contentLength != int64(len(payload)) || contentType != "application/json" {
+		t.Errorf("Expected Content-Length %d and JSON type, got %d %q", len(payload), contentLength, contentType)
+	}
+	if transferred != int64(len(payload)) || total != int64(len(payload)) {
+		t.Errorf("Expected progress %d of %d, got %d of %d", len(payload), len(payload), transferred, total)
+	}
+
+	if _, err := client.Put(context.Background(), "/upload", nil, gofetch.FileBody(filepath.Join(t.TempDir(), "missing")), nil); err == nil {
+		t.Error("Expected an error for a missing file")
+	}
+}
+
+func TestRejectedFileUploadClosesFile(t *testing.T) {
+	if _, err := os.ReadDir("/proc/self/fd"); err != nil {
+		t.Skip("Open files can't be listed on this platform")
+	}
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Connection", "close")
+		w.WriteHeader(http.StatusRequestEntityTooLarge)
+	}))
+	defer server.Close()
+
+	path := filepath.Join(t.TempDir(), "large.bin")
+	if err := os.WriteFile(path, bytes.Repeat([]byte("x"), 8<<20), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	client := gofetch.NewClient().
+		SetBaseURL(server.URL).
+		SetUploadProgress(func(int64, int64) {})
+
+	if _, err := client.Put(context.Background(), "/upload", nil, gofetch.FileBody(path), nil); err == nil {
+		t.Fatal("Expected the upload to be rejected")
+	}
+
+	// The transport closes request bodies asynchronously
+	deadline := time.Now().Add(time.Second)
+	for fileIsOpen(path) {
+		if time.Now().After(deadline) {
+			t.Fatal("Expected the file to be closed after the rejected upload")
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+}
+
+// fileIsOpen reports whether the process holds a descriptor for path.
+func fileIsOpen(path string) bool {
+	entries, _ := os.ReadDir("/proc/self/fd")
+	for _, entry := range entries {
+		if target, err := os.Readlink(filepath.Join("/proc/self/fd", entry.Name())); err == nil && target == path {
+			return true
+		}
+	}
+	return false
+}
+
+func TestPipedPostStreamsWrites(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if len(r.TransferEncoding) == 0 || r.TransferEncoding[0] != "chunked" {
+			t.Errorf("expected a chunked body, got %v", r.TransferEncoding)
+		}
+		data, _ := io.ReadAll(r.Body)
+		w.Header().Set("Content-Type", r.Header.Get("Content-Type"))
+		w.Write(data)
+	}))
+	defer server.Close()
+
+	client := infrastructure.NewClient().SetBaseURL(server.URL)
+	writer, result := client.PipedPost(context.Background(), "/import", infrastructure.WithContentType("text/csv"))
+
+	go func() {
+		for i := 0; i < 3; i++ {
+			io.WriteString(writer, "row\n")
+		}
+		writer.Close()
+	}()
+
+	outcome := <-result
+	if outcome.Err != nil {
+		t.Fatalf("PipedPost failed: %v", outcome.Err)
+	}
+	if body := string(outcome.Response.RawBody); body != "row\nrow\nrow\n" {
+		t.Errorf("unexpected echoed body %q", body)
+	}
+	if contentType := outcome.Response.Headers.Get("Content-Type"); contentType != "text/csv" {
+		t.Errorf("expected text/csv, got %q", contentType)
+	}
+	if _, err := io.WriteString(writer, "late"); err == nil {
+		t.Error("expected writes after completion to fail")
+	}
+}
+
+func TestPipedPostAbortsOnCancel(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		io.Copy(io.Discard, r.Body)
+	}))
+	defer server.Close()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	writer, result := infrastructure.NewClient().SetBaseURL(server.URL).PipedPost(ctx, "/import")
+
+	io.WriteString(writer, "partial")
+	cancel()
+
+	select {
+	case outcome := <-result:
+		if outcome.Err == nil {
+			t.Error("expected the cancelled request to fail")
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("cancelled request did not complete")
+	}
+	if _, err := io.WriteString(writer, "more"); err == nil {
+		t.Error("expected writes after cancellation to fail")
+	}
+}
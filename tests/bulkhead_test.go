@@ -0,0 +1,158 @@
+package tests
+
+import (
+	"context"
+	stderrors "errors"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/fourth-ally/gofetch/domain/errors"
+	"github.com/fourth-ally/gofetch/domain/models"
+	"github.com/fourth-ally/gofetch/infrastructure"
+)
+
+func TestMaxConcurrentRequestsQueuesExcess(t *testing.T) {
+	var inFlight, peak int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		current := atomic.AddInt32(&inFlight, 1)
+		for {
+			old := atomic.LoadInt32(&peak)
+			if current <= old || atomic.CompareAndSwapInt32(&peak, old, current) {
+				break
+			}
+		}
+		time.Sleep(20 * time.Millisecond)
+		atomic.AddInt32(&inFlight, -1)
+	}))
+	defer server.Close()
+
+	client := infrastructure.NewClient().
+		SetBaseURL(server.URL).
+		SetMaxConcurrentRequests(2)
+
+	var wg sync.WaitGroup
+	for i := 0; i < 6; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if _, err := client.Get(context.Background(), "/slow", nil, nil); err != nil {
+				t.Errorf("Expected queued request to succeed, got %v", err)
+			}
+		}()
+	}
+	wg.Wait()
+
+	if peak > 2 {
+		t.Errorf("Expected at most 2 concurrent requests, got %d", peak)
+	}
+}
+
+func TestBulkheadRejectsPerHost(t *testing.T) {
+	release := make(chan struct{})
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		<-release
+	}))
+	defer server.Close()
+
+	client := infrastructure.NewClient().
+		SetBaseURL(server.URL).
+		SetBulkhead(&models.BulkheadOptions{MaxPerHost: 1, MaxWait: -1})
+
+	done := make(chan struct{})
+	go func() {
+		client.Get(context.Background(), "/blocked", nil, nil)
+		close(done)
+	}()
+	time.Sleep(20 * time.Millisecond)
+
+	_, err := client.Get(context.Background(), "/rejected", nil, nil)
+	close(release)
+	<-done
+
+	var fullErr *errors.BulkheadFullError
+	if !stderrors.As(err, &fullErr) {
+		t.Fatalf("Expected BulkheadFullError, got %v", err)
+	}
+	if fullErr.Host == "" || fullErr.Limit != 1 {
+		t.Errorf("Expected per-host rejection with limit 1, got %+v", fullErr)
+	}
+}
+
+func TestBulkheadAdmitsHighPriorityFirst(t *testing.T) {
+	release := make(chan struct{})
+	var mu sync.Mutex
+	var order []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/blocking" {
+			<-release
+			return
+		}
+		mu.Lock()
+		order = append(order, r.URL.Path)
+		mu.Unlock()
+	}))
+	defer server.Close()
+
+	client := infrastructure.NewClient().
+		SetBaseURL(server.URL).
+		SetMaxConcurrentRequests(1)
+
+	var wg sync.WaitGroup
+	start := func(path string, priority models.Priority) {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			client.GetWithOptions(context.Background(), path, nil, nil, infrastructure.WithPriority(priority))
+		}()
+		time.Sleep(20 * time.Millisecond)
+	}
+
+	start("/blocking", models.PriorityNormal)
+	start("/background", models.PriorityLow)
+	start("/interactive", models.PriorityHigh)
+
+	close(release)
+	wg.Wait()
+
+	if len(order) != 2 || order[0] != "/interactive" {
+		t.Errorf("Expected high-priority request to be admitted first, got %v", order)
+	}
+}
+
+func TestBulkheadSaturatedHostDoesNotStarveOthers(t *testing.T) {
+	release := make(chan struct{})
+	saturated := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		<-release
+	}))
+	defer saturated.Close()
+
+	other := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+	defer other.Close()
+
+	client := infrastructure.NewClient().
+		SetBulkhead(&models.BulkheadOptions{MaxConcurrent: 2, MaxPerHost: 1})
+
+	var wg sync.WaitGroup
+	for i := 0; i < 3; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			client.Get(context.Background(), saturated.URL, nil, nil)
+		}()
+	}
+	time.Sleep(50 * time.Millisecond)
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+	_, err := client.Get(ctx, other.URL, nil, nil)
+	close(release)
+	wg.Wait()
+
+	if err != nil {
+		t.Errorf("Expected requests queued for one host not to hold global slots, got %v", err)
+	}
+}
@@ -0,0 +1,352 @@
+package tests
+
+import (
+	"context"
+	"encoding/json"
+	stderrors "errors"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/fourth-ally/gofetch/domain/errors"
+	"github.com/fourth-ally/gofetch/domain/models"
+	"github.com/fourth-ally/gofetch/infrastructure"
+)
+
+func TestCacheServesFreshResponses(t *testing.T) {
+	calls := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		w.Header().Set("Cache-Control", "max-age=60")
+		json.NewEncoder(w).Encode(TestUser{ID: 1, Name: "Cached"})
+	}))
+	defer server.Close()
+
+	client := infrastructure.NewClient().
+		SetBaseURL(server.URL).
+		SetCache(infrastructure.NewMemoryCacheStore(10), nil)
+
+	for i := 0; i < 3; i++ {
+		var user TestUser
+		resp, err := client.Get(context.Background(), "/users/1", nil, &user)
+		if err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+		if user.Name != "Cached" {
+			t.Errorf("Expected name 'Cached', got %q", user.Name)
+		}
+		if i > 0 && !resp.FromCache {
+			t.Errorf("Request %d: expected response from cache", i+1)
+		}
+	}
+
+	if calls != 1 {
+		t.Errorf("Expected 1 upstream request, got %d", calls)
+	}
+}
+
+func TestCacheRevalidatesStaleResponses(t *testing.T) {
+	calls, conditional := 0, 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		w.Header().Set("Cache-Control", "max-age=0")
+		w.Header().Set("ETag", `"v1"`)
+		if r.Header.Get("If-None-Match") == `"v1"` {
+			conditional++
+			w.WriteHeader(http.StatusNotModified)
+			return
+		}
+		json.NewEncoder(w).Encode(TestUser{ID: 1, Name: "Revalidated"})
+	}))
+	defer server.Close()
+
+	client := infrastructure.NewClient().
+		SetBaseURL(server.URL).
+		SetCache(infrastructure.NewMemoryCacheStore(10), nil)
+
+	client.Get(context.Background(), "/users/1", nil, nil)
+
+	var user TestUser
+	resp, err := client.Get(context.Background(), "/users/1", nil, &user)
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	if calls != 2 || conditional != 1 {
+		t.Errorf("Expected 2 requests with 1 conditional, got %d and %d", calls, conditional)
+	}
+
+	if resp.StatusCode != http.StatusOK || !resp.FromCache {
+		t.Errorf("Expected cached 200 after 304, got %d (fromCache=%v)", resp.StatusCode, resp.FromCache)
+	}
+
+	if user.Name != "Revalidated" {
+		t.Errorf("Expected cached body to be decoded, got %q", user.Name)
+	}
+}
+
+func TestCacheHonorsNoStoreAndVary(t *testing.T) {
+	calls := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		if r.URL.Path == "/private" {
+			w.Header().Set("Cache-Control", "no-store")
+		} else {
+			w.Header().Set("Cache-Control", "max-age=60")
+			w.Header().Set("Vary", "Accept-Language")
+		}
+		w.Write([]byte(`{}`))
+	}))
+	defer server.Close()
+
+	store := infrastructure.NewMemoryCacheStore(10)
+	client := infrastructure.NewClient().
+		SetBaseURL(server.URL).
+		SetCache(store, &models.CacheOptions{DefaultTTL: time.Minute})
+
+	client.Get(context.Background(), "/private", nil, nil)
+	client.Get(context.Background(), "/private", nil, nil)
+	if calls != 2 {
+		t.Errorf("Expected no-store responses to bypass cache, got %d calls", calls)
+	}
+
+	english := client.NewInstance().SetHeader("Accept-Language", "en")
+	greek := client.NewInstance().SetHeader("Accept-Language", "el")
+
+	english.Get(context.Background(), "/varied", nil, nil)
+	english.Get(context.Background(), "/varied", nil, nil)
+	greek.Get(context.Background(), "/varied", nil, nil)
+	if calls != 4 {
+		t.Errorf("Expected Vary to separate variants, got %d calls", calls)
+	}
+}
+
+func TestCacheInvalidatedByUnsafeMethods(t *testing.T) {
+	calls := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == http.MethodGet {
+			calls++
+		}
+		w.Header().Set("Cache-Control", "max-age=60")
+		w.Write([]byte(`{}`))
+	}))
+	defer server.Close()
+
+	client := infrastructure.NewClient().
+		SetBaseURL(server.URL).
+		SetCache(infrastructure.NewMemoryCacheStore(10), nil)
+
+	client.Get(context.Background(), "/users/1", nil, nil)
+	client.Put(context.Background(), "/users/1", nil, TestUser{ID: 1}, nil)
+	client.Get(context.Background(), "/users/1", nil, nil)
+
+	if calls != 2 {
+		t.Errorf("Expected PUT to invalidate cached entry, got %d GETs upstream", calls)
+	}
+}
+
+func TestMemoryCacheStoreEvictsLeastRecentlyUsed(t *testing.T) {
+	store := infrastructure.NewMemoryCacheStore(2)
+	store.Set("a", &models.CacheEntry{})
+	store.Set("b", &models.CacheEntry{})
+	store.Get("a")
+	store.Set("c", &models.CacheEntry{})
+
+	if _, ok := store.Get("b"); ok {
+		t.Error("Expected least recently used entry to be evicted")
+	}
+	if _, ok := store.Get("a"); !ok {
+		t.Error("Expected recently used entry to be kept")
+	}
+	if store.Len() != 2 {
+		t.Errorf("Expected 2 entries, got %d", store.Len())
+	}
+}
+
+func TestCacheStaleWhileRevalidate(t *testing.T) {
+	var version int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		v := atomic.AddInt32(&version, 1)
+		w.Header().Set("Cache-Control", "max-age=0")
+		json.NewEncoder(w).Encode(TestUser{ID: int(v)})
+	}))
+	defer server.Close()
+
+	refreshed := make(chan error, 1)
+	client := infrastructure.NewClient().
+		SetBaseURL(server.URL).
+		SetCache(infrastructure.NewMemoryCacheStore(10), &models.CacheOptions{
+			StaleWhileRevalidate: time.Minute,
+			OnRevalidate: func(key string, resp *models.Response, err error) {
+				refreshed <- err
+			},
+		})
+
+	client.Get(context.Background(), "/users/1", nil, nil)
+
+	var user TestUser
+	resp, err := client.Get(context.Background(), "/users/1", nil, &user)
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	if !resp.Stale || user.ID != 1 {
+		t.Errorf("Expected stale version 1 to be served, got stale=%v id=%d", resp.Stale, user.ID)
+	}
+
+	select {
+	case err := <-refreshed:
+		if err != nil {
+			t.Fatalf("Expected background refresh to succeed, got %v", err)
+		}
+	case <-time.After(time.Second):
+		t.Fatal("Expected background refresh to run")
+	}
+
+	client.Get(context.Background(), "/users/1", nil, &user)
+	if user.ID != 2 {
+		t.Errorf("Expected refreshed version 2, got %d", user.ID)
+	}
+}
+
+func TestResponseCacheInfo(t *testing.T) {
+	date := time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Cache-Control", "public, s-maxage=600, max-age=120")
+		w.Header().Set("Age", "30")
+		w.Header().Set("Date", date.Format(http.TimeFormat))
+		w.Header().Set("Expires", date.Add(time.Hour).Format(http.TimeFormat))
+		w.Header().Set("X-Cache", "Miss from edge, TCP_HIT")
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	resp, err := infrastructure.NewClient().SetBaseURL(server.URL).Get(context.Background(), "/", nil, nil)
+	if err != nil {
+		t.Fatalf("Get failed: %v", err)
+	}
+
+	info := resp.CacheInfo()
+	if info.Age != 30*time.Second || !info.Date.Equal(date) || !info.Expires.Equal(date.Add(time.Hour)) {
+		t.Errorf("unexpected cache info: %+v", info)
+	}
+	if !info.CacheControl.Has("public") {
+		t.Error("expected public directive")
+	}
+	if info.CDNStatus != "HIT" || !info.CDNHit() {
+		t.Errorf("expected CDN hit, got %q", info.CDNStatus)
+	}
+	if lifetime, _ := info.FreshnessLifetime(true); lifetime != 10*time.Minute {
+		t.Errorf("expected shared lifetime of 10m, got %v", lifetime)
+	}
+	if ttl, ok := info.TTL(); !ok || ttl != 90*time.Second {
+		t.Errorf("expected TTL of 90s, got %v", ttl)
+	}
+
+	invalid := models.ParseCacheInfo(http.Header{"Expires": {"0"}, "Cf-Cache-Status": {"dynamic"}})
+	if lifetime, ok := invalid.FreshnessLifetime(false); ok {
+		t.Errorf("expected no lifetime without Date, got %v", lifetime)
+	}
+	if invalid.Expires.After(time.Now()) || invalid.CDNStatus != "DYNAMIC" || invalid.CDNHit() {
+		t.Errorf("unexpected cache info: %+v", invalid)
+	}
+}
+
+func TestWithCacheControlDirectives(t *testing.T) {
+	var calls atomic.Int32
+	var lastCacheControl atomic.Value
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls.Add(1)
+		lastCacheControl.Store(r.Header.Get("Cache-Control"))
+		// Stored 30 seconds past its freshness lifetime
+		w.Header().Set("Cache-Control", "max-age=60")
+		w.Header().Set("Age", "90")
+		json.NewEncoder(w).Encode(TestUser{ID: 1})
+	}))
+	defer server.Close()
+
+	client := infrastructure.NewClient().
+		SetBaseURL(server.URL).
+		SetCache(infrastructure.NewMemoryCacheStore(10), nil)
+	ctx := context.Background()
+
+	if _, err := client.Get(ctx, "/user", nil, nil); err != nil {
+		t.Fatalf("Get failed: %v", err)
+	}
+
+	resp, err := client.Get(ctx, "/user", nil, nil, infrastructure.WithCacheControl(models.CacheMaxStale(time.Minute)))
+	if err != nil {
+		t.Fatalf("Get failed: %v", err)
+	}
+	if !resp.FromCache || !resp.Stale || calls.Load() != 1 {
+		t.Errorf("expected a stale cached response within max-stale, got fromCache=%v stale=%v calls=%d", resp.FromCache, resp.Stale, calls.Load())
+	}
+
+	resp, err = client.Get(ctx, "/user", nil, nil, infrastructure.WithCacheControl(models.CacheMaxStale(10*time.Second)))
+	if err != nil {
+		t.Fatalf("Get failed: %v", err)
+	}
+	if resp.FromCache || calls.Load() != 2 {
+		t.Errorf("expected a network request beyond max-stale, got %d calls", calls.Load())
+	}
+	if got := lastCacheControl.Load(); got != "max-stale=10" {
+		t.Errorf("expected directives to be sent, got %q", got)
+	}
+
+	_, err = client.Get(ctx, "/other", nil, nil, infrastructure.WithCacheControl(models.CacheOnlyIfCached))
+	var httpErr *errors.HTTPError
+	if !stderrors.As(err, &httpErr) || httpErr.StatusCode != http.StatusGatewayTimeout || calls.Load() != 2 {
+		t.Errorf("expected only-if-cached to fail with 504 without a request, got %v", err)
+	}
+
+	resp, err = client.Get(ctx, "/user", nil, nil, infrastructure.WithCacheControl(models.CacheOnlyIfCached, models.CacheMaxStale(-1)))
+	if err != nil || !resp.FromCache {
+		t.Errorf("expected only-if-cached to serve the stale entry, got %v", err)
+	}
+
+	if _, err := client.Get(ctx, "/user", nil, nil, infrastructure.WithCacheControl(models.CacheNoStore)); err != nil || calls.Load() != 3 {
+		t.Errorf("expected no-store to bypass the cache, got %d calls and %v", calls.Load(), err)
+	}
+}
+
+func TestCacheSkipsRangeRequests(t *testing.T) {
+	var calls atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls.Add(1)
+		w.Header().Set("Cache-Control", "max-age=60")
+		if r.Header.Get("Range") == "bytes=0-3" {
+			w.Header().Set("Content-Range", "bytes 0-3/10")
+			w.WriteHeader(http.StatusPartialContent)
+			w.Write([]byte("0123"))
+			return
+		}
+		w.Write([]byte("0123456789"))
+	}))
+	defer server.Close()
+
+	client := infrastructure.NewClient().
+		SetBaseURL(server.URL).
+		SetCache(infrastructure.NewMemoryCacheStore(10), nil)
+
+	partial := infrastructure.WithHeader("Range", "bytes=0-3")
+	if resp, err := client.Get(context.Background(), "/file", nil, nil, partial); err != nil || string(resp.RawBody) != "0123" {
+		t.Fatalf("Expected the partial body, got %v, %v", resp, err)
+	}
+
+	resp, err := client.Get(context.Background(), "/file", nil, nil)
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if string(resp.RawBody) != "0123456789" {
+		t.Errorf("Expected the full body instead of the stored partial one, got %q", resp.RawBody)
+	}
+
+	if resp, err := client.Get(context.Background(), "/file", nil, nil, partial); err != nil || string(resp.RawBody) != "0123" {
+		t.Errorf("Expected range requests to bypass the cached full body, got %v, %v", resp, err)
+	}
+	if got := calls.Load(); got != 3 {
+		t.Errorf("Expected 3 upstream requests, got %d", got)
+	}
+}
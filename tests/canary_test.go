@@ -0,0 +1,76 @@
+package tests
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/fourth-ally/gofetch/infrastructure"
+)
+
+func TestCanaryRoutesByPercentage(t *testing.T) {
+	primaryHits, canaryHits := 0, 0
+	primary := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		primaryHits++
+	}))
+	defer primary.Close()
+
+	canary := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		canaryHits++
+	}))
+	defer canary.Close()
+
+	client := infrastructure.NewClient().
+		SetBaseURL(primary.URL).
+		SetCanary(canary.URL, 100, nil)
+
+	for i := 0; i < 3; i++ {
+		if _, err := client.Get(context.Background(), "/health", nil, nil); err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+	}
+
+	if canaryHits != 3 || primaryHits != 0 {
+		t.Errorf("Expected all requests on canary, got primary=%d canary=%d", primaryHits, canaryHits)
+	}
+
+	stats := client.CanaryStats()
+	if stats.Canary.Requests != 3 || stats.Primary.Requests != 0 {
+		t.Errorf("Expected canary stats to count 3 requests, got %+v", stats)
+	}
+}
+
+func TestCanaryRoutesByHeader(t *testing.T) {
+	primary := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer primary.Close()
+
+	canary := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}))
+	defer canary.Close()
+
+	base := infrastructure.NewClient().
+		SetBaseURL(primary.URL).
+		SetCanary(canary.URL, 0, map[string]string{"X-Tenant": "beta"})
+
+	if _, err := base.Get(context.Background(), "/health", nil, nil); err != nil {
+		t.Fatalf("Expected primary request to succeed, got %v", err)
+	}
+
+	beta := base.NewInstance().SetHeader("X-Tenant", "beta")
+	if _, err := beta.Get(context.Background(), "/health", nil, nil); err == nil {
+		t.Fatal("Expected canary request to fail with 503")
+	}
+
+	stats := base.CanaryStats()
+	if stats.Primary.Requests != 1 || stats.Canary.Requests != 1 {
+		t.Errorf("Expected one request per target, got %+v", stats)
+	}
+
+	if stats.Canary.ErrorRate() != 1 {
+		t.Errorf("Expected canary error rate 1, got %v", stats.Canary.ErrorRate())
+	}
+}
@@ -0,0 +1,124 @@
+package tests
+
+import (
+	"bytes"
+	"context"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"net/http/httptrace"
+	"strings"
+	"testing"
+
+	"github.com/fourth-ally/gofetch/domain/models"
+	"github.com/fourth-ally/gofetch/infrastructure"
+)
+
+func TestCaptureFilterScopesJournalAndLogging(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/missing" {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		w.Write([]byte(`{}`))
+	}))
+	defer server.Close()
+
+	var journal, logs bytes.Buffer
+	client := infrastructure.NewClient().
+		SetBaseURL(server.URL).
+		SetJournal(&journal).
+		SetLogger(slog.New(slog.NewTextHandler(&logs, &slog.HandlerOptions{Level: slog.LevelDebug}))).
+		SetCaptureFilter(&models.CaptureFilter{
+			IncludeHosts:  []string{"127.0.0.1"},
+			ExcludePaths:  []string{"/auth/**"},
+			StatusClasses: []int{2},
+		})
+
+	for _, path := range []string{"/users", "/auth/token", "/auth", "/missing"} {
+		client.Get(context.Background(), path, nil, nil)
+	}
+
+	exchanges, err := infrastructure.LoadJournal(&journal)
+	if err != nil {
+		t.Fatalf("Expected journal to load, got %v", err)
+	}
+	if len(exchanges) != 1 || !strings.HasSuffix(exchanges[0].URL, "/users") {
+		t.Fatalf("Expected only /users to be journaled, got %+v", exchanges)
+	}
+
+	output := logs.String()
+	if !strings.Contains(output, "/users") {
+		t.Errorf("Expected /users to be logged, got %q", output)
+	}
+	for _, excluded := range []string{"/auth", "/missing"} {
+		if strings.Contains(output, excluded) {
+			t.Errorf("Expected %s not to be logged, got %q", excluded, output)
+		}
+	}
+}
+
+func TestCaptureFilterMatch(t *testing.T) {
+	filter := &models.CaptureFilter{
+		IncludeHosts: []string{"*.example.com"},
+		ExcludeHosts: []string{"vault.example.com"},
+		IncludePaths: []string{"/v1/**", "/health"},
+	}
+
+	tests := []struct {
+		host, path string
+		want       bool
+	}{
+		{"api.example.com", "/v1/users/7", true},
+		{"api.example.com:8443", "/v1", true},
+		{"api.example.com", "/health", true},
+		{"api.example.com", "/v2/users", false},
+		{"api.example.com", "/v1x", false},
+		{"vault.example.com", "/v1/secrets", false},
+		{"example.org", "/v1/users", false},
+	}
+	for _, tt := range tests {
+		if got := filter.Match(tt.host, tt.path, http.StatusOK); got != tt.want {
+			t.Errorf("Match(%q, %q) = %v, want %v", tt.host, tt.path, got, tt.want)
+		}
+	}
+
+	var none *models.CaptureFilter
+	if !none.Match("any", "/", 500) {
+		t.Error("Expected a nil filter to match everything")
+	}
+}
+
+func TestSamplingSkipsCaptureForUnsampledRequests(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`{}`))
+	}))
+	defer server.Close()
+
+	var journal bytes.Buffer
+	client := infrastructure.NewClient().
+		SetBaseURL(server.URL).
+		SetJournal(&journal).
+		SetSampling(0)
+
+	traced := 0
+	trace := &httptrace.ClientTrace{GotConn: func(httptrace.GotConnInfo) { traced++ }}
+
+	for i := 0; i < 5; i++ {
+		if _, err := client.GetWithOptions(context.Background(), "/quiet", nil, nil, infrastructure.WithClientTrace(trace)); err != nil {
+			t.Fatalf("Expected unsampled requests to be sent, got %v", err)
+		}
+	}
+	if journal.Len() != 0 || traced != 0 {
+		t.Fatalf("Expected no capture at rate 0, got %d journal bytes and %d traces", journal.Len(), traced)
+	}
+
+	_, err := client.GetWithOptions(context.Background(), "/investigate", nil, nil,
+		infrastructure.WithSampling(1), infrastructure.WithClientTrace(trace))
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if !strings.Contains(journal.String(), "/investigate") || traced != 1 {
+		t.Errorf("Expected the per-request rate to override the client rate, got %q and %d traces", journal.String(), traced)
+	}
+}
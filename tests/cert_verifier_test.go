@@ -0,0 +1,108 @@
+package tests
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"errors"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"golang.org/x/crypto/ocsp"
+
+	"github.com/fourth-ally/gofetch/infrastructure"
+	"github.com/fourth-ally/gofetch/infrastructure/revocation"
+)
+
+func TestCertVerifierRejectsConnection(t *testing.T) {
+	server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`{}`))
+	}))
+	defer server.Close()
+
+	var sawPeer bool
+	client := infrastructure.NewClient().
+		SetBaseURL(server.URL).
+		SetTransport(server.Client().Transport).
+		SetCertVerifier(func(state tls.ConnectionState) error {
+			sawPeer = len(state.PeerCertificates) > 0
+			return errors.New("policy violation")
+		})
+
+	_, err := client.Get(context.Background(), "/", nil, nil)
+	if err == nil {
+		t.Fatal("Expected verifier error to fail the request")
+	}
+
+	if !sawPeer {
+		t.Error("Expected verifier to receive peer certificates")
+	}
+}
+
+func TestOCSPStapleVerifierRequiresStaple(t *testing.T) {
+	server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+	defer server.Close()
+
+	client := infrastructure.NewClient().
+		SetBaseURL(server.URL).
+		SetTransport(server.Client().Transport).
+		SetCertVerifier(revocation.OCSPStapleVerifier(true))
+
+	_, err := client.Get(context.Background(), "/", nil, nil)
+	if !errors.Is(err, revocation.ErrNoStaple) {
+		t.Errorf("Expected ErrNoStaple, got %v", err)
+	}
+}
+
+func TestOCSPStapleVerifierDetectsRevocation(t *testing.T) {
+	caKey, _ := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	caTemplate := &x509.Certificate{
+		SerialNumber:          big.NewInt(1),
+		Subject:               pkix.Name{CommonName: "Test CA"},
+		NotBefore:             time.Now().Add(-time.Hour),
+		NotAfter:              time.Now().Add(time.Hour),
+		IsCA:                  true,
+		BasicConstraintsValid: true,
+		KeyUsage:              x509.KeyUsageCertSign | x509.KeyUsageDigitalSignature,
+	}
+	caDER, _ := x509.CreateCertificate(rand.Reader, caTemplate, caTemplate, &caKey.PublicKey, caKey)
+	ca, _ := x509.ParseCertificate(caDER)
+
+	leafKey, _ := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	leafTemplate := &x509.Certificate{
+		SerialNumber: big.NewInt(42),
+		Subject:      pkix.Name{CommonName: "example.com"},
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     time.Now().Add(time.Hour),
+	}
+	leafDER, _ := x509.CreateCertificate(rand.Reader, leafTemplate, ca, &leafKey.PublicKey, caKey)
+	leaf, _ := x509.ParseCertificate(leafDER)
+
+	staple, err := ocsp.CreateResponse(ca, ca, ocsp.Response{
+		Status:       ocsp.Revoked,
+		SerialNumber: leaf.SerialNumber,
+		ThisUpdate:   time.Now().Add(-time.Minute),
+		NextUpdate:   time.Now().Add(time.Hour),
+		RevokedAt:    time.Now().Add(-time.Minute),
+	}, caKey)
+	if err != nil {
+		t.Fatalf("Failed to create OCSP response: %v", err)
+	}
+
+	verify := revocation.OCSPStapleVerifier(false)
+	err = verify(tls.ConnectionState{
+		OCSPResponse:   staple,
+		VerifiedChains: [][]*x509.Certificate{{leaf, ca}},
+	})
+
+	if !errors.Is(err, revocation.ErrCertificateRevoked) {
+		t.Errorf("Expected ErrCertificateRevoked, got %v", err)
+	}
+}
@@ -0,0 +1,280 @@
+package tests
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/types/descriptorpb"
+	"google.golang.org/protobuf/types/known/anypb"
+	"google.golang.org/protobuf/types/known/wrapperspb"
+
+	"github.com/fourth-ally/gofetch/infrastructure"
+	"github.com/fourth-ally/gofetch/infrastructure/codecs/cbor"
+	"github.com/fourth-ally/gofetch/infrastructure/codecs/msgpack"
+	"github.com/fourth-ally/gofetch/infrastructure/codecs/protobuf"
+)
+
+type codecUser struct {
+	ID   int    `json:"id"`
+	Name string `json:"name"`
+}
+
+func TestMessagePackCodecRoundTrip(t *testing.T) {
+	codec := msgpack.New()
+
+	var contentType, accept string
+	var received codecUser
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		contentType = r.Header.Get("Content-Type")
+		accept = r.Header.Get("Accept")
+
+		data, _ := io.ReadAll(r.Body)
+		if err := codec.Unmarshal(data, &received); err != nil {
+			t.Errorf("Expected MessagePack request body, got %v", err)
+		}
+
+		reply, _ := codec.Marshal(codecUser{ID: received.ID + 1, Name: received.Name})
+		w.Header().Set("Content-Type", msgpack.ContentType)
+		w.Write(reply)
+	}))
+	defer server.Close()
+
+	client := infrastructure.NewClient().SetBaseURL(server.URL).SetCodec(codec)
+
+	var created codecUser
+	if _, err := client.Post(context.Background(), "/users", nil, codecUser{ID: 1, Name: "Ada"}, &created); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	if contentType != msgpack.ContentType || accept != msgpack.ContentType {
+		t.Errorf("Expected MessagePack Content-Type and Accept, got %q and %q", contentType, accept)
+	}
+	if received.Name != "Ada" || created.ID != 2 || created.Name != "Ada" {
+		t.Errorf("Expected round trip through MessagePack, got sent %+v, received %+v", received, created)
+	}
+}
+
+func TestCodecFallsBackToJSONByContentType(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"id":7,"name":"Grace"}`))
+	}))
+	defer server.Close()
+
+	client := infrastructure.NewClient().SetBaseURL(server.URL).SetCodec(msgpack.New())
+
+	var user codecUser
+	if _, err := client.Get(context.Background(), "/users/7", nil, &user); err != nil {
+		t.Fatalf("Expected JSON response to decode, got %v", err)
+	}
+	if user.ID != 7 || user.Name != "Grace" {
+		t.Errorf("Expected JSON fallback, got %+v", user)
+	}
+}
+
+func TestProtobufCodec(t *testing.T) {
+	codec := protobuf.New()
+
+	var received wrapperspb.StringValue
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		data, _ := io.ReadAll(r.Body)
+		codec.Unmarshal(data, &received)
+
+		reply, _ := codec.Marshal(wrapperspb.String("hello " + received.GetValue()))
+		w.Header().Set("Content-Type", protobuf.ContentType)
+		w.Write(reply)
+	}))
+	defer server.Close()
+
+	client := infrastructure.NewClient().SetBaseURL(server.URL).SetCodec(codec)
+
+	reply := &wrapperspb.StringValue{}
+	if _, err := client.Post(context.Background(), "/greet", nil, wrapperspb.String("gofetch"), reply); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if reply.GetValue() != "hello gofetch" {
+		t.Errorf("Expected protobuf round trip, got %q", reply.GetValue())
+	}
+
+	if _, err := client.Post(context.Background(), "/greet", nil, map[string]string{}, nil); err == nil {
+		t.Error("Expected error for non-proto body")
+	}
+}
+
+func TestCBORCodec(t *testing.T) {
+	codec := cbor.New()
+
+	var contentType string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		contentType = r.Header.Get("Content-Type")
+
+		var reading map[string]interface{}
+		data, _ := io.ReadAll(r.Body)
+		codec.Unmarshal(data, &reading)
+
+		reply, _ := codec.Marshal(codecUser{ID: 1, Name: reading["sensor"].(string)})
+		w.Header().Set("Content-Type", cbor.ContentType)
+		w.Write(reply)
+	}))
+	defer server.Close()
+
+	client := infrastructure.NewClient().SetBaseURL(server.URL).SetCodec(codec)
+
+	var user codecUser
+	if _, err := client.Post(context.Background(), "/readings", nil, map[string]interface{}{"sensor": "t-1", "celsius": 21.5}, &user); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	if contentType != cbor.ContentType {
+		t.Errorf("Expected CBOR Content-Type, got %q", contentType)
+	}
+	if user.ID != 1 || user.Name != "t-1" {
+		t.Errorf("Expected CBOR round trip using json tags, got %+v", user)
+	}
+}
+
+func TestAutoDecodeDisabled(t *testing.T) {
+	codec := msgpack.New()
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/msgpack" {
+			reply, _ := codec.Marshal(codecUser{ID: 7, Name: "Grace"})
+			w.Header().Set("Content-Type", msgpack.ContentType)
+			w.Write(reply)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"id":3,"name":"Ada"}`))
+	}))
+	defer server.Close()
+
+	client := infrastructure.NewClient().SetBaseURL(server.URL).RegisterCodec(codec).SetAutoDecode(false)
+
+	var target codecUser
+	resp, err := client.Get(context.Background(), "/json", nil, &target)
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if target != (codecUser{}) {
+		t.Errorf("Expected target to stay undecoded, got %+v", target)
+	}
+
+	var user codecUser
+	if err := resp.Decode(&user); err != nil || user.ID != 3 || user.Name != "Ada" {
+		t.Errorf("Expected explicit JSON decode, got %+v (%v)", user, err)
+	}
+
+	resp, err = client.Get(context.Background(), "/msgpack", nil, nil)
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if err := resp.Decode(&user); err != nil || user.ID != 7 || user.Name != "Grace" {
+		t.Errorf("Expected explicit decode with the registered codec, got %+v (%v)", user, err)
+	}
+}
+
+func TestPluggableJSON(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		io.Copy(w, r.Body)
+	}))
+	defer server.Close()
+
+	var marshals, unmarshals int32
+	impl := infrastructure.JSONFuncs{
+		MarshalFunc: func(v interface{}) ([]byte, error) {
+			atomic.AddInt32(&marshals, 1)
+			return json.Marshal(v)
+		},
+		UnmarshalFunc: func(data []byte, v interface{}) error {
+			atomic.AddInt32(&unmarshals, 1)
+			return json.Unmarshal(data, v)
+		},
+	}
+	client := infrastructure.NewClient().SetBaseURL(server.URL).SetJSON(impl).NewInstance()
+
+	var user codecUser
+	resp, err := client.Post(context.Background(), "/", nil, codecUser{ID: 1, Name: "Alice"}, &user)
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if user.Name != "Alice" {
+		t.Errorf("Expected echoed user, got %+v", user)
+	}
+
+	var decoded codecUser
+	if err := resp.Decode(&decoded); err != nil || decoded.ID != 1 {
+		t.Errorf("Expected Decode to succeed, got %+v, %v", decoded, err)
+	}
+
+	if marshals != 1 || unmarshals != 2 {
+		t.Errorf("Expected the plugged implementation to be used, got %d marshals and %d unmarshals", marshals, unmarshals)
+	}
+}
+
+func TestProtobufDynamicMessages(t *testing.T) {
+	set := &descriptorpb.FileDescriptorSet{File: []*descriptorpb.FileDescriptorProto{{
+		Name:    proto.String("user.proto"),
+		Package: proto.String("acme.v1"),
+		Syntax:  proto.String("proto3"),
+		MessageType: []*descriptorpb.DescriptorProto{{
+			Name: proto.String("User"),
+			Field: []*descriptorpb.FieldDescriptorProto{
+				{Name: proto.String("id"), JsonName: proto.String("id"), Number: proto.Int32(1), Type: descriptorpb.FieldDescriptorProto_TYPE_INT64.Enum(), Label: descriptorpb.FieldDescriptorProto_LABEL_OPTIONAL.Enum()},
+				{Name: proto.String("name"), JsonName: proto.String("name"), Number: proto.Int32(2), Type: descriptorpb.FieldDescriptorProto_TYPE_STRING.Enum(), Label: descriptorpb.FieldDescriptorProto_LABEL_OPTIONAL.Enum()},
+			},
+		}},
+	}}}
+	data, _ := proto.Marshal(set)
+	types, err := protobuf.LoadDescriptorSet(data)
+	if err != nil {
+		t.Fatalf("Expected descriptor set to load, got %v", err)
+	}
+
+	user, _ := types.NewMessage("acme.v1.User")
+	fields := user.Descriptor().Fields()
+	user.Set(fields.ByName("id"), protoreflect.ValueOfInt64(7))
+	user.Set(fields.ByName("name"), protoreflect.ValueOfString("Ada"))
+	userBytes, _ := proto.Marshal(user)
+	packed, _ := anypb.New(user)
+	packedBytes, _ := proto.Marshal(packed)
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", protobuf.ContentType)
+		if r.URL.Path == "/any" {
+			w.Write(packedBytes)
+			return
+		}
+		w.Write(userBytes)
+	}))
+	defer server.Close()
+
+	client := infrastructure.NewClient().SetBaseURL(server.URL).SetCodec(protobuf.NewWithTypes(types))
+
+	decoded, _ := types.NewMessage("acme.v1.User")
+	if _, err := client.Get(context.Background(), "/user", nil, decoded); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if decoded.Get(fields.ByName("name")).String() != "Ada" || decoded.Get(fields.ByName("id")).Int() != 7 {
+		t.Errorf("Expected dynamic user, got %v", decoded)
+	}
+
+	var envelope anypb.Any
+	if _, err := client.Get(context.Background(), "/any", nil, &envelope); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	unpacked, err := types.UnpackAny(&envelope)
+	if err != nil || !proto.Equal(unpacked, user) {
+		t.Errorf("Expected Any to unpack into the dynamic user, got %v, %v", unpacked, err)
+	}
+
+	if _, err := types.NewMessage("acme.v1.Missing"); err == nil {
+		t.Error("Expected an error for an unknown type")
+	}
+}
@@ -0,0 +1,115 @@
+package tests
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"slices"
+	"testing"
+
+	"github.com/fourth-ally/gofetch/domain/models"
+	"github.com/fourth-ally/gofetch/infrastructure"
+)
+
+func eventSummary(events []models.CollectionEvent) []string {
+	var summary []string
+	for _, event := range events {
+		summary = append(summary, string(event.Type)+":"+event.ID)
+	}
+	return summary
+}
+
+func TestSyncCollectionDiffsFullListings(t *testing.T) {
+	version := 1
+	var notModified int
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		etag := `"v` + string(rune('0'+version)) + `"`
+		if r.Header.Get("If-None-Match") == etag {
+			notModified++
+			w.WriteHeader(http.StatusNotModified)
+			return
+		}
+		w.Header().Set("ETag", etag)
+		if version == 1 {
+			w.Write([]byte(`[{"id": 1, "title": "a"}, {"id": 2, "title": "b"}]`))
+			return
+		}
+		w.Write([]byte(`[{"id":2,"title":"B"},{"id":3,"title":"c"}]`))
+	}))
+	defer server.Close()
+
+	client := infrastructure.NewClient().SetBaseURL(server.URL)
+	store := infrastructure.NewMemoryCollectionStore()
+
+	events, err := client.SyncCollection(context.Background(), "/todos", store, nil)
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if want := []string{"added:1", "added:2"}; !slices.Equal(eventSummary(events), want) {
+		t.Errorf("Expected %v, got %v", want, eventSummary(events))
+	}
+
+	events, err = client.SyncCollection(context.Background(), "/todos", store, nil)
+	if err != nil || len(events) != 0 || notModified != 1 {
+		t.Errorf("Expected an unchanged collection to be revalidated, got %v, %v", eventSummary(events), err)
+	}
+
+	version = 2
+	events, err = client.SyncCollection(context.Background(), "/todos", store, nil)
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if want := []string{"updated:2", "added:3", "deleted:1"}; !slices.Equal(eventSummary(events), want) {
+		t.Errorf("Expected %v, got %v", want, eventSummary(events))
+	}
+
+	snapshot, _ := store.Load()
+	if len(snapshot.Items) != 2 || string(snapshot.Items["2"]) != `{"id":2,"title":"B"}` || snapshot.ETag != `"v2"` {
+		t.Errorf("Unexpected snapshot: %+v", snapshot)
+	}
+}
+
+func TestSyncCollectionUsesDeltaTokens(t *testing.T) {
+	var tokens []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		token := r.URL.Query().Get("syncToken")
+		tokens = append(tokens, token)
+		switch token {
+		case "":
+			w.Write([]byte(`{"items": [{"id": "a"}, {"id": "b"}], "nextSyncToken": "t1"}`))
+		case "t1":
+			w.Write([]byte(`{"items": [{"id": "b", "deleted": true}, {"id": "c"}], "nextSyncToken": "t2"}`))
+		default:
+			w.WriteHeader(http.StatusGone)
+		}
+	}))
+	defer server.Close()
+
+	client := infrastructure.NewClient().SetBaseURL(server.URL)
+	store := infrastructure.NewMemoryCollectionStore()
+	options := models.NewSyncOptions()
+	options.ItemsField = "items"
+	options.DeltaParam = "syncToken"
+	options.DeltaTokenField = "nextSyncToken"
+
+	client.SyncCollection(context.Background(), "/events", store, options)
+	events, err := client.SyncCollection(context.Background(), "/events", store, options)
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if want := []string{"deleted:b", "added:c"}; !slices.Equal(eventSummary(events), want) {
+		t.Errorf("Expected %v, got %v", want, eventSummary(events))
+	}
+
+	// The expired token falls back to a full listing diffed against a and c
+	events, err = client.SyncCollection(context.Background(), "/events", store, options)
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if want := []string{"added:b", "deleted:c"}; !slices.Equal(eventSummary(events), want) {
+		t.Errorf("Expected %v, got %v", want, eventSummary(events))
+	}
+	if want := []string{"", "t1", "t2", ""}; !slices.Equal(tokens, want) {
+		t.Errorf("Expected tokens %v, got %v", want, tokens)
+	}
+}
@@ -0,0 +1,69 @@
+package tests
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/fourth-ally/gofetch/infrastructure"
+)
+
+func TestConditionalRequestsReuseBodyOnNotModified(t *testing.T) {
+	calls := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		if r.Header.Get("If-None-Match") == `"abc"` {
+			w.WriteHeader(http.StatusNotModified)
+			return
+		}
+		w.Header().Set("ETag", `"abc"`)
+		json.NewEncoder(w).Encode(TestUser{ID: 1, Name: "Stored"})
+	}))
+	defer server.Close()
+
+	client := infrastructure.NewClient().
+		SetBaseURL(server.URL).
+		SetConditionalRequests(infrastructure.NewMemoryCacheStore(0))
+
+	for i := 0; i < 2; i++ {
+		var user TestUser
+		resp, err := client.Get(context.Background(), "/users/1", nil, &user)
+		if err != nil {
+			t.Fatalf("Request %d: expected no error, got %v", i+1, err)
+		}
+		if resp.StatusCode != http.StatusOK {
+			t.Errorf("Request %d: expected status 200, got %d", i+1, resp.StatusCode)
+		}
+		if user.Name != "Stored" {
+			t.Errorf("Request %d: expected name 'Stored', got %q", i+1, user.Name)
+		}
+	}
+
+	if calls != 2 {
+		t.Errorf("Expected every request to reach the server, got %d", calls)
+	}
+}
+
+func TestConditionalRequestsSendIfModifiedSince(t *testing.T) {
+	const lastModified = "Wed, 21 Oct 2015 07:28:00 GMT"
+	var received string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		received = r.Header.Get("If-Modified-Since")
+		w.Header().Set("Last-Modified", lastModified)
+		w.Write([]byte(`{}`))
+	}))
+	defer server.Close()
+
+	client := infrastructure.NewClient().
+		SetBaseURL(server.URL).
+		SetConditionalRequests(infrastructure.NewMemoryCacheStore(0))
+
+	client.Get(context.Background(), "/report", nil, nil)
+	client.Get(context.Background(), "/report", nil, nil)
+
+	if received != lastModified {
+		t.Errorf("Expected If-Modified-Since %q, got %q", lastModified, received)
+	}
+}
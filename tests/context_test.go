@@ -8,6 +8,7 @@ import (
 	"testing"
 	"time"
 
+	"github.com/fourth-ally/gofetch"
 	"github.com/fourth-ally/gofetch/infrastructure"
 )
 
@@ -29,3 +30,46 @@ func TestContextCancellation(t *testing.T) {
 		t.Fatal("Expected context deadline exceeded error")
 	}
 }
+
+func TestContextOverrides(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/slow" {
+			time.Sleep(200 * time.Millisecond)
+		}
+		json.NewEncoder(w).Encode(map[string]string{
+			"tenant": r.Header.Get("X-Tenant"),
+			"trace":  r.Header.Get("X-Trace"),
+		})
+	}))
+	defer server.Close()
+
+	client := infrastructure.NewClient().
+		SetBaseURL(server.URL).
+		SetHeader("X-Tenant", "default").
+		SetTimeout(50 * time.Millisecond)
+
+	ctx := gofetch.WithHeaderContext(context.Background(), "X-Tenant", "acme")
+	ctx = gofetch.WithHeaderContext(ctx, "X-Trace", "abc")
+
+	var headers map[string]string
+	if _, err := client.Get(ctx, "/", nil, &headers); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if headers["tenant"] != "acme" || headers["trace"] != "abc" {
+		t.Errorf("Expected context headers, got %v", headers)
+	}
+
+	if _, err := client.GetWithOptions(ctx, "/", nil, &headers, infrastructure.WithHeader("X-Tenant", "explicit")); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if headers["tenant"] != "explicit" || headers["trace"] != "abc" {
+		t.Errorf("Expected request options to take precedence, got %v", headers)
+	}
+
+	if _, err := client.Get(ctx, "/slow", nil, nil); err == nil {
+		t.Error("Expected the client timeout to apply")
+	}
+	if _, err := client.Get(gofetch.WithTimeoutContext(ctx, time.Second), "/slow", nil, nil); err != nil {
+		t.Errorf("Expected the context timeout to replace the client timeout, got %v", err)
+	}
+}
@@ -0,0 +1,136 @@
+package tests
+
+import (
+	"context"
+	stderrors "errors"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/fourth-ally/gofetch/domain/errors"
+	"github.com/fourth-ally/gofetch/domain/models"
+	"github.com/fourth-ally/gofetch/infrastructure"
+)
+
+const testRobots = `# robots for tests
+User-agent: *
+Disallow: /
+
+User-agent: otherbot
+Allow: /
+
+User-agent: testbot
+Disallow: /private
+Allow: /private/public
+Disallow: /*.pdf$
+`
+
+func TestCrawlPolicyRobots(t *testing.T) {
+	var robotsFetches int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/robots.txt" {
+			atomic.AddInt32(&robotsFetches, 1)
+			w.Write([]byte(testRobots))
+			return
+		}
+		if r.Header.Get("User-Agent") != "TestBot/1.0" {
+			t.Errorf("Expected crawler User-Agent, got %q", r.Header.Get("User-Agent"))
+		}
+		w.Write([]byte(`{}`))
+	}))
+	defer server.Close()
+
+	options := models.NewCrawlOptions("TestBot/1.0")
+	options.Delay = 0
+	client := infrastructure.NewClient().SetBaseURL(server.URL).SetCrawlPolicy(options)
+
+	tests := map[string]bool{
+		"/":                    true,
+		"/private":             false,
+		"/private/secret":      false,
+		"/private/public/page": true,
+		"/docs/report.pdf":     false,
+		"/docs/report.pdf?v=2": true,
+	}
+	for path, allowed := range tests {
+		_, err := client.Get(context.Background(), path, nil, nil)
+
+		var robotsErr *errors.RobotsDisallowedError
+		if disallowed := stderrors.As(err, &robotsErr); disallowed == allowed {
+			t.Errorf("%s: expected allowed=%v, got %v", path, allowed, err)
+		}
+	}
+
+	if n := atomic.LoadInt32(&robotsFetches); n != 1 {
+		t.Errorf("Expected robots.txt to be fetched once, got %d", n)
+	}
+}
+
+func TestCrawlPolicyMissingRobotsAllowsAll(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/robots.txt" {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		w.Write([]byte(`{}`))
+	}))
+	defer server.Close()
+
+	options := models.NewCrawlOptions("TestBot")
+	options.Delay = 0
+	client := infrastructure.NewClient().SetBaseURL(server.URL).SetCrawlPolicy(options)
+
+	if _, err := client.Get(context.Background(), "/anything", nil, nil); err != nil {
+		t.Errorf("Expected request to be allowed, got %v", err)
+	}
+}
+
+func TestCrawlPolicyDelayAndConcurrency(t *testing.T) {
+	var mu sync.Mutex
+	var starts []time.Time
+	var inFlight, maxInFlight int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		current := atomic.AddInt32(&inFlight, 1)
+		defer atomic.AddInt32(&inFlight, -1)
+
+		mu.Lock()
+		starts = append(starts, time.Now())
+		if current > maxInFlight {
+			maxInFlight = current
+		}
+		mu.Unlock()
+
+		time.Sleep(10 * time.Millisecond)
+		w.Write([]byte(`{}`))
+	}))
+	defer server.Close()
+
+	client := infrastructure.NewClient().SetBaseURL(server.URL).SetCrawlPolicy(&models.CrawlOptions{
+		Delay:      30 * time.Millisecond,
+		MaxPerHost: 1,
+	})
+
+	var wg sync.WaitGroup
+	for i := 0; i < 3; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if _, err := client.Get(context.Background(), "/", nil, nil); err != nil {
+				t.Errorf("Expected no error, got %v", err)
+			}
+		}()
+	}
+	wg.Wait()
+
+	if maxInFlight != 1 {
+		t.Errorf("Expected one request in flight per host, got %d", maxInFlight)
+	}
+	for i := 1; i < len(starts); i++ {
+		if gap := starts[i].Sub(starts[i-1]); gap < 25*time.Millisecond {
+			t.Errorf("Expected requests spaced by the crawl delay, got %v", gap)
+		}
+	}
+}
@@ -0,0 +1,159 @@
+package tests
+
+import (
+	"bytes"
+	"compress/gzip"
+	"context"
+	stderrors "errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"testing"
+
+	brotlienc "github.com/andybalholm/brotli"
+	zstdenc "github.com/klauspost/compress/zstd"
+
+	"github.com/fourth-ally/gofetch/domain/contracts"
+	"github.com/fourth-ally/gofetch/domain/errors"
+	"github.com/fourth-ally/gofetch/infrastructure"
+	"github.com/fourth-ally/gofetch/infrastructure/compression/brotli"
+	"github.com/fourth-ally/gofetch/infrastructure/compression/snappy"
+	"github.com/fourth-ally/gofetch/infrastructure/compression/zstd"
+)
+
+func compress(t *testing.T, encoding string, data []byte) []byte {
+	var buf bytes.Buffer
+	var writer io.WriteCloser
+	switch encoding {
+	case "br":
+		writer = brotlienc.NewWriter(&buf)
+	case "zstd":
+		encoder, err := zstdenc.NewWriter(&buf)
+		if err != nil {
+			t.Fatal(err)
+		}
+		writer = encoder
+	case "gzip":
+		writer = gzip.NewWriter(&buf)
+	}
+	writer.Write(data)
+	writer.Close()
+	return buf.Bytes()
+}
+
+func TestRegisteredDecompressors(t *testing.T) {
+	payload := []byte(`{"message":"` + strings.Repeat("compressible ", 1000) + `"}`)
+
+	var accept string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		accept = r.Header.Get("Accept-Encoding")
+		encoding := strings.TrimPrefix(r.URL.Path, "/")
+		body := compress(t, encoding, payload)
+		w.Header().Set("Content-Encoding", encoding)
+		w.Header().Set("Content-Type", "application/json")
+		w.Header().Set("Content-Length", strconv.Itoa(len(body)))
+		w.Write(body)
+	}))
+	defer server.Close()
+
+	var progressed int64
+	client := infrastructure.NewClient().
+		SetBaseURL(server.URL).
+		RegisterDecompressor(brotli.New()).
+		RegisterDecompressor(zstd.New()).
+		SetDownloadProgress(func(n, total int64) { progressed = n })
+
+	for _, encoding := range []string{"br", "zstd", "gzip"} {
+		t.Run(encoding, func(t *testing.T) {
+			var result map[string]string
+			resp, err := client.Get(context.Background(), "/"+encoding, nil, &result)
+			if err != nil {
+				t.Fatalf("Expected no error, got %v", err)
+			}
+
+			if accept != "gzip, br, zstd" {
+				t.Errorf("Expected registered encodings to be advertised, got %q", accept)
+			}
+			if !bytes.Equal(resp.RawBody, payload) || len(result["message"]) == 0 {
+				t.Errorf("Expected decompressed body, got %d bytes", len(resp.RawBody))
+			}
+			if !resp.Decompressed || resp.Headers.Get("Content-Encoding") != "" {
+				t.Errorf("Expected response marked as decompressed, got %v", resp.Headers)
+			}
+		})
+	}
+
+	var buf bytes.Buffer
+	if _, err := client.Download(context.Background(), "/br", nil, &buf); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if !bytes.Equal(buf.Bytes(), payload) || progressed != int64(len(payload)) {
+		t.Errorf("Expected progress over decompressed bytes, got %d of %d written", progressed, buf.Len())
+	}
+}
+
+func TestRequestCompression(t *testing.T) {
+	payload := map[string]string{"message": strings.Repeat("compressible ", 1000)}
+	compressors := map[string]contracts.Compressor{"br": brotli.New(), "zstd": zstd.New(), "snappy": snappy.New()}
+
+	// Echo the body back compressed the same way
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		encoding := r.Header.Get("Content-Encoding")
+		var reader io.Reader
+		var err error
+		if encoding == "gzip" {
+			reader, err = gzip.NewReader(r.Body)
+		} else {
+			reader, err = compressors[encoding].NewReader(r.Body)
+		}
+		if err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+
+		body, _ := io.ReadAll(reader)
+		if r.ContentLength >= int64(len(body)) {
+			t.Errorf("Expected %s body smaller than %d bytes, got %d", encoding, len(body), r.ContentLength)
+		}
+
+		var buf bytes.Buffer
+		var writer io.WriteCloser = gzip.NewWriter(&buf)
+		if encoding != "gzip" {
+			writer, _ = compressors[encoding].NewWriter(&buf)
+		}
+		writer.Write(body)
+		writer.Close()
+
+		w.Header().Set("Content-Encoding", encoding)
+		w.Header().Set("Content-Type", "application/json")
+		w.Write(buf.Bytes())
+	}))
+	defer server.Close()
+
+	client := infrastructure.NewClient().SetBaseURL(server.URL)
+	for _, compressor := range compressors {
+		client.RegisterCompressor(compressor)
+	}
+
+	for _, encoding := range []string{"gzip", "br", "zstd", "snappy"} {
+		t.Run(encoding, func(t *testing.T) {
+			client := client.NewInstance().SetRequestCompression(encoding)
+
+			var result map[string]string
+			if _, err := client.Post(context.Background(), "/", nil, payload, &result); err != nil {
+				t.Fatalf("Expected no error, got %v", err)
+			}
+			if result["message"] != payload["message"] {
+				t.Errorf("Expected round-tripped body, got %d bytes", len(result["message"]))
+			}
+		})
+	}
+
+	_, err := client.NewInstance().SetRequestCompression("lz4").Post(context.Background(), "/", nil, payload, nil)
+	var configErr *errors.ConfigError
+	if !stderrors.As(err, &configErr) {
+		t.Errorf("Expected ConfigError for unregistered encoding, got %v", err)
+	}
+}
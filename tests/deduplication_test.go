@@ -0,0 +1,163 @@
+package tests
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/fourth-ally/gofetch/infrastructure"
+)
+
+func TestDeduplicationCollapsesConcurrentGets(t *testing.T) {
+	var calls int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&calls, 1)
+		time.Sleep(100 * time.Millisecond)
+		json.NewEncoder(w).Encode(TestUser{ID: 1, Name: "Shared"})
+	}))
+	defer server.Close()
+
+	client := infrastructure.NewClient().
+		SetBaseURL(server.URL).
+		SetDeduplication(true)
+
+	const callers = 5
+	users := make([]TestUser, callers)
+	errs := make([]error, callers)
+
+	var wg sync.WaitGroup
+	for i := 0; i < callers; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			_, errs[i] = client.Get(context.Background(), "/users/1", nil, &users[i])
+		}(i)
+	}
+	wg.Wait()
+
+	if got := atomic.LoadInt32(&calls); got != 1 {
+		t.Errorf("Expected 1 upstream request, got %d", got)
+	}
+
+	for i := 0; i < callers; i++ {
+		if errs[i] != nil {
+			t.Errorf("Caller %d: expected no error, got %v", i, errs[i])
+		}
+		if users[i].Name != "Shared" {
+			t.Errorf("Caller %d: expected name 'Shared', got %q", i, users[i].Name)
+		}
+	}
+}
+
+func TestDeduplicationKeepsDistinctURLsSeparate(t *testing.T) {
+	var calls int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&calls, 1)
+		time.Sleep(50 * time.Millisecond)
+	}))
+	defer server.Close()
+
+	client := infrastructure.NewClient().
+		SetBaseURL(server.URL).
+		SetDeduplication(true)
+
+	var wg sync.WaitGroup
+	for _, path := range []string{"/a", "/b"} {
+		wg.Add(1)
+		go func(path string) {
+			defer wg.Done()
+			client.Get(context.Background(), path, nil, nil)
+		}(path)
+	}
+	wg.Wait()
+
+	if got := atomic.LoadInt32(&calls); got != 2 {
+		t.Errorf("Expected 2 upstream requests, got %d", got)
+	}
+}
+
+func TestDeduplicationWithRequestIDs(t *testing.T) {
+	var calls int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&calls, 1)
+		if r.Header.Get("X-Request-ID") == "" {
+			t.Error("Expected the shared request to carry a request ID")
+		}
+		time.Sleep(100 * time.Millisecond)
+		json.NewEncoder(w).Encode(TestUser{ID: 1})
+	}))
+	defer server.Close()
+
+	client := infrastructure.NewClient().
+		SetBaseURL(server.URL).
+		SetDeduplication(true).
+		SetRequestIDHeader("X-Request-ID")
+
+	var wg sync.WaitGroup
+	for i := 0; i < 5; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if _, err := client.Get(context.Background(), "/users/1", nil, nil); err != nil {
+				t.Errorf("Expected no error, got %v", err)
+			}
+		}()
+	}
+	wg.Wait()
+
+	if got := atomic.LoadInt32(&calls); got != 1 {
+		t.Errorf("Expected request IDs not to defeat deduplication, got %d upstream requests", got)
+	}
+}
+
+func TestDeduplicationHonorsEachCallersContext(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		time.Sleep(300 * time.Millisecond)
+		json.NewEncoder(w).Encode(TestUser{ID: 1, Name: "Shared"})
+	}))
+	defer server.Close()
+
+	client := infrastructure.NewClient().
+		SetBaseURL(server.URL).
+		SetDeduplication(true)
+
+	// The leader gives up while a follower is still waiting
+	leaderCtx, cancelLeader := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancelLeader()
+	leaderDone := make(chan error, 1)
+	go func() {
+		_, err := client.Get(leaderCtx, "/users/1", nil, nil)
+		leaderDone <- err
+	}()
+	time.Sleep(10 * time.Millisecond)
+
+	var user TestUser
+	if _, err := client.Get(context.Background(), "/users/1", nil, &user); err != nil {
+		t.Errorf("Expected the follower not to inherit the leader's cancellation, got %v", err)
+	}
+	if user.Name != "Shared" {
+		t.Errorf("Expected the follower to get the response, got %+v", user)
+	}
+	if err := <-leaderDone; err == nil {
+		t.Error("Expected the leader to fail with its deadline")
+	}
+
+	// A follower stops waiting when its own context ends
+	go client.Get(context.Background(), "/users/1", nil, nil)
+	time.Sleep(10 * time.Millisecond)
+
+	followerCtx, cancelFollower := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancelFollower()
+	started := time.Now()
+	if _, err := client.Get(followerCtx, "/users/1", nil, nil); err == nil {
+		t.Error("Expected the follower to fail with its deadline")
+	}
+	if elapsed := time.Since(started); elapsed > 200*time.Millisecond {
+		t.Errorf("Expected the follower to stop waiting at its deadline, took %s", elapsed)
+	}
+}
@@ -0,0 +1,83 @@
+package tests
+
+import (
+	"context"
+	"crypto/md5"
+	"crypto/sha256"
+	"encoding/base64"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/fourth-ally/gofetch"
+	"github.com/fourth-ally/gofetch/domain/models"
+	"github.com/fourth-ally/gofetch/infrastructure"
+)
+
+func TestContentDigestHeaders(t *testing.T) {
+	var body []byte
+	var header http.Header
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ = io.ReadAll(r.Body)
+		header = r.Header.Clone()
+	}))
+	defer server.Close()
+
+	client := infrastructure.NewClient().
+		SetBaseURL(server.URL).
+		SetContentDigest(models.DigestMD5, models.DigestSHA256)
+
+	if _, err := client.Post(context.Background(), "/upload", nil, TestUser{ID: 1, Name: "John"}, nil); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	md5Sum := md5.Sum(body)
+	if got, want := header.Get("Content-MD5"), base64.StdEncoding.EncodeToString(md5Sum[:]); got != want {
+		t.Errorf("Expected Content-MD5 %q, got %q", want, got)
+	}
+
+	shaSum := sha256.Sum256(body)
+	if got, want := header.Get("Content-Digest"), "sha-256=:"+base64.StdEncoding.EncodeToString(shaSum[:])+":"; got != want {
+		t.Errorf("Expected Content-Digest %q, got %q", want, got)
+	}
+
+	client.Get(context.Background(), "/download", nil, nil)
+	if header.Get("Content-Digest") != "" {
+		t.Error("Expected no digest for requests without a body")
+	}
+}
+
+func TestContentDigestOfStreamedBodies(t *testing.T) {
+	var body []byte
+	var header http.Header
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ = io.ReadAll(r.Body)
+		header = r.Header.Clone()
+	}))
+	defer server.Close()
+
+	client := infrastructure.NewClient().
+		SetBaseURL(server.URL).
+		SetContentDigest(models.DigestSHA256)
+
+	path := filepath.Join(t.TempDir(), "report.csv")
+	if err := os.WriteFile(path, []byte("id,name\n1,John\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	seeker := struct{ io.ReadSeeker }{strings.NewReader("seekable payload")}
+
+	for _, upload := range []interface{}{gofetch.FileBody(path), seeker} {
+		if _, err := client.Put(context.Background(), "/upload", nil, upload, nil); err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+
+		shaSum := sha256.Sum256(body)
+		if got, want := header.Get("Content-Digest"), "sha-256=:"+base64.StdEncoding.EncodeToString(shaSum[:])+":"; got != want || len(body) == 0 {
+			t.Errorf("Expected Content-Digest %q for %q, got %q", want, body, got)
+		}
+	}
+}
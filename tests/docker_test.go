@@ -0,0 +1,134 @@
+package tests
+
+import (
+	"bytes"
+	"context"
+	"encoding/binary"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/fourth-ally/gofetch"
+	"github.com/fourth-ally/gofetch/domain/models"
+	"github.com/fourth-ally/gofetch/infrastructure"
+)
+
+// newUnixServer starts a test server listening on a Unix socket.
+func newUnixServer(t *testing.T, handler http.Handler) string {
+	socket := filepath.Join(t.TempDir(), "docker.sock")
+	listener, err := net.Listen("unix", socket)
+	if err != nil {
+		t.Skipf("Unix sockets unavailable: %v", err)
+	}
+
+	server := httptest.NewUnstartedServer(handler)
+	server.Listener = listener
+	server.Start()
+	t.Cleanup(server.Close)
+	return socket
+}
+
+func dockerFrame(stream byte, payload string) []byte {
+	frame := make([]byte, 8, 8+len(payload))
+	frame[0] = stream
+	binary.BigEndian.PutUint32(frame[4:], uint32(len(payload)))
+	return append(frame, payload...)
+}
+
+func TestDockerClientOverUnixSocket(t *testing.T) {
+	socket := newUnixServer(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`{"ApiVersion":"1.45"}`))
+	}))
+
+	var version struct {
+		ApiVersion string
+	}
+	if _, err := gofetch.NewDockerClient(socket).Get(context.Background(), "/version", nil, &version); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if version.ApiVersion != "1.45" {
+		t.Errorf("Expected API version 1.45, got %q", version.ApiVersion)
+	}
+}
+
+func TestStreamDockerMessages(t *testing.T) {
+	socket := newUnixServer(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost || r.URL.Query().Get("fromImage") != "alpine" {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		w.Write([]byte(`{"status":"Pulling fs layer","id":"a1"}` + "\n" +
+			`{"status":"Downloading","id":"a1","progressDetail":{"current":5,"total":10}}` + "\n" +
+			`{"error":"manifest unknown","errorDetail":{"message":"manifest unknown"}}` + "\n"))
+	}))
+
+	var messages []models.DockerMessage
+	err := gofetch.NewDockerClient(socket).StreamDockerMessages(context.Background(), http.MethodPost, "/images/create",
+		map[string]interface{}{"fromImage": "alpine"}, nil, func(message models.DockerMessage) error {
+			messages = append(messages, message)
+			return nil
+		})
+
+	if err == nil || !strings.Contains(err.Error(), "manifest unknown") {
+		t.Errorf("Expected in-stream error, got %v", err)
+	}
+	if len(messages) != 2 || messages[1].ProgressDetail.Total != 10 {
+		t.Errorf("Unexpected messages: %+v", messages)
+	}
+}
+
+func TestUpgradeAndDemuxDockerStream(t *testing.T) {
+	socket := newUnixServer(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Upgrade") != "tcp" {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+
+		conn, buf, err := w.(http.Hijacker).Hijack()
+		if err != nil {
+			return
+		}
+		defer conn.Close()
+
+		buf.WriteString("HTTP/1.1 101 UPGRADED\r\nConnection: Upgrade\r\nUpgrade: tcp\r\n\r\n")
+		buf.Flush()
+
+		line, _ := buf.ReadString('\n')
+		conn.Write(dockerFrame(1, "echo: "+line))
+		conn.Write(dockerFrame(2, "warning\n"))
+	}))
+
+	conn, err := gofetch.NewDockerClient(socket).Upgrade(context.Background(), http.MethodPost, "/containers/:id/attach",
+		map[string]interface{}{"id": "c1", "stream": 1, "stdin": 1, "stdout": 1, "stderr": 1}, nil, "tcp")
+	if err != nil {
+		t.Fatalf("Expected upgraded connection, got %v", err)
+	}
+	defer conn.Close()
+
+	if _, err := conn.Write([]byte("hello\n")); err != nil {
+		t.Fatalf("Expected writable connection, got %v", err)
+	}
+
+	var stdout, stderr bytes.Buffer
+	if err := infrastructure.DemuxDockerStream(conn, &stdout, &stderr); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if stdout.String() != "echo: hello\n" || stderr.String() != "warning\n" {
+		t.Errorf("Unexpected output: stdout %q, stderr %q", stdout.String(), stderr.String())
+	}
+}
+
+func TestUpgradeRejected(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNotFound)
+	}))
+	defer server.Close()
+
+	_, err := infrastructure.NewClient().SetBaseURL(server.URL).Upgrade(context.Background(), http.MethodPost, "/exec/x/start", nil, nil, "tcp")
+	if err == nil || !strings.Contains(err.Error(), "404") {
+		t.Errorf("Expected HTTP 404 error, got %v", err)
+	}
+}
@@ -0,0 +1,322 @@
+package tests
+
+import (
+	"bytes"
+	"context"
+	"crypto/md5"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	stderrors "errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/fourth-ally/gofetch/domain/errors"
+	"github.com/fourth-ally/gofetch/domain/models"
+	"github.com/fourth-ally/gofetch/infrastructure"
+)
+
+func TestDownloadStreamsToWriter(t *testing.T) {
+	payload := strings.Repeat("0123456789", 100000)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/files/report.csv" {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		w.Header().Set("Content-Type", "text/csv")
+		w.Header().Set("Content-Length", fmt.Sprint(len(payload)))
+		w.Write([]byte(payload))
+	}))
+	defer server.Close()
+
+	var transferred, total int64
+	client := infrastructure.NewClient().
+		SetBaseURL(server.URL).
+		SetDownloadProgress(func(n, t int64) { transferred, total = n, t })
+
+	var buf bytes.Buffer
+	resp, err := client.Download(context.Background(), "/files/:name", map[string]interface{}{"name": "report.csv"}, &buf)
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	if buf.String() != payload {
+		t.Errorf("Expected %d bytes written, got %d", len(payload), buf.Len())
+	}
+	if resp.StatusCode != http.StatusOK || resp.Headers.Get("Content-Type") != "text/csv" || resp.RawBody != nil {
+		t.Errorf("Unexpected response: %d %v", resp.StatusCode, resp.Headers)
+	}
+	if transferred != int64(len(payload)) || total != int64(len(payload)) {
+		t.Errorf("Expected progress %d of %d, got %d of %d", len(payload), len(payload), transferred, total)
+	}
+}
+
+func TestDownloadErrors(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/missing" {
+			w.WriteHeader(http.StatusNotFound)
+			w.Write([]byte("not found"))
+			return
+		}
+		w.Write([]byte("data"))
+	}))
+	defer server.Close()
+
+	client := infrastructure.NewClient().SetBaseURL(server.URL)
+
+	var buf bytes.Buffer
+	_, err := client.Download(context.Background(), "/missing", nil, &buf)
+	var httpErr *errors.HTTPError
+	if !stderrors.As(err, &httpErr) || httpErr.StatusCode != http.StatusNotFound {
+		t.Errorf("Expected HTTP 404 error, got %v", err)
+	}
+	if buf.Len() != 0 {
+		t.Errorf("Expected nothing written for an error response, got %q", buf.String())
+	}
+
+	_, err = client.Download(context.Background(), "/", nil, failingWriter{})
+	var transportErr *errors.TransportError
+	if err == nil || !strings.Contains(err.Error(), "disk full") || stderrors.As(err, &transportErr) {
+		t.Errorf("Expected write error not classified as transport error, got %v", err)
+	}
+}
+
+func TestDownloadFileRenamesAtomically(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/export":
+			w.Header().Set("Content-Disposition", `attachment; filename="../../report.csv"`)
+			w.Write([]byte("a,b\n1,2\n"))
+		case "/plain/data.bin":
+			w.Write([]byte("binary"))
+		default:
+			w.Header().Set("Content-Length", "100")
+			w.Write([]byte("partial"))
+		}
+	}))
+	defer server.Close()
+
+	dir := t.TempDir()
+	client := infrastructure.NewClient().SetBaseURL(server.URL)
+
+	dest := filepath.Join(dir, "out.csv")
+	resp, err := client.DownloadFile(context.Background(), "/export", nil, dest, nil)
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if data, _ := os.ReadFile(dest); string(data) != "a,b\n1,2\n" || resp.File != dest {
+		t.Errorf("Expected body saved to %s, got %q in %s", dest, data, resp.File)
+	}
+
+	options := models.NewDownloadFileOptions()
+	options.UseContentDisposition = true
+	resp, err = client.DownloadFile(context.Background(), "/export", nil, dir, options)
+	if err != nil || resp.File != filepath.Join(dir, "report.csv") {
+		t.Fatalf("Expected Content-Disposition name inside the directory, got %v, %v", resp, err)
+	}
+	resp, err = client.DownloadFile(context.Background(), "/plain/data.bin", nil, dir, options)
+	if err != nil || resp.File != filepath.Join(dir, "data.bin") {
+		t.Fatalf("Expected URL path name, got %v, %v", resp, err)
+	}
+
+	// A truncated body leaves neither the destination nor a temporary file
+	if _, err := client.DownloadFile(context.Background(), "/broken", nil, filepath.Join(dir, "broken"), nil); err == nil {
+		t.Fatal("Expected truncated download to fail")
+	}
+	entries, _ := os.ReadDir(dir)
+	if len(entries) != 3 {
+		t.Errorf("Expected only the 3 completed downloads, got %d entries", len(entries))
+	}
+}
+
+func TestDownloadFileVerifiesChecksums(t *testing.T) {
+	payload := []byte("release artifact")
+	sha := sha256.Sum256(payload)
+	md := md5.Sum(payload)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/digest":
+			w.Header().Set("Content-Digest", "sha-256=:"+base64.StdEncoding.EncodeToString(sha[:])+":")
+			w.Header().Set("Content-MD5", base64.StdEncoding.EncodeToString(md[:]))
+			w.Write(payload)
+		case "/tampered":
+			w.Header().Set("Digest", "SHA-256="+base64.StdEncoding.EncodeToString(sha[:]))
+			w.Write([]byte("tampered artifact"))
+		default:
+			w.Write(payload)
+		}
+	}))
+	defer server.Close()
+
+	dir := t.TempDir()
+	client := infrastructure.NewClient().SetBaseURL(server.URL)
+
+	options := models.NewDownloadFileOptions()
+	options.Checksum = &models.Checksum{Algorithm: models.DigestSHA256, Value: hex.EncodeToString(sha[:])}
+	if _, err := client.DownloadFile(context.Background(), "/plain", nil, filepath.Join(dir, "plain"), options); err != nil {
+		t.Fatalf("Expected matching checksum, got %v", err)
+	}
+
+	options = models.NewDownloadFileOptions()
+	options.VerifyDigest = true
+	if _, err := client.DownloadFile(context.Background(), "/digest", nil, filepath.Join(dir, "digest"), options); err != nil {
+		t.Fatalf("Expected matching header digests, got %v", err)
+	}
+
+	_, err := client.DownloadFile(context.Background(), "/tampered", nil, filepath.Join(dir, "tampered"), options)
+	var mismatch *errors.ChecksumMismatchError
+	if !stderrors.As(err, &mismatch) || mismatch.Source != "Digest" || mismatch.Expected != hex.EncodeToString(sha[:]) {
+		t.Fatalf("Expected checksum mismatch from the Digest header, got %v", err)
+	}
+	if _, err := os.Stat(filepath.Join(dir, "tampered")); !os.IsNotExist(err) {
+		t.Error("Expected tampered download not to be saved")
+	}
+
+	options = models.NewDownloadFileOptions()
+	options.Checksum = &models.Checksum{Algorithm: models.DigestMD5, Value: "not hex"}
+	var configErr *errors.ConfigError
+	if _, err := client.DownloadFile(context.Background(), "/plain", nil, filepath.Join(dir, "bad"), options); !stderrors.As(err, &configErr) {
+		t.Errorf("Expected config error for a malformed checksum, got %v", err)
+	}
+}
+
+func TestDownloadFileInParallelRanges(t *testing.T) {
+	payload := strings.Repeat("abcdefghij", 10000)
+	sum := sha256.Sum256([]byte(payload))
+
+	var mu sync.Mutex
+	var ranges []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == http.MethodGet {
+			mu.Lock()
+			ranges = append(ranges, r.Header.Get("Range"))
+			mu.Unlock()
+		}
+		w.Header().Set("ETag", `"v1"`)
+		http.ServeContent(w, r, "data.bin", time.Time{}, strings.NewReader(payload))
+	}))
+	defer server.Close()
+
+	var progressMu sync.Mutex
+	var transferred, total int64
+	client := infrastructure.NewClient().
+		SetBaseURL(server.URL).
+		SetDownloadProgress(func(n, t int64) {
+			progressMu.Lock()
+			transferred, total = n, t
+			progressMu.Unlock()
+		})
+
+	options := models.NewDownloadFileOptions()
+	options.Parallel = 4
+	options.Checksum = &models.Checksum{Algorithm: models.DigestSHA256, Value: hex.EncodeToString(sum[:])}
+
+	dest := filepath.Join(t.TempDir(), "data.bin")
+	if _, err := client.DownloadFile(context.Background(), "/data.bin", nil, dest, options); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if data, _ := os.ReadFile(dest); string(data) != payload {
+		t.Errorf("Expected the reassembled payload, got %d bytes", len(data))
+	}
+	if len(ranges) != 4 {
+		t.Errorf("Expected 4 range requests, got %q", ranges)
+	}
+	if transferred != int64(len(payload)) || total != int64(len(payload)) {
+		t.Errorf("Expected aggregate progress %d of %d, got %d of %d", len(payload), len(payload), transferred, total)
+	}
+}
+
+func TestDownloadFileParallelFallsBackWithoutRanges(t *testing.T) {
+	requests := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		w.Write([]byte("no ranges here"))
+	}))
+	defer server.Close()
+
+	options := models.NewDownloadFileOptions()
+	options.Parallel = 4
+	dest := filepath.Join(t.TempDir(), "plain.txt")
+	client := infrastructure.NewClient().SetBaseURL(server.URL)
+	if _, err := client.DownloadFile(context.Background(), "/plain.txt", nil, dest, options); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if data, _ := os.ReadFile(dest); string(data) != "no ranges here" || requests != 2 {
+		t.Errorf("Expected a HEAD and a single GET, got %q after %d requests", data, requests)
+	}
+}
+
+func TestStreamsHoldBulkheadSlot(t *testing.T) {
+	unblock := make(chan struct{})
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte("{}\n"))
+		if r.URL.Path == "/events" {
+			w.(http.Flusher).Flush()
+			<-unblock
+		}
+	}))
+	defer server.Close()
+	defer close(unblock)
+
+	client := infrastructure.NewClient().
+		SetBaseURL(server.URL).
+		SetMaxConcurrentRequests(1)
+
+	started := make(chan struct{})
+	go client.StreamNDJSON(context.Background(), "/events", nil, func([]byte) error {
+		close(started)
+		<-unblock
+		return nil
+	})
+	<-started
+
+	ctx, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
+	defer cancel()
+	var buf bytes.Buffer
+	if _, err := client.Download(ctx, "/file", nil, &buf); !stderrors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("Expected the download to wait for the stream's slot, got %v", err)
+	}
+}
+
+func TestStreamsFailOverAndRespectCircuitBreaker(t *testing.T) {
+	down := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusBadGateway)
+	}))
+	defer down.Close()
+
+	up := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte("payload"))
+	}))
+	defer up.Close()
+
+	client := infrastructure.NewClient().SetBaseURLs([]string{down.URL, up.URL})
+	var buf bytes.Buffer
+	if _, err := client.Download(context.Background(), "/file", nil, &buf); err != nil {
+		t.Fatalf("Expected the download to fail over, got %v", err)
+	}
+	if buf.String() != "payload" {
+		t.Errorf("Expected the mirror's payload, got %q", buf.String())
+	}
+
+	client = infrastructure.NewClient().
+		SetBaseURL(down.URL).
+		SetRetryOptions(&models.RetryOptions{
+			CircuitBreaker:          true,
+			CircuitBreakerThreshold: 1,
+			CircuitBreakerTimeout:   time.Minute,
+		})
+	client.Download(context.Background(), "/file", nil, &buf)
+
+	_, err := client.Download(context.Background(), "/file", nil, &buf)
+	var circuitErr *errors.CircuitOpenError
+	if !stderrors.As(err, &circuitErr) {
+		t.Errorf("Expected the open circuit to block the download, got %v", err)
+	}
+}
@@ -0,0 +1,73 @@
+package tests
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/fourth-ally/gofetch/domain/models"
+	"github.com/fourth-ally/gofetch/infrastructure"
+)
+
+func TestEffectiveConfigRedactsSecrets(t *testing.T) {
+	client := infrastructure.NewClient().
+		SetBaseURL("https://api.example.com").
+		SetHeader("Authorization", "Bearer secret-token").
+		SetHeader("X-Request-Source", "tests").
+		SetIdempotencyKeys(true)
+
+	snapshot := client.EffectiveConfig()
+
+	if snapshot.Headers["Authorization"] != "[REDACTED]" {
+		t.Errorf("Expected Authorization to be redacted, got %q", snapshot.Headers["Authorization"])
+	}
+	if snapshot.Headers["X-Request-Source"] != "tests" {
+		t.Errorf("Expected plain header to be kept, got %q", snapshot.Headers["X-Request-Source"])
+	}
+
+	dump := snapshot.String()
+	if strings.Contains(dump, "secret-token") {
+		t.Errorf("Expected dump to hide secrets, got:\n%s", dump)
+	}
+	for _, want := range []string{"baseURL: https://api.example.com", "timeout: 30s", "idempotency-keys"} {
+		if !strings.Contains(dump, want) {
+			t.Errorf("Expected dump to contain %q, got:\n%s", want, dump)
+		}
+	}
+}
+
+func TestEffectiveConfigListsOverrides(t *testing.T) {
+	client := infrastructure.NewClient().SetHeader("Accept", "application/json")
+
+	snapshot := client.EffectiveConfig(
+		infrastructure.WithTimeout(2*time.Second),
+		infrastructure.WithPriority(models.PriorityHigh),
+	)
+
+	if snapshot.Timeout != 2*time.Second {
+		t.Errorf("Expected per-request timeout, got %v", snapshot.Timeout)
+	}
+	if snapshot.Headers["Accept"] != "application/json" {
+		t.Errorf("Expected client default header to be merged, got %v", snapshot.Headers)
+	}
+
+	want := []string{"priority: 0 -> 1", "timeout: 30s -> 2s"}
+	if strings.Join(snapshot.Overrides, "|") != strings.Join(want, "|") {
+		t.Errorf("Expected overrides %v, got %v", want, snapshot.Overrides)
+	}
+
+	if client.EffectiveConfig().Timeout != 30*time.Second {
+		t.Error("Expected EffectiveConfig not to change the client")
+	}
+}
+
+func TestRequestPriorityOverridesClientPriority(t *testing.T) {
+	client := infrastructure.NewClient().SetPriority(models.PriorityLow)
+
+	if got := client.EffectiveConfig().Priority; got != models.PriorityLow {
+		t.Errorf("Expected the client priority, got %d", got)
+	}
+	if got := client.EffectiveConfig(infrastructure.WithPriority(models.PriorityNormal)).Priority; got != models.PriorityNormal {
+		t.Errorf("Expected WithPriority(PriorityNormal) to override the client priority, got %d", got)
+	}
+}
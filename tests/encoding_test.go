@@ -0,0 +1,71 @@
+package tests
+
+import (
+	"compress/gzip"
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/fourth-ally/gofetch/infrastructure"
+)
+
+func newGzipServer(seen *string) *httptest.Server {
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		*seen = r.Header.Get("Accept-Encoding")
+		if *seen != "gzip" {
+			w.Write([]byte(`{"id":1}`))
+			return
+		}
+
+		w.Header().Set("Content-Encoding", "gzip")
+		gz := gzip.NewWriter(w)
+		gz.Write([]byte(`{"id":1}`))
+		gz.Close()
+	}))
+}
+
+func TestTransparentDecompressionIsReported(t *testing.T) {
+	var seen string
+	server := newGzipServer(&seen)
+	defer server.Close()
+
+	client := infrastructure.NewClient().SetBaseURL(server.URL)
+
+	var user TestUser
+	resp, err := client.Get(context.Background(), "/users/1", nil, &user)
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	if !resp.Decompressed || user.ID != 1 {
+		t.Errorf("Expected decompressed body, got decompressed=%v id=%d", resp.Decompressed, user.ID)
+	}
+}
+
+func TestAcceptEncodingIdentityPerRequest(t *testing.T) {
+	var seen string
+	server := newGzipServer(&seen)
+	defer server.Close()
+
+	client := infrastructure.NewClient().SetBaseURL(server.URL)
+
+	ctx := infrastructure.WithAcceptEncodingContext(context.Background(), infrastructure.EncodingIdentity)
+	resp, err := client.Get(ctx, "/users/1", nil, nil)
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	if seen != "identity" {
+		t.Errorf("Expected Accept-Encoding identity, got %q", seen)
+	}
+	if resp.Decompressed {
+		t.Error("Expected identity response not to be decompressed")
+	}
+
+	client.SetAcceptEncoding(infrastructure.EncodingIdentity)
+	client.Get(context.Background(), "/users/1", nil, nil)
+	if seen != "identity" {
+		t.Errorf("Expected client-wide Accept-Encoding identity, got %q", seen)
+	}
+}
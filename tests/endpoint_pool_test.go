@@ -0,0 +1,108 @@
+package tests
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/fourth-ally/gofetch/domain/models"
+	"github.com/fourth-ally/gofetch/infrastructure"
+)
+
+func TestEndpointPoolRoundRobin(t *testing.T) {
+	hits := map[string]int{}
+	newServer := func(name string) *httptest.Server {
+		return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			hits[name]++
+			w.Write([]byte(`{}`))
+		}))
+	}
+
+	first, second := newServer("first"), newServer("second")
+	defer first.Close()
+	defer second.Close()
+
+	client := infrastructure.NewClient().SetEndpointPool([]models.Endpoint{
+		{URL: first.URL},
+		{URL: second.URL},
+	}, nil)
+
+	for i := 0; i < 4; i++ {
+		if _, err := client.Get(context.Background(), "/ping", nil, nil); err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+	}
+
+	if hits["first"] != 2 || hits["second"] != 2 {
+		t.Errorf("Expected requests split evenly, got %v", hits)
+	}
+}
+
+func TestEndpointPoolMarksFailingEndpointDown(t *testing.T) {
+	broken := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusBadGateway)
+	}))
+	defer broken.Close()
+
+	healthyCalls := 0
+	healthy := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		healthyCalls++
+		w.Write([]byte(`{}`))
+	}))
+	defer healthy.Close()
+
+	client := infrastructure.NewClient().SetEndpointPool([]models.Endpoint{
+		{URL: broken.URL},
+		{URL: healthy.URL},
+	}, &models.EndpointPoolOptions{
+		Strategy:         models.BalanceRoundRobin,
+		FailureThreshold: 1,
+		Cooldown:         time.Minute,
+	})
+
+	client.Get(context.Background(), "/ping", nil, nil)
+	for i := 0; i < 3; i++ {
+		if _, err := client.Get(context.Background(), "/ping", nil, nil); err != nil {
+			t.Fatalf("Expected healthy endpoint to serve request, got %v", err)
+		}
+	}
+
+	if healthyCalls != 3 {
+		t.Errorf("Expected 3 requests to healthy endpoint, got %d", healthyCalls)
+	}
+
+	statuses := client.EndpointStatuses()
+	if statuses[0].Healthy || statuses[0].Failures != 1 {
+		t.Errorf("Expected broken endpoint marked down with 1 failure, got %+v", statuses[0])
+	}
+	if !statuses[1].Healthy {
+		t.Errorf("Expected healthy endpoint to stay up, got %+v", statuses[1])
+	}
+}
+
+func TestEndpointPoolWeightedRandom(t *testing.T) {
+	hits := map[string]int{}
+	heavy := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		hits["heavy"]++
+	}))
+	defer heavy.Close()
+	light := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		hits["light"]++
+	}))
+	defer light.Close()
+
+	client := infrastructure.NewClient().SetEndpointPool([]models.Endpoint{
+		{URL: heavy.URL, Weight: 9},
+		{URL: light.URL, Weight: 1},
+	}, &models.EndpointPoolOptions{Strategy: models.BalanceWeightedRandom, FailureThreshold: 3})
+
+	for i := 0; i < 200; i++ {
+		client.Get(context.Background(), "/ping", nil, nil)
+	}
+
+	if hits["heavy"] <= hits["light"]*3 {
+		t.Errorf("Expected weighted endpoint to receive most traffic, got %v", hits)
+	}
+}
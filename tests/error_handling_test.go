@@ -2,11 +2,18 @@ package tests
 
 import (
 	"context"
+	stderrors "errors"
 	"net/http"
 	"net/http/httptest"
+	"os"
+	"strconv"
+	"strings"
 	"testing"
+	"time"
 
+	"github.com/fourth-ally/gofetch"
 	"github.com/fourth-ally/gofetch/domain/errors"
+	"github.com/fourth-ally/gofetch/domain/models"
 	"github.com/fourth-ally/gofetch/infrastructure"
 )
 
@@ -71,3 +78,225 @@ func TestCustomStatusValidator(t *testing.T) {
 		t.Fatalf("Expected no error, got %v", err)
 	}
 }
+
+func TestErrorBodyLimitTruncatesLargeBodies(t *testing.T) {
+	page := strings.Repeat("<html>oops</html>", 1000)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Length", strconv.Itoa(len(page)))
+		w.WriteHeader(http.StatusInternalServerError)
+		w.Write([]byte(page))
+	}))
+	defer server.Close()
+
+	client := infrastructure.NewClient().
+		SetBaseURL(server.URL).
+		SetErrorBodyLimit(&models.ErrorBodyOptions{MaxBytes: 100})
+
+	_, err := client.Get(context.Background(), "/", nil, nil)
+
+	var httpErr *errors.HTTPError
+	if !stderrors.As(err, &httpErr) {
+		t.Fatalf("Expected HTTPError, got %v", err)
+	}
+	if len(httpErr.Body) != 100 || !httpErr.Truncated {
+		t.Errorf("Expected body truncated to 100 bytes, got %d (truncated=%v)", len(httpErr.Body), httpErr.Truncated)
+	}
+	if httpErr.BodyLength != int64(len(page)) {
+		t.Errorf("Expected full length %d, got %d", len(page), httpErr.BodyLength)
+	}
+	if !strings.HasSuffix(httpErr.Preview(), "...") {
+		t.Errorf("Expected preview to mark truncation, got %q", httpErr.Preview())
+	}
+	if httpErr.BodyFile != "" {
+		t.Errorf("Expected no spill file, got %q", httpErr.BodyFile)
+	}
+}
+
+func TestErrorBodyLimitSpillsToFile(t *testing.T) {
+	page := strings.Repeat("x", 5000)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusBadGateway)
+		w.Write([]byte(page))
+	}))
+	defer server.Close()
+
+	client := infrastructure.NewClient().
+		SetBaseURL(server.URL).
+		SetErrorBodyLimit(&models.ErrorBodyOptions{MaxBytes: 10, SpillToFile: true, SpillDir: t.TempDir()})
+
+	_, err := client.Get(context.Background(), "/", nil, nil)
+
+	var httpErr *errors.HTTPError
+	if !stderrors.As(err, &httpErr) {
+		t.Fatalf("Expected HTTPError, got %v", err)
+	}
+
+	spilled, readErr := os.ReadFile(httpErr.BodyFile)
+	if readErr != nil {
+		t.Fatalf("Expected spilled body file, got %v", readErr)
+	}
+	if string(spilled) != page || httpErr.BodyLength != int64(len(page)) {
+		t.Errorf("Expected complete body in spill file, got %d bytes (length %d)", len(spilled), httpErr.BodyLength)
+	}
+}
+
+func TestMaxResponseSize(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body := strings.Repeat("x", 2048)
+		if r.URL.Path == "/declared" {
+			w.Header().Set("Content-Length", "2048")
+		} else {
+			w.(http.Flusher).Flush()
+		}
+		w.Write([]byte(body))
+	}))
+	defer server.Close()
+
+	client := infrastructure.NewClient().SetBaseURL(server.URL).SetMaxResponseSize(1024)
+
+	for path, contentLength := range map[string]int64{"/declared": 2048, "/chunked": -1} {
+		_, err := client.Get(context.Background(), path, nil, nil)
+		var tooLarge *errors.ResponseTooLargeError
+		if !stderrors.As(err, &tooLarge) {
+			t.Fatalf("%s: expected ResponseTooLargeError, got %v", path, err)
+		}
+		if tooLarge.Limit != 1024 || tooLarge.ContentLength != contentLength {
+			t.Errorf("%s: unexpected error %+v", path, tooLarge)
+		}
+	}
+
+	client.SetMaxResponseSize(2048)
+	resp, err := client.Get(context.Background(), "/chunked", nil, nil)
+	if err != nil || len(resp.RawBody) != 2048 {
+		t.Errorf("Expected a body at the limit to be read, got %v", err)
+	}
+}
+
+type apiError struct {
+	Code    string `json:"code"`
+	Message string `json:"message"`
+}
+
+func TestSetErrorDecodesErrorBody(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		if r.URL.Path == "/html" {
+			w.Header().Set("Content-Type", "text/html")
+			w.WriteHeader(http.StatusBadGateway)
+			w.Write([]byte("<html>bad gateway</html>"))
+			return
+		}
+		w.WriteHeader(http.StatusUnprocessableEntity)
+		w.Write([]byte(`{"code":"invalid_email","message":"email is invalid"}`))
+	}))
+	defer server.Close()
+
+	client := infrastructure.NewClient().SetBaseURL(server.URL).SetError(apiError{})
+
+	_, err := client.Get(context.Background(), "/users", nil, nil)
+	var httpErr *errors.HTTPError
+	if !stderrors.As(err, &httpErr) {
+		t.Fatalf("Expected HTTPError, got %v", err)
+	}
+	decoded, ok := httpErr.Decoded.(*apiError)
+	if !ok || decoded.Code != "invalid_email" {
+		t.Errorf("Expected decoded *apiError, got %#v", httpErr.Decoded)
+	}
+
+	_, err = client.Get(context.Background(), "/html", nil, nil)
+	if !stderrors.As(err, &httpErr) || httpErr.Decoded != nil {
+		t.Errorf("Expected undecodable body to leave Decoded nil, got %#v", err)
+	}
+
+	// A per-request target is filled in place and wins over the client type
+	var target apiError
+	_, err = client.GetWithOptions(context.Background(), "/users", nil, nil, infrastructure.WithError(&target))
+	if !stderrors.As(err, &httpErr) || httpErr.Decoded != &target || target.Message != "email is invalid" {
+		t.Errorf("Expected per-request target to be decoded, got %+v, %v", target, err)
+	}
+}
+
+func TestProblemDetails(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/problem+json; charset=utf-8")
+		w.WriteHeader(http.StatusForbidden)
+		w.Write([]byte(`{
+			"type": "https://example.com/probs/out-of-credit",
+			"title": "You do not have enough credit.",
+			"status": 403,
+			"detail": "Your current balance is 30, but that costs 50.",
+			"instance": "/account/12345/msgs/abc",
+			"balance": 30
+		}`))
+	}))
+	defer server.Close()
+
+	client := infrastructure.NewClient().SetBaseURL(server.URL)
+	_, err := client.Get(context.Background(), "/", nil, nil)
+
+	var httpErr *errors.HTTPError
+	if !stderrors.As(err, &httpErr) {
+		t.Fatalf("Expected HTTPError, got %v", err)
+	}
+	problem := httpErr.Problem
+	if problem == nil {
+		t.Fatal("Expected problem details to be parsed")
+	}
+	if problem.Type != "https://example.com/probs/out-of-credit" || problem.Status != 403 || problem.Instance != "/account/12345/msgs/abc" {
+		t.Errorf("Unexpected problem details: %+v", problem)
+	}
+	if problem.Extensions["balance"] != float64(30) {
+		t.Errorf("Expected balance extension, got %v", problem.Extensions)
+	}
+	if want := "HTTP 403: You do not have enough credit.: Your current balance is 30, but that costs 50."; httpErr.Error() != want {
+		t.Errorf("Expected %q, got %q", want, httpErr.Error())
+	}
+}
+
+func TestCodeOf(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/bad-json":
+			w.Write([]byte(`{"id": "not a number"}`))
+		case "/slow":
+			time.Sleep(100 * time.Millisecond)
+		default:
+			w.WriteHeader(http.StatusInternalServerError)
+		}
+	}))
+	defer server.Close()
+
+	client := gofetch.NewClient().SetBaseURL(server.URL)
+
+	var user TestUser
+	_, err := client.Get(context.Background(), "/bad-json", nil, &user)
+	if code := gofetch.CodeOf(err); code != errors.CodeDecode {
+		t.Errorf("Expected %s, got %s (%v)", errors.CodeDecode, code, err)
+	}
+
+	_, err = client.NewInstance().SetTimeout(10*time.Millisecond).Get(context.Background(), "/slow", nil, nil)
+	if code := gofetch.CodeOf(err); code != errors.CodeTimeout {
+		t.Errorf("Expected %s, got %s (%v)", errors.CodeTimeout, code, err)
+	}
+
+	breaker := client.NewInstance().SetRetryOptions(&models.RetryOptions{
+		CircuitBreaker:          true,
+		CircuitBreakerThreshold: 1,
+		CircuitBreakerTimeout:   time.Minute,
+	})
+	_, err = breaker.Get(context.Background(), "/fail", nil, nil)
+	if code := gofetch.CodeOf(err); code != errors.CodeHTTPStatus {
+		t.Errorf("Expected %s, got %s (%v)", errors.CodeHTTPStatus, code, err)
+	}
+	_, err = breaker.Get(context.Background(), "/fail", nil, nil)
+	if code := gofetch.CodeOf(err); code != errors.CodeCircuitOpen {
+		t.Errorf("Expected %s, got %s (%v)", errors.CodeCircuitOpen, code, err)
+	}
+
+	if code := gofetch.CodeOf(stderrors.New("other")); code != errors.CodeUnknown {
+		t.Errorf("Expected %s, got %s", errors.CodeUnknown, code)
+	}
+	if code := gofetch.CodeOf(nil); code != "" {
+		t.Errorf("Expected no code for nil, got %s", code)
+	}
+}
@@ -0,0 +1,130 @@
+package tests
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+
+	"github.com/fourth-ally/gofetch/infrastructure"
+)
+
+func TestFailoverToMirrorOnServerError(t *testing.T) {
+	primary := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusBadGateway)
+	}))
+	defer primary.Close()
+
+	mirror := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`{"id": 1}`))
+	}))
+	defer mirror.Close()
+
+	client := infrastructure.NewClient().SetBaseURLs([]string{primary.URL, mirror.URL})
+
+	resp, err := client.Get(context.Background(), "/users/1", nil, nil)
+	if err != nil {
+		t.Fatalf("Expected failover to succeed, got %v", err)
+	}
+
+	if resp.BaseURL != mirror.URL {
+		t.Errorf("Expected response from mirror %s, got %s", mirror.URL, resp.BaseURL)
+	}
+}
+
+func TestFailoverOnTransportError(t *testing.T) {
+	dead := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+	deadURL := dead.URL
+	dead.Close()
+
+	mirror := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`{}`))
+	}))
+	defer mirror.Close()
+
+	client := infrastructure.NewClient().SetBaseURLs([]string{deadURL, mirror.URL})
+
+	resp, err := client.Get(context.Background(), "/", nil, nil)
+	if err != nil {
+		t.Fatalf("Expected failover to succeed, got %v", err)
+	}
+
+	if resp.BaseURL != mirror.URL {
+		t.Errorf("Expected response from mirror, got %s", resp.BaseURL)
+	}
+}
+
+func TestFailoverStopsOnClientError(t *testing.T) {
+	mirrorCalls := 0
+	primary := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNotFound)
+	}))
+	defer primary.Close()
+
+	mirror := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mirrorCalls++
+	}))
+	defer mirror.Close()
+
+	client := infrastructure.NewClient().SetBaseURLs([]string{primary.URL, mirror.URL})
+
+	if _, err := client.Get(context.Background(), "/missing", nil, nil); err == nil {
+		t.Fatal("Expected 404 error")
+	}
+
+	if mirrorCalls != 0 {
+		t.Errorf("Expected no failover on 4xx, got %d mirror calls", mirrorCalls)
+	}
+}
+
+func TestFailoverOnlyForHostFailures(t *testing.T) {
+	var primaryCalls, mirrorCalls atomic.Int32
+	primary := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		primaryCalls.Add(1)
+		if r.URL.Path == "/broken" {
+			w.WriteHeader(http.StatusBadGateway)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{not json`))
+	}))
+	defer primary.Close()
+
+	mirror := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mirrorCalls.Add(1)
+		w.Write([]byte(`{}`))
+	}))
+	defer mirror.Close()
+
+	client := infrastructure.NewClient().SetBaseURLs([]string{primary.URL, mirror.URL})
+
+	// An undecodable body says nothing about the host
+	var target map[string]interface{}
+	if _, err := client.Post(context.Background(), "/orders", nil, map[string]int{"id": 1}, &target); err == nil {
+		t.Error("Expected the decode error to be returned")
+	}
+	if _, err := client.Get(context.Background(), "/orders", nil, &target); err == nil {
+		t.Error("Expected the decode error to be returned")
+	}
+
+	// A POST that reached the host isn't sent again, even on 5xx
+	client.Post(context.Background(), "/broken", nil, map[string]int{"id": 1}, nil)
+
+	if got := mirrorCalls.Load(); got != 0 {
+		t.Errorf("Expected no failover, got %d mirror requests", got)
+	}
+	if got := primaryCalls.Load(); got != 3 {
+		t.Errorf("Expected 3 primary requests, got %d", got)
+	}
+
+	// A POST whose connection was refused is safe to send to the mirror
+	dead := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+	dead.Close()
+	resp, err := infrastructure.NewClient().
+		SetBaseURLs([]string{dead.URL, mirror.URL}).
+		Post(context.Background(), "/orders", nil, map[string]int{"id": 1}, nil)
+	if err != nil || resp.BaseURL != mirror.URL {
+		t.Errorf("Expected the POST to fail over after a refused connection, got %v, %v", resp, err)
+	}
+}
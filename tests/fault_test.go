@@ -0,0 +1,63 @@
+package tests
+
+import (
+	"context"
+	stderrors "errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/fourth-ally/gofetch/domain/errors"
+	"github.com/fourth-ally/gofetch/domain/models"
+	"github.com/fourth-ally/gofetch/infrastructure"
+)
+
+func TestFaultInjectorErrorStatus(t *testing.T) {
+	calls := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls++
+	}))
+	defer server.Close()
+
+	client := infrastructure.NewClient().
+		SetBaseURL(server.URL).
+		SetFaultInjector(&models.FaultOptions{ErrorRate: 1, ErrorStatus: http.StatusTooManyRequests})
+
+	_, err := client.Get(context.Background(), "/users", nil, nil)
+
+	var httpErr *errors.HTTPError
+	if !stderrors.As(err, &httpErr) || httpErr.StatusCode != http.StatusTooManyRequests {
+		t.Fatalf("Expected injected 429, got %v", err)
+	}
+	if calls != 0 {
+		t.Errorf("Expected server not to be contacted, got %d calls", calls)
+	}
+}
+
+func TestFaultInjectorDropsAndDelays(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+	defer server.Close()
+
+	client := infrastructure.NewClient().
+		SetBaseURL(server.URL).
+		SetFaultInjector(&models.FaultOptions{DropRate: 1})
+
+	if _, err := client.Get(context.Background(), "/users", nil, nil); !stderrors.Is(err, infrastructure.ErrInjectedFault) {
+		t.Errorf("Expected injected drop, got %v", err)
+	}
+
+	client.SetFaultInjector(&models.FaultOptions{LatencyRate: 1, Latency: 50 * time.Millisecond})
+	start := time.Now()
+	if _, err := client.Get(context.Background(), "/users", nil, nil); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if elapsed := time.Since(start); elapsed < 50*time.Millisecond {
+		t.Errorf("Expected injected latency, took %v", elapsed)
+	}
+
+	client.SetFaultInjector(nil)
+	if _, err := client.Get(context.Background(), "/users", nil, nil); err != nil {
+		t.Errorf("Expected no faults once disabled, got %v", err)
+	}
+}
@@ -0,0 +1,81 @@
+package tests
+
+import (
+	"context"
+	stderrors "errors"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+
+	"github.com/fourth-ally/gofetch/domain/errors"
+	"github.com/fourth-ally/gofetch/domain/models"
+	"github.com/fourth-ally/gofetch/infrastructure"
+)
+
+func TestSimpleHeadersOnlyAvoidsPreflightTriggers(t *testing.T) {
+	var received http.Header
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		received = r.Header.Clone()
+	}))
+	defer server.Close()
+
+	client := infrastructure.NewClient().
+		SetBaseURL(server.URL).
+		SetHeader("Authorization", "Bearer token").
+		SetHeader("Accept-Language", "en").
+		SetFetchOptions(&models.FetchOptions{SimpleHeadersOnly: true})
+
+	if _, err := client.Post(context.Background(), "/events", nil, map[string]string{"type": "click"}, nil); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	if received.Get("Authorization") != "" {
+		t.Error("Expected non-safelisted header to be dropped")
+	}
+	if received.Get("Accept-Language") != "en" {
+		t.Error("Expected safelisted header to be kept")
+	}
+	if got := received.Get("Content-Type"); got != "text/plain;charset=UTF-8" {
+		t.Errorf("Expected JSON content type to be downgraded, got %q", got)
+	}
+}
+
+func TestValidateCORSReportsPreflightTriggers(t *testing.T) {
+	var requests int
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+	}))
+	defer server.Close()
+
+	client := infrastructure.NewClient().
+		SetBaseURL(server.URL).
+		SetFetchOptions(&models.FetchOptions{ValidateCORS: true, Credentials: "include"})
+
+	if _, err := client.PostForm(context.Background(), "/login", url.Values{"user": {"ada"}}, nil); err != nil {
+		t.Fatalf("Expected simple request to pass validation, got %v", err)
+	}
+
+	_, err := client.NewInstance().
+		SetHeader("Authorization", "Bearer token").
+		SetHeader("X-Trace", "abc").
+		Put(context.Background(), "/items/1", nil, map[string]string{"name": "x"}, nil)
+
+	var corsErr *errors.CORSError
+	if !stderrors.As(err, &corsErr) {
+		t.Fatalf("Expected CORSError, got %v", err)
+	}
+	reasons := strings.Join(corsErr.Reasons, "; ")
+	for _, want := range []string{"method PUT", "Authorization, X-Trace", "Content-Type application/json"} {
+		if !strings.Contains(reasons, want) {
+			t.Errorf("Expected reasons to mention %q, got %q", want, reasons)
+		}
+	}
+	if !strings.Contains(corsErr.Hint, "exact origin") {
+		t.Errorf("Expected a hint about credentials and wildcard origins, got %q", corsErr.Hint)
+	}
+	if requests != 1 {
+		t.Errorf("Expected the invalid request not to be sent, got %d requests", requests)
+	}
+}
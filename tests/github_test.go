@@ -0,0 +1,108 @@
+package tests
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/fourth-ally/gofetch"
+	"github.com/fourth-ally/gofetch/domain/models"
+	"github.com/fourth-ally/gofetch/infrastructure"
+)
+
+func TestParseLinkHeader(t *testing.T) {
+	links := infrastructure.ParseLinkHeader(`<https://api.github.com/repositories/1/issues?page=2>; rel="next", <https://api.github.com/repositories/1/issues?page=5>; rel="last"`)
+
+	if links["next"] != "https://api.github.com/repositories/1/issues?page=2" || links["last"] != "https://api.github.com/repositories/1/issues?page=5" {
+		t.Errorf("Unexpected links: %v", links)
+	}
+}
+
+func TestGitHubClientPaginatesWithAuth(t *testing.T) {
+	var server *httptest.Server
+	server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Authorization") != "Bearer token" || r.Header.Get("X-GitHub-Api-Version") == "" {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+
+		page := r.URL.Query().Get("page")
+		if page == "" {
+			page = "1"
+		}
+		if page != "3" {
+			next := map[string]string{"1": "2", "2": "3"}[page]
+			w.Header().Set("Link", fmt.Sprintf(`<%s/repos/o/r/issues?page=%s>; rel="next"`, server.URL, next))
+		}
+		fmt.Fprintf(w, `[{"number":%s}]`, page)
+	}))
+	defer server.Close()
+
+	client := gofetch.NewGitHubClient("token").SetBaseURL(server.URL)
+
+	var issues []struct {
+		Number int `json:"number"`
+	}
+	var numbers []int
+	err := client.Paginate(context.Background(), "/repos/:owner/:repo/issues", map[string]interface{}{"owner": "o", "repo": "r"}, &issues,
+		func(*models.Response) error {
+			for _, issue := range issues {
+				numbers = append(numbers, issue.Number)
+			}
+			return nil
+		})
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	if fmt.Sprint(numbers) != "[1 2 3]" {
+		t.Errorf("Expected all three pages, got %v", numbers)
+	}
+}
+
+func TestRetryRespectsRateLimitHeaders(t *testing.T) {
+	var attempts int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if atomic.AddInt32(&attempts, 1) == 1 {
+			w.Header().Set("Retry-After", "1")
+			w.WriteHeader(http.StatusForbidden)
+			return
+		}
+		w.Write([]byte(`{}`))
+	}))
+	defer server.Close()
+
+	client := infrastructure.NewClient().
+		SetBaseURL(server.URL).
+		SetRetryOptions(&models.RetryOptions{
+			MaxRetries:        2,
+			InitialDelay:      time.Millisecond,
+			MaxDelay:          5 * time.Second,
+			Backoff:           models.BackoffFixed,
+			RespectRateLimits: true,
+		})
+
+	start := time.Now()
+	if _, err := client.Get(context.Background(), "/", nil, nil); err != nil {
+		t.Fatalf("Expected retry after rate limit, got %v", err)
+	}
+	if elapsed := time.Since(start); elapsed < time.Second {
+		t.Errorf("Expected to wait for Retry-After, waited %v", elapsed)
+	}
+
+	atomic.StoreInt32(&attempts, 0)
+	client.SetRetryOptions(&models.RetryOptions{
+		MaxRetries:        2,
+		InitialDelay:      time.Millisecond,
+		MaxDelay:          100 * time.Millisecond,
+		Backoff:           models.BackoffFixed,
+		RespectRateLimits: true,
+	})
+	if _, err := client.Get(context.Background(), "/", nil, nil); err == nil {
+		t.Error("Expected no retry when Retry-After exceeds MaxDelay")
+	}
+}
@@ -0,0 +1,59 @@
+package tests
+
+import (
+	"context"
+	stderrors "errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/fourth-ally/gofetch/domain/errors"
+	"github.com/fourth-ally/gofetch/infrastructure"
+)
+
+func TestMaxResponseHeaderCount(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		for i := 0; i < 50; i++ {
+			w.Header().Add("X-Junk", fmt.Sprint(i))
+		}
+		w.Write([]byte(`{}`))
+	}))
+	defer server.Close()
+
+	client := infrastructure.NewClient().SetBaseURL(server.URL).SetMaxResponseHeaderCount(20)
+
+	_, err := client.Get(context.Background(), "/", nil, nil)
+	var limitErr *errors.HeaderLimitError
+	if !stderrors.As(err, &limitErr) {
+		t.Fatalf("Expected HeaderLimitError, got %v", err)
+	}
+	if limitErr.Limit != errors.HeaderLimitCount || limitErr.Max != 20 || limitErr.Actual < 50 {
+		t.Errorf("Unexpected limit error: %+v", limitErr)
+	}
+
+	client.SetMaxResponseHeaderCount(100)
+	if _, err := client.Get(context.Background(), "/", nil, nil); err != nil {
+		t.Errorf("Expected response within the limit, got %v", err)
+	}
+}
+
+func TestMaxResponseHeaderBytes(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("X-Large", strings.Repeat("a", 8<<10))
+		w.Write([]byte(`{}`))
+	}))
+	defer server.Close()
+
+	client := infrastructure.NewClient().SetBaseURL(server.URL).SetMaxResponseHeaderBytes(4 << 10)
+
+	_, err := client.Get(context.Background(), "/", nil, nil)
+	var limitErr *errors.HeaderLimitError
+	if !stderrors.As(err, &limitErr) {
+		t.Fatalf("Expected HeaderLimitError, got %v", err)
+	}
+	if limitErr.Limit != errors.HeaderLimitBytes || limitErr.Max != 4<<10 {
+		t.Errorf("Unexpected limit error: %+v", limitErr)
+	}
+}
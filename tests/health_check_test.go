@@ -0,0 +1,108 @@
+package tests
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/fourth-ally/gofetch/domain/models"
+	"github.com/fourth-ally/gofetch/infrastructure"
+)
+
+func TestHealthCheckSkipsDownBaseURL(t *testing.T) {
+	var primaryCalls int32
+	primary := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/health" {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		atomic.AddInt32(&primaryCalls, 1)
+		w.Write([]byte(`{}`))
+	}))
+	defer primary.Close()
+
+	mirror := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`{}`))
+	}))
+	defer mirror.Close()
+
+	changes := make(chan string, 4)
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	client := infrastructure.NewClient().
+		SetBaseURLs([]string{primary.URL, mirror.URL}).
+		StartHealthChecks(ctx, &models.HealthCheckOptions{
+			Path:     "/health",
+			Interval: 20 * time.Millisecond,
+			Timeout:  time.Second,
+			OnStateChange: func(baseURL string, healthy bool) {
+				if !healthy {
+					changes <- baseURL
+				}
+			},
+		})
+
+	select {
+	case baseURL := <-changes:
+		if baseURL != primary.URL {
+			t.Fatalf("Expected primary to be marked down, got %s", baseURL)
+		}
+	case <-time.After(time.Second):
+		t.Fatal("Expected a state change callback")
+	}
+
+	resp, err := client.Get(context.Background(), "/users", nil, nil)
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	if resp.BaseURL != mirror.URL {
+		t.Errorf("Expected request to go to mirror, got %s", resp.BaseURL)
+	}
+	if atomic.LoadInt32(&primaryCalls) != 0 {
+		t.Errorf("Expected no requests to unhealthy primary, got %d", primaryCalls)
+	}
+
+	status := client.HealthStatus()
+	if status[primary.URL] || !status[mirror.URL] {
+		t.Errorf("Unexpected health status %v", status)
+	}
+}
+
+func TestHealthCheckRecoveryNotifies(t *testing.T) {
+	var healthy atomic.Bool
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if !healthy.Load() {
+			w.WriteHeader(http.StatusInternalServerError)
+		}
+	}))
+	defer server.Close()
+
+	events := make(chan bool, 4)
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	infrastructure.NewClient().
+		SetBaseURL(server.URL).
+		StartHealthChecks(ctx, &models.HealthCheckOptions{
+			Path:          "/health",
+			Interval:      20 * time.Millisecond,
+			OnStateChange: func(baseURL string, up bool) { events <- up },
+		})
+
+	for _, want := range []bool{false, true} {
+		select {
+		case got := <-events:
+			if got != want {
+				t.Fatalf("Expected healthy=%v, got %v", want, got)
+			}
+		case <-time.After(time.Second):
+			t.Fatalf("Expected healthy=%v transition", want)
+		}
+		healthy.Store(true)
+	}
+}
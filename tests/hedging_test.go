@@ -0,0 +1,80 @@
+package tests
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/fourth-ally/gofetch/domain/models"
+	"github.com/fourth-ally/gofetch/infrastructure"
+)
+
+func TestHedgedRequestWinsOverSlowPrimary(t *testing.T) {
+	var calls int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		// The first request stalls; the hedge answers immediately
+		if atomic.AddInt32(&calls, 1) == 1 {
+			select {
+			case <-r.Context().Done():
+			case <-time.After(2 * time.Second):
+			}
+			return
+		}
+		json.NewEncoder(w).Encode(TestUser{ID: 7, Name: "Hedge"})
+	}))
+	defer server.Close()
+
+	client := infrastructure.NewClient().
+		SetBaseURL(server.URL).
+		SetHedging(&models.HedgingOptions{Delay: 20 * time.Millisecond, MaxHedges: 1})
+
+	start := time.Now()
+	var user TestUser
+	resp, err := client.Get(context.Background(), "/users/7", nil, &user)
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	if time.Since(start) > time.Second {
+		t.Errorf("Expected hedge to return quickly, took %v", time.Since(start))
+	}
+
+	if !resp.Hedged {
+		t.Error("Expected response to be served by the hedge request")
+	}
+
+	if user.Name != "Hedge" {
+		t.Errorf("Expected name 'Hedge', got %s", user.Name)
+	}
+}
+
+func TestHedgingSkipsNonIdempotentMethods(t *testing.T) {
+	var calls int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&calls, 1)
+		time.Sleep(50 * time.Millisecond)
+		w.WriteHeader(http.StatusCreated)
+	}))
+	defer server.Close()
+
+	client := infrastructure.NewClient().
+		SetBaseURL(server.URL).
+		SetHedging(&models.HedgingOptions{Delay: 5 * time.Millisecond, MaxHedges: 2})
+
+	resp, err := client.Post(context.Background(), "/users", nil, TestUser{Name: "Jane"}, nil)
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	if resp.Hedged {
+		t.Error("Expected POST not to be hedged")
+	}
+
+	if got := atomic.LoadInt32(&calls); got != 1 {
+		t.Errorf("Expected 1 request for POST, got %d", got)
+	}
+}
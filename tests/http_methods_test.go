@@ -6,7 +6,9 @@ import (
 	"net/http"
 	"net/http/httptest"
 	"testing"
+	"time"
 
+	"github.com/fourth-ally/gofetch/domain/models"
 	"github.com/fourth-ally/gofetch/infrastructure"
 )
 
@@ -159,3 +161,62 @@ func TestDeleteRequest(t *testing.T) {
 		t.Errorf("Expected status 204, got %d", resp.StatusCode)
 	}
 }
+
+func TestArbitraryMethodRequest(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.Method {
+		case "PROPFIND":
+			if r.Header.Get("Depth") != "1" || r.URL.Query().Get("v") != "2" {
+				t.Errorf("Expected Depth header and query, got %v %s", r.Header, r.URL)
+			}
+			var body map[string]string
+			json.NewDecoder(r.Body).Decode(&body)
+			json.NewEncoder(w).Encode(map[string]string{"prop": body["prop"]})
+		case "PURGE":
+			w.WriteHeader(http.StatusOK)
+		default:
+			t.Errorf("Unexpected method %s", r.Method)
+		}
+	}))
+	defer server.Close()
+
+	client := infrastructure.NewClient().SetBaseURL(server.URL)
+
+	var result map[string]string
+	resp, err := client.Do(context.Background(), "PROPFIND", "/files/:dir", map[string]interface{}{"dir": "docs", "v": 2},
+		map[string]string{"prop": "getetag"}, &result, infrastructure.WithHeader("Depth", "1"))
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if resp.StatusCode != 200 || result["prop"] != "getetag" {
+		t.Errorf("Expected the PROPFIND result, got %d %v", resp.StatusCode, result)
+	}
+
+	if _, err := client.Do(context.Background(), "PURGE", "/cached", nil, nil, nil); err != nil {
+		t.Errorf("Expected PURGE to succeed, got %v", err)
+	}
+
+	if _, err := client.Do(context.Background(), "BAD METHOD", "/", nil, nil, nil); err == nil {
+		t.Error("Expected an invalid method to fail")
+	}
+}
+
+func TestSafeWebDAVMethodsAreHedged(t *testing.T) {
+	calls := make(chan string, 4)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls <- r.Method
+		time.Sleep(50 * time.Millisecond)
+	}))
+	defer server.Close()
+
+	client := infrastructure.NewClient().
+		SetBaseURL(server.URL).
+		SetHedging(&models.HedgingOptions{Delay: 10 * time.Millisecond, MaxHedges: 1})
+
+	if _, err := client.Do(context.Background(), "REPORT", "/calendar", nil, nil, nil); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if len(calls) != 2 {
+		t.Errorf("Expected REPORT to be hedged, got %d requests", len(calls))
+	}
+}
@@ -0,0 +1,75 @@
+package tests
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"regexp"
+	"testing"
+
+	"github.com/fourth-ally/gofetch/domain/contracts"
+	"github.com/fourth-ally/gofetch/infrastructure"
+)
+
+var uuidV7Pattern = regexp.MustCompile(`^[0-9a-f]{8}-[0-9a-f]{4}-7[0-9a-f]{3}-[89ab][0-9a-f]{3}-[0-9a-f]{12}$`)
+
+func TestUUIDv7Generator(t *testing.T) {
+	first := infrastructure.UUIDv7Generator.NewID()
+	second := infrastructure.UUIDv7Generator.NewID()
+
+	if !uuidV7Pattern.MatchString(first) {
+		t.Errorf("Expected a version 7 UUID, got %q", first)
+	}
+	if first == second {
+		t.Error("Expected unique IDs")
+	}
+	if first[:8] > second[:8] {
+		t.Errorf("Expected time-ordered IDs, got %q then %q", first, second)
+	}
+}
+
+func TestRequestIDHeaderUsesDefaultGenerator(t *testing.T) {
+	var got string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		got = r.Header.Get("X-Request-ID")
+	}))
+	defer server.Close()
+
+	client := infrastructure.NewClient().SetBaseURL(server.URL).SetRequestIDHeader("X-Request-ID")
+	if _, err := client.Get(context.Background(), "/", nil, nil); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	if !uuidV7Pattern.MatchString(got) {
+		t.Errorf("Expected a UUIDv7 request ID, got %q", got)
+	}
+}
+
+func TestCustomIDGenerator(t *testing.T) {
+	var requestID, idempotencyKey string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requestID = r.Header.Get("X-Request-ID")
+		idempotencyKey = r.Header.Get("Idempotency-Key")
+	}))
+	defer server.Close()
+
+	next := 0
+	generator := contracts.IDGeneratorFunc(func() string {
+		next++
+		return "id-" + string(rune('0'+next))
+	})
+
+	client := infrastructure.NewClient().
+		SetBaseURL(server.URL).
+		SetIDGenerator(generator).
+		SetRequestIDHeader("X-Request-ID").
+		SetIdempotencyKeys(true)
+
+	if _, err := client.Post(context.Background(), "/", nil, map[string]string{}, nil); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	if requestID != "id-1" || idempotencyKey != "id-2" {
+		t.Errorf("Expected IDs from custom generator, got request ID %q and idempotency key %q", requestID, idempotencyKey)
+	}
+}
@@ -0,0 +1,73 @@
+package tests
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/fourth-ally/gofetch/domain/models"
+	"github.com/fourth-ally/gofetch/infrastructure"
+)
+
+func TestIdempotencyKeyReusedAcrossRetries(t *testing.T) {
+	var keys []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		keys = append(keys, r.Header.Get("Idempotency-Key"))
+		if len(keys) < 3 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		w.Write([]byte(`{}`))
+	}))
+	defer server.Close()
+
+	retry := models.NewRetryOptions()
+	retry.MaxRetries = 2
+	retry.InitialDelay = 0
+	retry.Jitter = false
+
+	client := infrastructure.NewClient().
+		SetBaseURL(server.URL).
+		SetRetryOptions(retry).
+		SetIdempotencyKeys(true)
+
+	if _, err := client.Post(context.Background(), "/charges", nil, map[string]int{"amount": 100}, nil); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	if len(keys) != 3 || keys[0] == "" {
+		t.Fatalf("Expected 3 attempts with a key, got %q", keys)
+	}
+	if keys[1] != keys[0] || keys[2] != keys[0] {
+		t.Errorf("Expected the same key across retries, got %q", keys)
+	}
+
+	client.Post(context.Background(), "/charges", nil, nil, nil)
+	if keys[3] == keys[0] {
+		t.Error("Expected a new key for a new logical request")
+	}
+}
+
+func TestIdempotencyKeyOnlyForPostAndPatch(t *testing.T) {
+	var key string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		key = r.Header.Get("Idempotency-Key")
+	}))
+	defer server.Close()
+
+	client := infrastructure.NewClient().
+		SetBaseURL(server.URL).
+		SetIdempotencyKeys(true)
+
+	client.Put(context.Background(), "/charges/1", nil, nil, nil)
+	if key != "" {
+		t.Errorf("Expected no key on PUT, got %q", key)
+	}
+
+	client.SetHeader("Idempotency-Key", "fixed")
+	client.Patch(context.Background(), "/charges/1", nil, nil, nil)
+	if key != "fixed" {
+		t.Errorf("Expected explicit key to be kept, got %q", key)
+	}
+}
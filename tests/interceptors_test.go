@@ -1,13 +1,21 @@
 package tests
 
 import (
+	"bytes"
 	"context"
 	"encoding/json"
+	stderrors "errors"
+	"log/slog"
 	"net/http"
 	"net/http/httptest"
+	"strings"
+	"sync/atomic"
 	"testing"
+	"time"
 
+	"github.com/fourth-ally/gofetch/domain/contracts"
 	"github.com/fourth-ally/gofetch/infrastructure"
+	"github.com/fourth-ally/gofetch/infrastructure/interceptors"
 )
 
 func TestRequestInterceptor(t *testing.T) {
@@ -58,3 +66,200 @@ func TestResponseInterceptor(t *testing.T) {
 		t.Error("Expected response interceptor to be called")
 	}
 }
+
+func TestInterceptorOrdering(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(r.Header.Get("X-Trace")))
+	}))
+	defer server.Close()
+
+	trace := func(step string) func(*http.Request) (*http.Request, error) {
+		return func(req *http.Request) (*http.Request, error) {
+			req.Header.Set("X-Trace", strings.TrimPrefix(req.Header.Get("X-Trace")+","+step, ","))
+			return req, nil
+		}
+	}
+
+	client := infrastructure.NewClient().
+		SetBaseURL(server.URL).
+		AddNamedRequestInterceptor("logging", trace("logging")).
+		AddRequestInterceptor(trace("metrics")).
+		PrependRequestInterceptor(trace("tracing"))
+
+	if err := client.InsertRequestInterceptorBefore("logging", "auth", trace("auth")); err != nil {
+		t.Fatalf("Expected insert to succeed, got %v", err)
+	}
+	if err := client.InsertRequestInterceptorAfter("auth", "signing", trace("signing")); err != nil {
+		t.Fatalf("Expected insert to succeed, got %v", err)
+	}
+	if err := client.InsertRequestInterceptorAfter("missing", "x", trace("x")); err == nil {
+		t.Error("Expected error for unknown anchor")
+	}
+
+	resp, err := client.Get(context.Background(), "/", nil, nil)
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if got := string(resp.RawBody); got != "tracing,auth,signing,logging,metrics" {
+		t.Errorf("Unexpected interceptor order: %s", got)
+	}
+
+	names := strings.Join(client.RequestInterceptorNames(), ",")
+	if names != ",auth,signing,logging," {
+		t.Errorf("Unexpected interceptor names: %q", names)
+	}
+
+	if !client.RemoveRequestInterceptor("signing") || client.RemoveRequestInterceptor("signing") {
+		t.Error("Expected signing interceptor to be removed exactly once")
+	}
+	resp, _ = client.Get(context.Background(), "/", nil, nil)
+	if got := string(resp.RawBody); got != "tracing,auth,logging,metrics" {
+		t.Errorf("Unexpected order after removal: %s", got)
+	}
+}
+
+func TestResponseInterceptorOrdering(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+	defer server.Close()
+
+	var order []string
+	record := func(step string) func(*http.Response) (*http.Response, error) {
+		return func(resp *http.Response) (*http.Response, error) {
+			order = append(order, step)
+			return resp, nil
+		}
+	}
+
+	client := infrastructure.NewClient().
+		SetBaseURL(server.URL).
+		AddNamedResponseInterceptor("decode", record("decode")).
+		PrependResponseInterceptor(record("metrics"))
+	client.InsertResponseInterceptorAfter("decode", "audit", record("audit"))
+
+	client.Get(context.Background(), "/", nil, nil)
+	if strings.Join(order, ",") != "metrics,decode,audit" {
+		t.Errorf("Unexpected response interceptor order: %v", order)
+	}
+}
+
+func TestInterceptorToolkitHeaders(t *testing.T) {
+	var got http.Header
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		got = r.Header.Clone()
+	}))
+	defer server.Close()
+
+	client := infrastructure.NewClient().
+		SetBaseURL(server.URL).
+		AddRequestInterceptor(interceptors.UserAgent("billing/1.2")).
+		AddRequestInterceptor(interceptors.RequestID("", contracts.IDGeneratorFunc(func() string { return "req-1" }))).
+		AddRequestInterceptor(interceptors.HeaderPropagation("Traceparent", "X-Tenant")).
+		AddRequestInterceptor(interceptors.BearerAuth(interceptors.StaticToken("secret")))
+
+	ctx := interceptors.WithIncomingHeaders(context.Background(), http.Header{
+		"Traceparent": {"00-abc-def-01"},
+		"Cookie":      {"session=1"},
+	})
+	if _, err := client.Get(ctx, "/", nil, nil); err != nil {
+		t.Fatalf("Get failed: %v", err)
+	}
+
+	want := map[string]string{
+		"User-Agent":    "billing/1.2",
+		"X-Request-Id":  "req-1",
+		"Traceparent":   "00-abc-def-01",
+		"Authorization": "Bearer secret",
+		"Cookie":        "",
+	}
+	for name, value := range want {
+		if got.Get(name) != value {
+			t.Errorf("expected %s %q, got %q", name, value, got.Get(name))
+		}
+	}
+
+	failing := interceptors.BearerAuth(func(context.Context) (string, error) {
+		return "", stderrors.New("token expired")
+	})
+	_, err := infrastructure.NewClient().SetBaseURL(server.URL).AddRequestInterceptor(failing).Get(context.Background(), "/", nil, nil)
+	if err == nil || !strings.Contains(err.Error(), "token expired") {
+		t.Errorf("expected the token error, got %v", err)
+	}
+}
+
+func TestInterceptorToolkitLoggingAndTiming(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		time.Sleep(10 * time.Millisecond)
+		w.WriteHeader(http.StatusAccepted)
+	}))
+	defer server.Close()
+
+	var logs bytes.Buffer
+	logging := interceptors.NewLogging(slog.New(slog.NewTextHandler(&logs, nil)), slog.LevelInfo)
+
+	var duration time.Duration
+	timing := interceptors.NewTiming(func(resp *http.Response, d time.Duration) {
+		duration = d
+	})
+
+	client := infrastructure.NewClient().
+		SetBaseURL(server.URL).
+		AddRequestInterceptor(logging.Request).
+		AddResponseInterceptor(logging.Response).
+		AddRequestInterceptor(timing.Request).
+		AddResponseInterceptor(timing.Response)
+	if _, err := client.Get(context.Background(), "/jobs", map[string]interface{}{"token": "x"}, nil); err != nil {
+		t.Fatalf("Get failed: %v", err)
+	}
+
+	if duration < 10*time.Millisecond {
+		t.Errorf("expected the round trip to take at least 10ms, got %s", duration)
+	}
+	out := logs.String()
+	for _, want := range []string{"sending request", "received response", "status=202", "method=GET", "/jobs"} {
+		if !strings.Contains(out, want) {
+			t.Errorf("expected log to contain %q, got %s", want, out)
+		}
+	}
+}
+
+func TestInterceptorToolkitRetryAfter(t *testing.T) {
+	var calls atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if calls.Add(1) == 1 {
+			w.Header().Set("Retry-After", "1")
+			w.WriteHeader(http.StatusTooManyRequests)
+		}
+	}))
+	defer server.Close()
+
+	retryAfter := interceptors.NewRetryAfter(0)
+	client := infrastructure.NewClient().
+		SetBaseURL(server.URL).
+		AddRequestInterceptor(retryAfter.Request).
+		AddResponseInterceptor(retryAfter.Response)
+
+	client.Get(context.Background(), "/", nil, nil)
+	_, err := client.Get(context.Background(), "/", nil, nil)
+	if !stderrors.Is(err, interceptors.ErrRetryAfter) {
+		t.Fatalf("expected ErrRetryAfter while the host backs off, got %v", err)
+	}
+	if calls.Load() != 1 {
+		t.Errorf("expected the held back request not to reach the server, got %d calls", calls.Load())
+	}
+
+	waiting := interceptors.NewRetryAfter(2 * time.Second)
+	calls.Store(0)
+	client = infrastructure.NewClient().
+		SetBaseURL(server.URL).
+		AddRequestInterceptor(waiting.Request).
+		AddResponseInterceptor(waiting.Response)
+
+	client.Get(context.Background(), "/", nil, nil)
+	started := time.Now()
+	if _, err := client.Get(context.Background(), "/", nil, nil); err != nil {
+		t.Fatalf("expected the request to wait for the host, got %v", err)
+	}
+	if elapsed := time.Since(started); elapsed < 500*time.Millisecond {
+		t.Errorf("expected the request to wait for Retry-After, took %s", elapsed)
+	}
+}
@@ -0,0 +1,96 @@
+package tests
+
+import (
+	"context"
+	stderrors "errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/fourth-ally/gofetch/domain/errors"
+	"github.com/fourth-ally/gofetch/infrastructure"
+)
+
+func TestDecodeJSON(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"id":1,"name":"Alice"}`))
+	}))
+	defer server.Close()
+
+	client := infrastructure.NewClient().SetBaseURL(server.URL)
+
+	var user TestUser
+	resp, err := client.DecodeJSON(context.Background(), "/user", nil, &user)
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if user.Name != "Alice" || resp.Data != &user || resp.RawBody != nil {
+		t.Errorf("Unexpected result: %+v, %+v", user, resp)
+	}
+}
+
+func TestStreamJSONArray(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`[{"id":1,"name":"Alice"}, {"id":2,"name":"Bob"}, {"id":3,"name":"Carol"}]`))
+	}))
+	defer server.Close()
+
+	client := infrastructure.NewClient().SetBaseURL(server.URL)
+
+	var names []string
+	index := 0
+	_, err := client.StreamJSONArray(context.Background(), "/users", nil, func(decode func(v interface{}) error) error {
+		index++
+		if index == 2 {
+			return nil // skipped without decoding
+		}
+		var user TestUser
+		if err := decode(&user); err != nil {
+			return err
+		}
+		names = append(names, user.Name)
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if strings.Join(names, ",") != "Alice,Carol" {
+		t.Errorf("Unexpected elements: %v", names)
+	}
+}
+
+func TestStreamJSONArrayErrors(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/object":
+			w.Write([]byte(`{"id":1}`))
+		case "/missing":
+			w.WriteHeader(http.StatusNotFound)
+		default:
+			w.Write([]byte(`[1, 2, 3]`))
+		}
+	}))
+	defer server.Close()
+
+	client := infrastructure.NewClient().SetBaseURL(server.URL)
+	ignore := func(decode func(v interface{}) error) error { return nil }
+
+	if _, err := client.StreamJSONArray(context.Background(), "/object", nil, ignore); err == nil || !strings.Contains(err.Error(), "expected JSON array") {
+		t.Errorf("Expected array error, got %v", err)
+	}
+
+	var httpErr *errors.HTTPError
+	if _, err := client.StreamJSONArray(context.Background(), "/missing", nil, ignore); !stderrors.As(err, &httpErr) {
+		t.Errorf("Expected HTTPError, got %v", err)
+	}
+
+	stop := stderrors.New("stop")
+	_, err := client.StreamJSONArray(context.Background(), "/numbers", nil, func(decode func(v interface{}) error) error {
+		return stop
+	})
+	if err != stop {
+		t.Errorf("Expected callback error to be returned unchanged, got %v", err)
+	}
+}
@@ -0,0 +1,139 @@
+package tests
+
+import (
+	"context"
+	stderrors "errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+
+	"github.com/fourth-ally/gofetch"
+	"github.com/fourth-ally/gofetch/domain/errors"
+	"github.com/fourth-ally/gofetch/domain/models"
+	"github.com/fourth-ally/gofetch/infrastructure"
+)
+
+func kubeEvent(eventType, resourceVersion string) string {
+	return fmt.Sprintf(`{"type":%q,"object":{"kind":"Pod","metadata":{"name":"web","resourceVersion":%q}}}`+"\n", eventType, resourceVersion)
+}
+
+func TestFormatSelector(t *testing.T) {
+	selector := infrastructure.FormatSelector(map[string]string{"tier": "frontend", "app": "web"})
+	if selector != "app=web,tier=frontend" {
+		t.Errorf("Expected sorted selector, got %q", selector)
+	}
+}
+
+func TestStreamNDJSON(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte("{\"n\":1}\n\n{\"n\":2}\n{\"n\":3}"))
+	}))
+	defer server.Close()
+
+	client := infrastructure.NewClient().SetBaseURL(server.URL)
+
+	var lines []string
+	err := client.StreamNDJSON(context.Background(), "/", nil, func(line []byte) error {
+		lines = append(lines, string(line))
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if strings.Join(lines, " ") != `{"n":1} {"n":2} {"n":3}` {
+		t.Errorf("Unexpected lines: %v", lines)
+	}
+}
+
+func TestWatchKubernetesResumesFromBookmark(t *testing.T) {
+	var connections int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		query := r.URL.Query()
+		if query.Get("watch") != "true" || query.Get("labelSelector") != "app=web" || query.Get("allowWatchBookmarks") != "true" {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+
+		switch atomic.AddInt32(&connections, 1) {
+		case 1:
+			if query.Get("resourceVersion") != "" {
+				t.Errorf("Expected first watch to start without a version, got %q", query.Get("resourceVersion"))
+			}
+			w.Write([]byte(kubeEvent(models.KubeEventAdded, "10") + kubeEvent(models.KubeEventBookmark, "15")))
+		default:
+			if query.Get("resourceVersion") != "15" {
+				t.Errorf("Expected reconnect from bookmark version 15, got %q", query.Get("resourceVersion"))
+			}
+			w.Write([]byte(kubeEvent(models.KubeEventDeleted, "16")))
+		}
+	}))
+	defer server.Close()
+
+	client := gofetch.NewKubernetesClient(server.URL, "token")
+	options := models.NewKubeWatchOptions()
+	options.LabelSelector = infrastructure.FormatSelector(map[string]string{"app": "web"})
+
+	stop := stderrors.New("stop")
+	var types []string
+	err := client.WatchKubernetes(context.Background(), "/api/v1/namespaces/:namespace/pods", map[string]interface{}{"namespace": "default"}, options,
+		func(event models.KubeWatchEvent) error {
+			types = append(types, event.Type)
+			if event.Type == models.KubeEventDeleted {
+				return stop
+			}
+			return nil
+		})
+	if !stderrors.Is(err, stop) {
+		t.Fatalf("Expected handler error, got %v", err)
+	}
+	if strings.Join(types, ",") != "ADDED,DELETED" {
+		t.Errorf("Expected bookmarks to be consumed, got %v", types)
+	}
+}
+
+func TestWatchKubernetesRelistsOnGone(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Query().Get("resourceVersion") {
+		case "1":
+			w.WriteHeader(http.StatusGone)
+		case "2":
+			w.Write([]byte(`{"type":"ERROR","object":{"kind":"Status","code":410,"message":"too old resource version"}}` + "\n"))
+		default:
+			w.Write([]byte(kubeEvent(models.KubeEventModified, "101")))
+		}
+	}))
+	defer server.Close()
+
+	client := gofetch.NewKubernetesClient(server.URL, "")
+
+	options := models.NewKubeWatchOptions()
+	options.ResourceVersion = "1"
+	var relists int
+	options.Relist = func(ctx context.Context) (string, error) {
+		relists++
+		return map[int]string{1: "2", 2: "100"}[relists], nil
+	}
+
+	stop := stderrors.New("stop")
+	err := client.WatchKubernetes(context.Background(), "/api/v1/pods", nil, options, func(event models.KubeWatchEvent) error {
+		return stop
+	})
+	if !stderrors.Is(err, stop) {
+		t.Fatalf("Expected watch to resume after relisting, got %v", err)
+	}
+	if relists != 2 {
+		t.Errorf("Expected a relist for both the 410 response and the ERROR event, got %d", relists)
+	}
+
+	options.Relist = nil
+	err = client.WatchKubernetes(context.Background(), "/api/v1/pods", nil, options, func(event models.KubeWatchEvent) error {
+		return nil
+	})
+	var expired *errors.ResourceExpiredError
+	if !stderrors.As(err, &expired) || expired.ResourceVersion != "1" {
+		t.Errorf("Expected ResourceExpiredError, got %v", err)
+	}
+}
@@ -0,0 +1,50 @@
+package tests
+
+import (
+	"bytes"
+	"context"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/fourth-ally/gofetch/infrastructure"
+)
+
+func TestContextLoggerOverridesClientLogger(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusCreated)
+	}))
+	defer server.Close()
+
+	newLogger := func(buf *bytes.Buffer) *slog.Logger {
+		return slog.New(slog.NewTextHandler(buf, &slog.HandlerOptions{Level: slog.LevelDebug}))
+	}
+
+	var clientLog, tenantLog bytes.Buffer
+	client := infrastructure.NewClient().
+		SetBaseURL(server.URL).
+		SetLogger(newLogger(&clientLog))
+
+	ctx := infrastructure.WithLogger(context.Background(), newLogger(&tenantLog).With("tenant", "acme"))
+	if _, err := client.Get(ctx, "/users", nil, nil); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	if clientLog.Len() != 0 {
+		t.Errorf("Expected client logger to be bypassed, got %q", clientLog.String())
+	}
+
+	output := tenantLog.String()
+	for _, want := range []string{"tenant=acme", "method=GET", "status=201", "/users"} {
+		if !strings.Contains(output, want) {
+			t.Errorf("Expected log output to contain %q, got %q", want, output)
+		}
+	}
+
+	client.Get(context.Background(), "/users", nil, nil)
+	if !strings.Contains(clientLog.String(), "request completed") {
+		t.Errorf("Expected client logger to be used without a context logger, got %q", clientLog.String())
+	}
+}
@@ -0,0 +1,121 @@
+package tests
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"text/template"
+
+	"github.com/fourth-ally/gofetch/domain/models"
+	"github.com/fourth-ally/gofetch/infrastructure"
+)
+
+type negotiatedUser struct {
+	ID   int    `json:"id" xml:"id"`
+	Name string `json:"name" xml:"name"`
+}
+
+func TestSetAcceptDecodesByContentType(t *testing.T) {
+	var accept string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		accept = r.Header.Get("Accept")
+		switch r.URL.Path {
+		case "/xml":
+			w.Header().Set("Content-Type", "application/atom+xml; charset=utf-8")
+			w.Write([]byte(`<user><id>1</id><name>Alice</name></user>`))
+		case "/text":
+			w.Header().Set("Content-Type", "text/plain")
+			w.Write([]byte("plain hello"))
+		default:
+			w.Header().Set("Content-Type", "application/problem+json")
+			w.Write([]byte(`{"id":2,"name":"Bob"}`))
+		}
+	}))
+	defer server.Close()
+
+	client := infrastructure.NewClient().
+		SetBaseURL(server.URL).
+		SetAccept("application/json", "application/*+xml;q=0.9", "text/*;q=0.5")
+
+	var xmlUser negotiatedUser
+	if _, err := client.Get(context.Background(), "/xml", nil, &xmlUser); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if xmlUser.Name != "Alice" {
+		t.Errorf("Expected XML decoded user, got %+v", xmlUser)
+	}
+	if accept != "application/json, application/*+xml;q=0.9, text/*;q=0.5" {
+		t.Errorf("Unexpected Accept header %q", accept)
+	}
+
+	var text string
+	resp, err := client.Get(context.Background(), "/text", nil, &text)
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if text != "plain hello" {
+		t.Errorf("Expected text body, got %q", text)
+	}
+	var decoded []byte
+	if err := resp.Decode(&decoded); err != nil || string(decoded) != "plain hello" {
+		t.Errorf("Expected Decode to use the text codec, got %q, %v", decoded, err)
+	}
+
+	var jsonUser negotiatedUser
+	if _, err := client.NewInstance().Get(context.Background(), "/json", nil, &jsonUser); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if jsonUser.Name != "Bob" {
+		t.Errorf("Expected JSON decoded user, got %+v", jsonUser)
+	}
+}
+
+func TestUnacceptedContentTypeFallsBackToJSON(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "text/plain")
+		w.Write([]byte(`{"id":3,"name":"Carol"}`))
+	}))
+	defer server.Close()
+
+	client := infrastructure.NewClient().SetBaseURL(server.URL).SetAccept("application/json")
+
+	var user negotiatedUser
+	if _, err := client.Get(context.Background(), "/", nil, &user); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if user.Name != "Carol" {
+		t.Errorf("Expected JSON fallback, got %+v", user)
+	}
+}
+
+func TestTextTargetAndRenderInto(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		// Mislabelled as JSON, which a Text target ignores
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte("db up\r\ncache down\n"))
+	}))
+	defer server.Close()
+
+	var status models.Text
+	resp, err := infrastructure.NewClient().SetBaseURL(server.URL).Get(context.Background(), "/status", nil, &status)
+	if err != nil {
+		t.Fatalf("Get failed: %v", err)
+	}
+	if status != "db up\r\ncache down\n" {
+		t.Errorf("unexpected text %q", status)
+	}
+	if lines := status.Lines(); len(lines) != 2 || lines[1] != "cache down" {
+		t.Errorf("unexpected lines %q", lines)
+	}
+
+	tmpl := template.Must(template.New("report").Parse(`{{.StatusCode}}:{{range .Lines}} [{{.}}]{{end}}`))
+	var report strings.Builder
+	if err := resp.RenderInto(tmpl, &report); err != nil {
+		t.Fatalf("RenderInto failed: %v", err)
+	}
+	if report.String() != "200: [db up] [cache down]" {
+		t.Errorf("unexpected report %q", report.String())
+	}
+}
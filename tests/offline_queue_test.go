@@ -0,0 +1,147 @@
+package tests
+
+import (
+	"context"
+	"encoding/json"
+	stderrors "errors"
+	"io"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/fourth-ally/gofetch/domain/models"
+	"github.com/fourth-ally/gofetch/infrastructure"
+)
+
+func TestOfflineQueueFlushesInOrder(t *testing.T) {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	addr := listener.Addr().String()
+	listener.Close()
+
+	path := filepath.Join(t.TempDir(), "queue.json")
+	store, err := infrastructure.NewFileQueueStore(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	client := infrastructure.NewClient().
+		SetBaseURL("http://"+addr).
+		SetOfflineQueue(store, nil)
+
+	for i := 1; i <= 2; i++ {
+		_, err := client.Post(context.Background(), "/events", nil, TestUser{ID: i}, nil)
+		if !stderrors.Is(err, infrastructure.ErrRequestQueued) {
+			t.Fatalf("Expected request to be queued, got %v", err)
+		}
+	}
+
+	// The queue survives a restart
+	reopened, err := infrastructure.NewFileQueueStore(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if queued, _ := reopened.List(); len(queued) != 2 {
+		t.Fatalf("Expected 2 persisted requests, got %d", len(queued))
+	}
+
+	var mu sync.Mutex
+	var received []int
+	listener, err = net.Listen("tcp", addr)
+	if err != nil {
+		t.Skipf("Could not rebind %s: %v", addr, err)
+	}
+	server := httptest.NewUnstartedServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost {
+			return
+		}
+
+		var user TestUser
+		json.NewDecoder(r.Body).Decode(&user)
+		mu.Lock()
+		received = append(received, user.ID)
+		mu.Unlock()
+	}))
+	server.Listener = listener
+	server.Start()
+	defer server.Close()
+
+	flushed := make(chan error, 2)
+	client.SetOfflineQueue(reopened, &models.OfflineQueueOptions{
+		OnFlushed: func(req *models.QueuedRequest, resp *models.Response, err error) {
+			flushed <- err
+		},
+	})
+
+	// A successful request signals connectivity and triggers the flush
+	if _, err := client.Get(context.Background(), "/health", nil, nil); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	for i := 0; i < 2; i++ {
+		select {
+		case err := <-flushed:
+			if err != nil {
+				t.Errorf("Expected flush to succeed, got %v", err)
+			}
+		case <-time.After(time.Second):
+			t.Fatal("Expected queued requests to be flushed")
+		}
+	}
+
+	mu.Lock()
+	defer mu.Unlock()
+	if len(received) != 2 || received[0] != 1 || received[1] != 2 {
+		t.Errorf("Expected requests replayed in order, got %v", received)
+	}
+	if queued, _ := reopened.List(); len(queued) != 0 {
+		t.Errorf("Expected empty queue after flush, got %d", len(queued))
+	}
+}
+
+func TestOfflineQueueReplaysEncodedBodiesBeforeNewMutations(t *testing.T) {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	addr := listener.Addr().String()
+	listener.Close()
+
+	client := infrastructure.NewClient().
+		SetBaseURL("http://"+addr).
+		SetOfflineQueue(infrastructure.NewMemoryQueueStore(), nil)
+
+	_, err = client.PostForm(context.Background(), "/form", url.Values{"name": {"gopher"}}, nil)
+	if !stderrors.Is(err, infrastructure.ErrRequestQueued) {
+		t.Fatalf("Expected request to be queued, got %v", err)
+	}
+
+	var mu sync.Mutex
+	var received []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		mu.Lock()
+		received = append(received, r.URL.Path+" "+r.Header.Get("Content-Type")+" "+string(body))
+		mu.Unlock()
+	}))
+	defer server.Close()
+	client.SetBaseURL(server.URL)
+
+	if _, err := client.Post(context.Background(), "/json", nil, TestUser{ID: 1}, nil); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	mu.Lock()
+	defer mu.Unlock()
+	if len(received) != 2 || received[0] != "/form application/x-www-form-urlencoded name=gopher" || !strings.HasPrefix(received[1], "/json application/json") {
+		t.Errorf("Expected the queued form before the new request, got %q", received)
+	}
+}
@@ -0,0 +1,238 @@
+package tests
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	stderrors "errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"net/http/httptrace"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/fourth-ally/gofetch/domain/errors"
+	"github.com/fourth-ally/gofetch/domain/models"
+	"github.com/fourth-ally/gofetch/infrastructure"
+)
+
+func TestGetWithOptionsOverridesConfig(t *testing.T) {
+	var header string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		header = r.Header.Get("X-Tenant")
+		if r.URL.Path == "/slow" {
+			time.Sleep(100 * time.Millisecond)
+		}
+		w.WriteHeader(http.StatusNotFound)
+	}))
+	defer server.Close()
+
+	client := infrastructure.NewClient().
+		SetBaseURL(server.URL).
+		SetHeader("X-Tenant", "default")
+
+	resp, err := client.GetWithOptions(context.Background(), "/users", nil, nil,
+		infrastructure.WithHeader("X-Tenant", "acme"),
+		infrastructure.WithStatusValidator(func(status int) bool { return status == http.StatusNotFound }),
+	)
+	if err != nil {
+		t.Fatalf("Expected overridden validator to accept 404, got %v", err)
+	}
+	if resp.StatusCode != http.StatusNotFound || header != "acme" {
+		t.Errorf("Expected 404 with overridden header, got %d and %q", resp.StatusCode, header)
+	}
+
+	if _, err := client.Get(context.Background(), "/users", nil, nil); err == nil {
+		t.Error("Expected client defaults to be unchanged")
+	}
+	if header != "default" {
+		t.Errorf("Expected default header, got %q", header)
+	}
+
+	_, err = client.GetWithOptions(context.Background(), "/slow", nil, nil, infrastructure.WithTimeout(20*time.Millisecond))
+	if err == nil {
+		t.Error("Expected per-request timeout to be enforced")
+	}
+}
+
+func TestWithBodyTeeCopiesAcceptedBodies(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/missing" {
+			w.WriteHeader(http.StatusNotFound)
+			w.Write([]byte(`{"error":"not found"}`))
+			return
+		}
+		w.Write([]byte(`{"name":"gofetch"}`))
+	}))
+	defer server.Close()
+
+	client := infrastructure.NewClient().SetBaseURL(server.URL)
+
+	var file bytes.Buffer
+	hash := sha256.New()
+	var result map[string]string
+	_, err := client.GetWithOptions(context.Background(), "/", nil, &result, infrastructure.WithBodyTee(&file, hash))
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	if result["name"] != "gofetch" {
+		t.Errorf("Expected body to be decoded, got %v", result)
+	}
+	if file.String() != `{"name":"gofetch"}` {
+		t.Errorf("Expected body copied to writer, got %q", file.String())
+	}
+	if want := sha256.Sum256([]byte(`{"name":"gofetch"}`)); !bytes.Equal(hash.Sum(nil), want[:]) {
+		t.Error("Expected body hashed in the same pass")
+	}
+
+	file.Reset()
+	client.GetWithOptions(context.Background(), "/missing", nil, nil, infrastructure.WithBodyTee(&file))
+	if file.Len() != 0 {
+		t.Errorf("Expected rejected body not to be copied, got %q", file.String())
+	}
+}
+
+type failingWriter struct{}
+
+func (failingWriter) Write([]byte) (int, error) { return 0, stderrors.New("disk full") }
+
+func TestWithBodyTeeReportsWriteErrors(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`{}`))
+	}))
+	defer server.Close()
+
+	client := infrastructure.NewClient().SetBaseURL(server.URL)
+	_, err := client.GetWithOptions(context.Background(), "/", nil, nil, infrastructure.WithBodyTee(failingWriter{}))
+	if err == nil || !strings.Contains(err.Error(), "disk full") {
+		t.Fatalf("Expected tee write error, got %v", err)
+	}
+
+	var transportErr *errors.TransportError
+	if stderrors.As(err, &transportErr) {
+		t.Errorf("Expected write error not to be classified as a transport error, got %v", err)
+	}
+}
+
+func TestWithRawResponseHandsOverBody(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/missing" {
+			w.WriteHeader(http.StatusNotFound)
+			w.Write([]byte("not found"))
+			return
+		}
+		w.Write([]byte(`{"streamed":true}`))
+	}))
+	defer server.Close()
+
+	client := infrastructure.NewClient().
+		SetBaseURL(server.URL).
+		SetBulkhead(&models.BulkheadOptions{MaxConcurrent: 1, MaxWait: -1})
+
+	var target map[string]interface{}
+	resp, err := client.GetWithOptions(context.Background(), "/", nil, &target, infrastructure.WithRawResponse())
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if resp.Body == nil || resp.RawBody != nil || target != nil {
+		t.Fatalf("Expected an unread body only, got RawBody %q and target %v", resp.RawBody, target)
+	}
+
+	// The open body still holds the only bulkhead slot
+	var fullErr *errors.BulkheadFullError
+	if _, err := client.Get(context.Background(), "/", nil, nil); !stderrors.As(err, &fullErr) {
+		t.Errorf("Expected the unread body to hold its bulkhead slot, got %v", err)
+	}
+
+	data, err := io.ReadAll(resp.Body)
+	if err != nil || string(data) != `{"streamed":true}` {
+		t.Errorf("Expected to read the body, got %q (%v)", data, err)
+	}
+	resp.Body.Close()
+
+	if _, err := client.Get(context.Background(), "/", nil, nil); err != nil {
+		t.Errorf("Expected closing the body to release its slot, got %v", err)
+	}
+
+	_, err = client.GetWithOptions(context.Background(), "/missing", nil, nil, infrastructure.WithRawResponse())
+	var httpErr *errors.HTTPError
+	if !stderrors.As(err, &httpErr) || string(httpErr.Body) != "not found" {
+		t.Errorf("Expected rejected body in the error, got %v", err)
+	}
+}
+
+func TestWithClientTraceRunsAlongsideTimings(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`{}`))
+	}))
+	defer server.Close()
+
+	client := infrastructure.NewClient().SetBaseURL(server.URL)
+
+	var gotConn, wroteRequest, contextTrace bool
+	trace := &httptrace.ClientTrace{
+		GotConn:      func(httptrace.GotConnInfo) { gotConn = true },
+		WroteRequest: func(httptrace.WroteRequestInfo) { wroteRequest = true },
+	}
+	ctx := httptrace.WithClientTrace(context.Background(), &httptrace.ClientTrace{
+		GotFirstResponseByte: func() { contextTrace = true },
+	})
+
+	resp, err := client.GetWithOptions(ctx, "/", nil, nil, infrastructure.WithClientTrace(trace))
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	if !gotConn || !wroteRequest || !contextTrace {
+		t.Errorf("Expected all trace hooks to run, got GotConn %v, WroteRequest %v, context trace %v", gotConn, wroteRequest, contextTrace)
+	}
+	if resp.Timings == nil || resp.Timings.Total <= 0 {
+		t.Errorf("Expected built-in timings to be collected, got %+v", resp.Timings)
+	}
+}
+
+func TestVerbMethodsAcceptRequestOptions(t *testing.T) {
+	var calls int
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		if calls == 1 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		w.Header().Set("X-Echo", r.Header.Get("X-Tenant")+"|"+r.URL.RawQuery)
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	client := infrastructure.NewClient().SetBaseURL(server.URL)
+	params := map[string]interface{}{"page": 1}
+	retry := &models.RetryOptions{MaxRetries: 2, InitialDelay: time.Millisecond, MaxDelay: time.Millisecond}
+
+	resp, err := client.Get(context.Background(), "/users", params, nil,
+		infrastructure.WithHeader("X-Tenant", "acme"),
+		infrastructure.WithQuery("page", 2),
+		infrastructure.WithTimeout(time.Second),
+		infrastructure.WithRetryPolicy(retry),
+	)
+	if err != nil {
+		t.Fatalf("Get failed: %v", err)
+	}
+	if calls != 2 {
+		t.Errorf("expected the per-request retry policy to retry once, got %d calls", calls)
+	}
+	if got := resp.Headers.Get("X-Echo"); got != "acme|page=2" {
+		t.Errorf("unexpected request overrides: %q", got)
+	}
+	if params["page"] != 1 {
+		t.Error("WithQuery modified the caller's params")
+	}
+
+	// Without the option the client has no retries
+	calls = 0
+	if _, err := client.Get(context.Background(), "/users", nil, nil); err == nil || calls != 1 {
+		t.Errorf("expected a single failed attempt, got %d calls and %v", calls, err)
+	}
+}
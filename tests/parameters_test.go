@@ -3,10 +3,20 @@ package tests
 import (
 	"context"
 	"encoding/json"
+	stderrors "errors"
+	"net"
 	"net/http"
 	"net/http/httptest"
+	"net/url"
+	"sort"
+	"strings"
 	"testing"
+	"time"
 
+	"github.com/fourth-ally/gofetch"
+	"github.com/fourth-ally/gofetch/domain/contracts"
+	"github.com/fourth-ally/gofetch/domain/errors"
+	"github.com/fourth-ally/gofetch/domain/models"
 	"github.com/fourth-ally/gofetch/infrastructure"
 )
 
@@ -68,3 +78,239 @@ func TestQueryParameters(t *testing.T) {
 		t.Errorf("Expected 1 user, got %d", len(users))
 	}
 }
+
+func TestURITemplateParameters(t *testing.T) {
+	client := infrastructure.NewClient().SetBaseURL("https://api.example.com")
+
+	tests := []struct {
+		path   string
+		params map[string]interface{}
+		want   string
+	}{
+		{"/users/{id}/posts{?page,limit}", map[string]interface{}{"id": 7, "page": 2}, "/users/7/posts?page=2"},
+		{"/files/{name}", map[string]interface{}{"name": "a b/c"}, "/files/a%20b%2Fc"},
+		{"/files/{+name}", map[string]interface{}{"name": "a b/c"}, "/files/a%20b/c"},
+		{"/search{?q,tags}", map[string]interface{}{"q": "café", "tags": []string{"x", "y"}}, "/search?q=caf%C3%A9&tags=x,y"},
+		{"/search{?tags*}", map[string]interface{}{"tags": []string{"x", "y"}}, "/search?tags=x&tags=y"},
+		{"/items{/path*}{;v}", map[string]interface{}{"path": []string{"a", "b"}, "v": ""}, "/items/a/b;v"},
+		{"/users/{id}{?fields}", map[string]interface{}{"id": "abcdef", "sort": "name"}, "/users/abcdef?sort=name"},
+		{"/users/{id:3}", map[string]interface{}{"id": "abcdef"}, "/users/abc"},
+		{"/orgs/{org}/users/:id", map[string]interface{}{"org": "acme", "id": 1}, "/orgs/acme/users/1"},
+	}
+
+	for _, tt := range tests {
+		plan, err := client.Plan(context.Background(), http.MethodGet, tt.path, tt.params, nil)
+		if err != nil {
+			t.Fatalf("%s: %v", tt.path, err)
+		}
+		if want := "https://api.example.com" + tt.want; plan.URL != want {
+			t.Errorf("%s: expected %s, got %s", tt.path, want, plan.URL)
+		}
+	}
+
+	if _, err := client.Plan(context.Background(), http.MethodGet, "/users/{id", nil, nil); err == nil {
+		t.Error("expected an unclosed expression to be rejected")
+	}
+
+	for _, path := range []string{"/files/{name}/x", "/files{/name}/x", "/files{/names*}/x"} {
+		_, err := client.Plan(context.Background(), http.MethodGet, path, map[string]interface{}{"name": "..", "names": []string{"a", "."}}, nil)
+		if gofetch.CodeOf(err) != errors.CodeInvalidURL {
+			t.Errorf("%s: expected a dot segment to be rejected, got %v", path, err)
+		}
+	}
+}
+
+func TestSliceQueryParameterFormats(t *testing.T) {
+	params := map[string]interface{}{"tag": []string{"a", "b"}, "id": [2]int{1, 2}, "empty": []string{}}
+
+	tests := []struct {
+		format models.ArrayFormat
+		want   string
+	}{
+		{models.ArrayRepeat, "?id=1&id=2&tag=a&tag=b"},
+		{models.ArrayComma, "?id=1%2C2&tag=a%2Cb"},
+		{models.ArrayBrackets, "?id%5B%5D=1&id%5B%5D=2&tag%5B%5D=a&tag%5B%5D=b"},
+	}
+
+	for _, tt := range tests {
+		client := infrastructure.NewClient().
+			SetBaseURL("https://api.example.com").
+			SetQueryArrayFormat(tt.format)
+
+		plan, err := client.Plan(context.Background(), http.MethodGet, "/items", params, nil)
+		if err != nil {
+			t.Fatalf("Plan failed: %v", err)
+		}
+		if want := "https://api.example.com/items" + tt.want; plan.URL != want {
+			t.Errorf("format %d: expected %s, got %s", tt.format, want, plan.URL)
+		}
+	}
+}
+
+func TestPathParametersAreEscaped(t *testing.T) {
+	client := infrastructure.NewClient().SetBaseURL("https://api.example.com/v1/")
+
+	tests := []struct {
+		path   string
+		params map[string]interface{}
+		want   string
+	}{
+		{"/files/:name", map[string]interface{}{"name": "a/b c?d"}, "https://api.example.com/v1/files/a%2Fb%20c%3Fd"},
+		{"/users/:id/:identifier", map[string]interface{}{"id": 1, "identifier": "x"}, "https://api.example.com/v1/users/1/x"},
+		{"/search?q=:term", map[string]interface{}{"term": "a&b"}, "https://api.example.com/v1/search?q=a%26b"},
+		{"/items?page=2", map[string]interface{}{"size": 10}, "https://api.example.com/v1/items?page=2&size=10"},
+		{"projects/1a:archive", nil, "https://api.example.com/v1/projects/1a:archive"},
+	}
+	for _, tt := range tests {
+		plan, err := client.Plan(context.Background(), http.MethodGet, tt.path, tt.params, nil)
+		if err != nil {
+			t.Fatalf("%s: %v", tt.path, err)
+		}
+		if plan.URL != tt.want {
+			t.Errorf("%s: expected %s, got %s", tt.path, tt.want, plan.URL)
+		}
+	}
+
+	_, err := client.Get(context.Background(), "/users/:id/secrets", map[string]interface{}{"id": ".."}, nil)
+	var urlErr *errors.URLError
+	if !stderrors.As(err, &urlErr) || gofetch.CodeOf(err) != errors.CodeInvalidURL {
+		t.Errorf("expected a dot segment to be rejected with a URLError, got %v", err)
+	}
+
+	_, err = infrastructure.NewClient().SetBaseURL("http://[::1").Get(context.Background(), "/users", nil, nil)
+	if !stderrors.As(err, &urlErr) {
+		t.Errorf("expected a malformed base URL to be reported as a URLError, got %v", err)
+	}
+
+	legacy := infrastructure.NewClient().SetBaseURL("https://api.example.com").SetLegacyURLBuilding(true)
+	plan, err := legacy.Plan(context.Background(), http.MethodGet, "/files/:name", map[string]interface{}{"name": "a/b"}, nil)
+	if err != nil || plan.URL != "https://api.example.com/files/a/b" {
+		t.Errorf("expected legacy building to substitute verbatim, got %v, %v", plan, err)
+	}
+}
+
+func TestBaseURLPathIsPreserved(t *testing.T) {
+	tests := []struct {
+		baseURL string
+		policy  models.URLJoinPolicy
+		path    string
+		params  map[string]interface{}
+		want    string
+	}{
+		{"https://host/api/v2", models.JoinAppend, "/users", nil, "https://host/api/v2/users"},
+		{"https://host/api/v2/", models.JoinAppend, "users/", nil, "https://host/api/v2/users/"},
+		{"https://host/api/v2", models.JoinAppend, "", nil, "https://host/api/v2"},
+		{"https://host/api/v2?key=abc", models.JoinAppend, "/users?page=2", map[string]interface{}{"size": 10}, "https://host/api/v2/users?key=abc&page=2&size=10"},
+		{"https://host/api/v2?key=abc", models.JoinAppend, "/users", nil, "https://host/api/v2/users?key=abc"},
+		{"https://host/api/v2", models.JoinResolve, "/users", nil, "https://host/users"},
+		{"https://host/api/v2", models.JoinResolve, "users", nil, "https://host/api/users"},
+		{"https://host/api/v2/", models.JoinResolve, "users?page=2", nil, "https://host/api/v2/users?page=2"},
+	}
+
+	for _, tt := range tests {
+		client := infrastructure.NewClient().SetBaseURL(tt.baseURL).SetURLJoinPolicy(tt.policy)
+		plan, err := client.Plan(context.Background(), http.MethodGet, tt.path, tt.params, nil)
+		if err != nil {
+			t.Fatalf("%s + %s: %v", tt.baseURL, tt.path, err)
+		}
+		if plan.URL != tt.want {
+			t.Errorf("%s + %s (policy %d): expected %s, got %s", tt.baseURL, tt.path, tt.policy, tt.want, plan.URL)
+		}
+	}
+}
+
+func TestCustomQueryEncoder(t *testing.T) {
+	// PHP-style nested brackets for maps and JSON filters, other params
+	// handled by the default encoder
+	fallback := infrastructure.DefaultQueryEncoder(models.ArrayBrackets)
+	encoder := contracts.QueryEncoderFunc(func(params map[string]interface{}) (string, error) {
+		var parts []string
+		rest := make(map[string]interface{})
+		for key, value := range params {
+			switch v := value.(type) {
+			case map[string]string:
+				for field, fieldValue := range v {
+					parts = append(parts, url.QueryEscape(key+"["+field+"]")+"="+url.QueryEscape(fieldValue))
+				}
+			case filter:
+				data, err := json.Marshal(v)
+				if err != nil {
+					return "", err
+				}
+				parts = append(parts, key+"="+url.QueryEscape(string(data)))
+			default:
+				rest[key] = value
+			}
+		}
+
+		query, err := fallback.EncodeQuery(rest)
+		if query != "" {
+			parts = append(parts, query)
+		}
+		sort.Strings(parts)
+		return strings.Join(parts, "&"), err
+	})
+
+	client := infrastructure.NewClient().SetBaseURL("https://api.example.com").SetQueryEncoder(encoder)
+	plan, err := client.Plan(context.Background(), http.MethodGet, "/users/:id", map[string]interface{}{
+		"id":     7,
+		"user":   map[string]string{"name": "ada"},
+		"filter": filter{Status: "active"},
+		"tags":   []string{"a"},
+	}, nil)
+	if err != nil {
+		t.Fatalf("Plan failed: %v", err)
+	}
+
+	want := "https://api.example.com/users/7?filter=%7B%22status%22%3A%22active%22%7D&tags%5B%5D=a&user%5Bname%5D=ada"
+	if plan.URL != want {
+		t.Errorf("expected %s, got %s", want, plan.URL)
+	}
+
+	failing := contracts.QueryEncoderFunc(func(map[string]interface{}) (string, error) {
+		return "", stderrors.New("unsupported value")
+	})
+	_, err = infrastructure.NewClient().SetQueryEncoder(failing).Get(context.Background(), "http://localhost/x", map[string]interface{}{"a": 1}, nil)
+	var urlErr *errors.URLError
+	if !stderrors.As(err, &urlErr) {
+		t.Errorf("expected encoder errors to surface as a URLError, got %v", err)
+	}
+}
+
+type filter struct {
+	Status string `json:"status"`
+}
+
+func TestTypedParamFormatting(t *testing.T) {
+	since := time.Date(2024, 3, 1, 12, 30, 0, 0, time.UTC)
+	params := map[string]interface{}{
+		"day":   since,
+		"since": since,
+		"ip":    net.ParseIP("10.0.0.1"),
+		"level": level(2),
+	}
+
+	client := infrastructure.NewClient().SetBaseURL("https://api.example.com")
+	plan, err := client.Plan(context.Background(), http.MethodGet, "/reports/:day{?level}", params, nil)
+	if err != nil {
+		t.Fatalf("Plan failed: %v", err)
+	}
+	want := "https://api.example.com/reports/2024-03-01T12:30:00Z?level=warn&ip=10.0.0.1&since=2024-03-01T12%3A30%3A00Z"
+	if plan.URL != want {
+		t.Errorf("expected %s, got %s", want, plan.URL)
+	}
+
+	plan, err = client.SetTimeLayout(time.DateOnly).Plan(context.Background(), http.MethodGet, "/reports/:day", map[string]interface{}{"day": &since}, nil)
+	if err != nil {
+		t.Fatalf("Plan failed: %v", err)
+	}
+	if want := "https://api.example.com/reports/2024-03-01"; plan.URL != want {
+		t.Errorf("expected %s with a custom layout, got %s", want, plan.URL)
+	}
+}
+
+type level int
+
+func (l level) String() string {
+	return [...]string{"debug", "info", "warn"}[l]
+}
@@ -0,0 +1,70 @@
+package tests
+
+import (
+	"context"
+	"net/http"
+	"net/url"
+	"testing"
+
+	"github.com/fourth-ally/gofetch/domain/models"
+	"github.com/fourth-ally/gofetch/infrastructure"
+)
+
+func TestPlanBuildsRequestWithoutSending(t *testing.T) {
+	sent := false
+	client := infrastructure.NewClient().
+		SetBaseURL("https://api.example.com").
+		SetHeader("Authorization", "Bearer secret").
+		SetIdempotencyKeys(true).
+		SetTransport(roundTripperFunc(func(r *http.Request) (*http.Response, error) {
+			sent = true
+			return nil, http.ErrNotSupported
+		})).
+		AddRequestInterceptor(func(req *http.Request) (*http.Request, error) {
+			req.Header.Set("X-Intercepted", "yes")
+			return req, nil
+		})
+
+	plan, err := client.Plan(context.Background(), http.MethodPost, "/users/:id",
+		map[string]interface{}{"id": 42, "notify": true},
+		map[string]string{"name": "Ada"},
+		infrastructure.WithPriority(models.PriorityHigh))
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	if sent {
+		t.Error("Expected Plan not to send the request")
+	}
+	if plan.Method != http.MethodPost || plan.URL != "https://api.example.com/users/42?notify=true" {
+		t.Errorf("Unexpected method or URL: %s %s", plan.Method, plan.URL)
+	}
+	if string(plan.Body) != `{"name":"Ada"}` {
+		t.Errorf("Expected encoded body, got %q", plan.Body)
+	}
+	if plan.Headers.Get("X-Intercepted") != "yes" || plan.Headers.Get("Idempotency-Key") == "" {
+		t.Errorf("Expected headers after interceptors and idempotency keys, got %v", plan.Headers)
+	}
+	if plan.Headers.Get("Authorization") != "Bearer secret" {
+		t.Errorf("Expected wire headers to be exact, got %q", plan.Headers.Get("Authorization"))
+	}
+	if plan.Config.Headers["Authorization"] != "[REDACTED]" || plan.Config.Priority != models.PriorityHigh {
+		t.Errorf("Expected redacted effective config with overrides, got %+v", plan.Config)
+	}
+}
+
+func TestPlanChoosesFirstBaseURL(t *testing.T) {
+	client := infrastructure.NewClient().SetBaseURLs([]string{"https://primary.example.com", "https://mirror.example.com"})
+
+	plan, err := client.Plan(context.Background(), http.MethodPost, "/token", nil, url.Values{"grant_type": {"password"}})
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	if plan.URL != "https://primary.example.com/token" {
+		t.Errorf("Expected primary base URL, got %q", plan.URL)
+	}
+	if plan.Headers.Get("Content-Type") != "application/x-www-form-urlencoded" || string(plan.Body) != "grant_type=password" {
+		t.Errorf("Expected form body, got %q with %q", plan.Body, plan.Headers.Get("Content-Type"))
+	}
+}
@@ -0,0 +1,53 @@
+package tests
+
+import (
+	"context"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/fourth-ally/gofetch"
+	"github.com/fourth-ally/gofetch/domain/models"
+)
+
+func TestPresetPoliciesAreConsistent(t *testing.T) {
+	for _, policy := range []*models.Policy{gofetch.PolicyStrictInternal, gofetch.PolicyPublicAPI} {
+		client := gofetch.NewClient().ApplyPolicy(policy)
+		if err := client.Validate(); err != nil {
+			t.Errorf("Expected policy %s to be consistent, got %v", policy.Name, err)
+		}
+		if client.Config().Timeout != policy.Timeout {
+			t.Errorf("Expected policy %s timeout to be applied", policy.Name)
+		}
+	}
+}
+
+func TestApplyPolicyLayersSettings(t *testing.T) {
+	attempts := 0
+	var header string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		attempts++
+		header = r.Header.Get("X-Org")
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}))
+	defer server.Close()
+
+	client := gofetch.NewClient().
+		SetBaseURL(server.URL).
+		ApplyPolicy(&models.Policy{
+			RetryOptions: &models.RetryOptions{MaxRetries: 2, InitialDelay: time.Millisecond, MaxDelay: time.Millisecond},
+			Logger:       slog.Default(),
+		}).
+		ApplyPolicy(&models.Policy{Headers: map[string]string{"X-Org": "acme"}})
+
+	client.Get(context.Background(), "/", nil, nil)
+
+	if attempts != 3 {
+		t.Errorf("Expected retry policy to allow 3 attempts, got %d", attempts)
+	}
+	if header != "acme" {
+		t.Errorf("Expected policy header, got %q", header)
+	}
+}
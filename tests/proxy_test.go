@@ -0,0 +1,82 @@
+package tests
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/fourth-ally/gofetch/domain/models"
+	"github.com/fourth-ally/gofetch/infrastructure"
+)
+
+func TestProxyFallsBackAfterFailures(t *testing.T) {
+	origin := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`{"id":1}`))
+	}))
+	defer origin.Close()
+
+	proxied := 0
+	proxy := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		proxied++
+		w.Write([]byte(`{"id":2}`))
+	}))
+	defer proxy.Close()
+
+	dead := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+	deadURL := dead.URL
+	dead.Close()
+
+	client := infrastructure.NewClient().
+		SetBaseURL(origin.URL).
+		SetProxies([]string{deadURL, proxy.URL, models.ProxyDirect}, &models.ProxyOptions{
+			FailureThreshold: 1,
+			Cooldown:         time.Minute,
+		})
+
+	if _, err := client.Get(context.Background(), "/users/1", nil, nil); err == nil {
+		t.Fatal("Expected request through dead proxy to fail")
+	}
+
+	var user TestUser
+	if _, err := client.Get(context.Background(), "/users/1", nil, &user); err != nil {
+		t.Fatalf("Expected fallback proxy to be used, got %v", err)
+	}
+	if user.ID != 2 || proxied != 1 {
+		t.Errorf("Expected response via fallback proxy, got id=%d proxied=%d", user.ID, proxied)
+	}
+
+	status := client.ProxyStatus()
+	if status[deadURL] || !status[proxy.URL] {
+		t.Errorf("Unexpected proxy status %v", status)
+	}
+}
+
+func TestProxyFallsBackToDirect(t *testing.T) {
+	origin := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`{"id":1}`))
+	}))
+	defer origin.Close()
+
+	dead := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+	deadURL := dead.URL
+	dead.Close()
+
+	client := infrastructure.NewClient().
+		SetBaseURL(origin.URL).
+		SetProxies([]string{deadURL, models.ProxyDirect}, &models.ProxyOptions{
+			FailureThreshold: 1,
+			Cooldown:         time.Minute,
+		})
+
+	client.Get(context.Background(), "/users/1", nil, nil)
+
+	var user TestUser
+	if _, err := client.Get(context.Background(), "/users/1", nil, &user); err != nil {
+		t.Fatalf("Expected direct connection, got %v", err)
+	}
+	if user.ID != 1 {
+		t.Errorf("Expected response from origin, got id=%d", user.ID)
+	}
+}
@@ -0,0 +1,62 @@
+package tests
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/fourth-ally/gofetch/domain/models"
+	"github.com/fourth-ally/gofetch/infrastructure"
+)
+
+func TestRateLimitThrottlesRequests(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+	defer server.Close()
+
+	client := infrastructure.NewClient().
+		SetBaseURL(server.URL).
+		SetRateLimit(&models.RateLimit{RequestsPerSecond: 20, Burst: 1})
+
+	start := time.Now()
+	for i := 0; i < 4; i++ {
+		client.Get(context.Background(), "/", nil, nil)
+	}
+
+	if elapsed := time.Since(start); elapsed < 140*time.Millisecond {
+		t.Errorf("Expected requests to be throttled to 20 rps, took %v", elapsed)
+	}
+}
+
+func TestHostRateLimitsAreIndependent(t *testing.T) {
+	slow := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+	defer slow.Close()
+	fast := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+	defer fast.Close()
+
+	slowURL, _ := url.Parse(slow.URL)
+	client := infrastructure.NewClient().SetHostRateLimits(map[string]models.RateLimit{
+		slowURL.Host: {RequestsPerSecond: 1, Burst: 1},
+	})
+
+	slowClient := client.NewInstance().SetBaseURL(slow.URL)
+	fastClient := client.NewInstance().SetBaseURL(fast.URL)
+
+	slowClient.Get(context.Background(), "/", nil, nil)
+
+	start := time.Now()
+	for i := 0; i < 5; i++ {
+		fastClient.Get(context.Background(), "/", nil, nil)
+	}
+	if elapsed := time.Since(start); elapsed > 500*time.Millisecond {
+		t.Errorf("Expected unlimited host not to be throttled, took %v", elapsed)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+	if _, err := slowClient.Get(ctx, "/", nil, nil); err == nil {
+		t.Error("Expected limited host to wait for its quota")
+	}
+}
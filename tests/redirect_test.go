@@ -0,0 +1,48 @@
+package tests
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/fourth-ally/gofetch/infrastructure"
+)
+
+func TestResponseRecordsRedirectChain(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/old":
+			http.Redirect(w, r, "/moved", http.StatusMovedPermanently)
+		case "/moved":
+			http.Redirect(w, r, "/final", http.StatusTemporaryRedirect)
+		default:
+			w.Write([]byte(`{}`))
+		}
+	}))
+	defer server.Close()
+
+	client := infrastructure.NewClient().SetBaseURL(server.URL)
+
+	resp, err := client.Get(context.Background(), "/old", nil, nil)
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	if len(resp.Redirects) != 2 {
+		t.Fatalf("Expected 2 redirects, got %d", len(resp.Redirects))
+	}
+
+	first, second := resp.Redirects[0], resp.Redirects[1]
+	if first.URL != server.URL+"/old" || first.StatusCode != http.StatusMovedPermanently || first.Location != "/moved" {
+		t.Errorf("Unexpected first hop %+v", first)
+	}
+	if second.URL != server.URL+"/moved" || second.StatusCode != http.StatusTemporaryRedirect || second.Location != "/final" {
+		t.Errorf("Unexpected second hop %+v", second)
+	}
+
+	resp, _ = client.Get(context.Background(), "/final", nil, nil)
+	if resp.Redirects != nil {
+		t.Errorf("Expected no redirects, got %+v", resp.Redirects)
+	}
+}
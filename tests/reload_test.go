@@ -0,0 +1,175 @@
+package tests
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/fourth-ally/gofetch/domain/models"
+	"github.com/fourth-ally/gofetch/infrastructure"
+)
+
+func TestReloadSwapsBaseURLAndHeaders(t *testing.T) {
+	newServer := func(name string) *httptest.Server {
+		return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.Write([]byte(`{"server":"` + name + `","tenant":"` + r.Header.Get("X-Tenant") + `"}`))
+		}))
+	}
+	blue, green := newServer("blue"), newServer("green")
+	defer blue.Close()
+	defer green.Close()
+
+	client := infrastructure.NewClient().SetBaseURL(blue.URL).SetHeader("X-Tenant", "old")
+
+	var wg sync.WaitGroup
+	stop := make(chan struct{})
+	for i := 0; i < 4; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for {
+				select {
+				case <-stop:
+					return
+				default:
+					client.Get(context.Background(), "/", nil, nil)
+				}
+			}
+		}()
+	}
+
+	err := client.Reload(&models.ReloadOptions{
+		BaseURL: green.URL,
+		Headers: map[string]string{"X-Tenant": "new"},
+		Policy:  &models.Policy{Timeout: 5 * time.Second},
+	})
+	close(stop)
+	wg.Wait()
+
+	if err != nil {
+		t.Fatalf("Expected reload to succeed, got %v", err)
+	}
+
+	var result map[string]string
+	if _, err := client.Get(context.Background(), "/", nil, &result); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if result["server"] != "green" || result["tenant"] != "new" {
+		t.Errorf("Expected reloaded base URL and headers, got %v", result)
+	}
+	if client.Config().Timeout != 5*time.Second {
+		t.Errorf("Expected policy timeout, got %v", client.Config().Timeout)
+	}
+}
+
+func TestReloadKeepsSettingsOfInFlightRequests(t *testing.T) {
+	reloaded := make(chan struct{})
+	var mu sync.Mutex
+	var tenants []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		tenants = append(tenants, r.Header.Get("X-Tenant"))
+		first := len(tenants) == 1
+		mu.Unlock()
+		if first {
+			<-reloaded
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		w.Write([]byte(`{}`))
+	}))
+	defer server.Close()
+
+	client := infrastructure.NewClient().
+		SetBaseURL(server.URL).
+		SetHeader("X-Tenant", "old").
+		SetRetryOptions(&models.RetryOptions{MaxRetries: 1, InitialDelay: time.Millisecond, Backoff: models.BackoffFixed, RetryOnStatusCodes: []int{http.StatusServiceUnavailable}})
+
+	done := make(chan error)
+	go func() {
+		_, err := client.Get(context.Background(), "/", nil, nil)
+		done <- err
+	}()
+
+	for {
+		mu.Lock()
+		started := len(tenants) > 0
+		mu.Unlock()
+		if started {
+			break
+		}
+		time.Sleep(time.Millisecond)
+	}
+	err := client.Reload(&models.ReloadOptions{
+		Headers: map[string]string{"X-Tenant": "new"},
+		Policy:  &models.Policy{RetryOptions: &models.RetryOptions{}},
+	})
+	close(reloaded)
+	if err != nil {
+		t.Fatalf("Expected reload to succeed, got %v", err)
+	}
+
+	if err := <-done; err != nil {
+		t.Fatalf("Expected the retry to succeed, got %v", err)
+	}
+	mu.Lock()
+	defer mu.Unlock()
+	if len(tenants) != 2 || tenants[1] != "old" {
+		t.Errorf("Expected the retry to keep the settings it started with, got %v", tenants)
+	}
+}
+
+func TestReloadTLSRequiresHTTPTransport(t *testing.T) {
+	client := infrastructure.NewClient().
+		SetBaseURL("https://old.example.com").
+		SetTransport(roundTripperFunc(func(r *http.Request) (*http.Response, error) {
+			return nil, http.ErrNotSupported
+		}))
+
+	err := client.Reload(&models.ReloadOptions{
+		BaseURL: "https://new.example.com",
+		Policy:  &models.Policy{MinTLSVersion: 0x0304},
+	})
+	if err == nil || !strings.Contains(err.Error(), "Transport") {
+		t.Fatalf("Expected ConfigError for custom transport, got %v", err)
+	}
+	if client.Config().BaseURL != "https://old.example.com" {
+		t.Errorf("Expected failed reload to leave config unchanged, got %q", client.Config().BaseURL)
+	}
+}
+
+func TestWatchConfigFileReloadsOnChange(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "client.conf")
+	os.WriteFile(path, []byte("https://first.example.com"), 0o600)
+
+	client := infrastructure.NewClient().SetBaseURL("https://first.example.com")
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	client.WatchConfigFile(ctx, path, 10*time.Millisecond, func(data []byte) (*models.ReloadOptions, error) {
+		return &models.ReloadOptions{BaseURL: strings.TrimSpace(string(data))}, nil
+	}, func(err error) { t.Errorf("Unexpected watch error: %v", err) })
+
+	future := time.Now().Add(time.Second)
+	os.WriteFile(path, []byte("https://second.example.com"), 0o600)
+	os.Chtimes(path, future, future)
+
+	deadline := time.Now().Add(2 * time.Second)
+	for client.Config().BaseURL != "https://second.example.com" {
+		if time.Now().After(deadline) {
+			t.Fatalf("Expected config file change to be reloaded, got %q", client.Config().BaseURL)
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+}
+
+type roundTripperFunc func(*http.Request) (*http.Response, error)
+
+func (f roundTripperFunc) RoundTrip(r *http.Request) (*http.Response, error) { return f(r) }
@@ -0,0 +1,127 @@
+package tests
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/fourth-ally/gofetch/domain/models"
+	"github.com/fourth-ally/gofetch/infrastructure"
+)
+
+func TestJournalReplayAgainstOtherEnvironment(t *testing.T) {
+	recorded := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		w.Header().Set("Content-Type", "application/json")
+		switch r.URL.Path {
+		case "/users":
+			w.Write([]byte(`{"id": 1, "name": "Alice"}`))
+		case "/echo":
+			w.Write(body)
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer recorded.Close()
+
+	var journal bytes.Buffer
+	client := infrastructure.NewClient().
+		SetBaseURL(recorded.URL).
+		SetHeader("Authorization", "Bearer old").
+		SetJournal(&journal)
+
+	client.Get(context.Background(), "/users", map[string]interface{}{"active": true}, nil)
+	client.Post(context.Background(), "/echo", nil, map[string]string{"ping": "pong"}, nil)
+	client.Get(context.Background(), "/missing", nil, nil)
+
+	if strings.Contains(journal.String(), "Bearer old") {
+		t.Fatal("Expected credentials to be redacted from the journal")
+	}
+
+	exchanges, err := infrastructure.LoadJournal(&journal)
+	if err != nil {
+		t.Fatalf("Expected journal to load, got %v", err)
+	}
+	if len(exchanges) != 3 {
+		t.Fatalf("Expected 3 recorded exchanges, got %d", len(exchanges))
+	}
+
+	// The new environment reorders JSON fields, changes one user and finds the missing path
+	var authorizations []string
+	target := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		authorizations = append(authorizations, r.Header.Get("Authorization"))
+		body, _ := io.ReadAll(r.Body)
+		switch r.URL.Path {
+		case "/users":
+			if r.URL.Query().Get("active") != "true" {
+				t.Errorf("Expected recorded query to be replayed, got %q", r.URL.RawQuery)
+			}
+			w.Write([]byte(`{"name":"Alicia","id":1}`))
+		case "/echo":
+			w.Write(body)
+		default:
+			w.Write([]byte(`{}`))
+		}
+	}))
+	defer target.Close()
+
+	replayer := infrastructure.NewClient().SetBaseURL(target.URL).SetHeader("Authorization", "Bearer new")
+	report, err := replayer.Replay(context.Background(), exchanges, nil)
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	if report.Mismatches != 2 || report.Failures != 0 {
+		t.Errorf("Expected 2 mismatches, got %+v", report)
+	}
+	if !report.Results[0].BodyMismatch || report.Results[0].StatusMismatch {
+		t.Errorf("Expected body mismatch on /users, got %+v", report.Results[0])
+	}
+	if report.Results[1].Mismatch() {
+		t.Errorf("Expected echo to match, got %+v", report.Results[1])
+	}
+	if !report.Results[2].StatusMismatch || report.Results[2].Status != http.StatusOK {
+		t.Errorf("Expected status mismatch on /missing, got %+v", report.Results[2])
+	}
+	for _, authorization := range authorizations {
+		if authorization != "Bearer new" {
+			t.Errorf("Expected the replaying client's credentials, got %q", authorization)
+		}
+	}
+}
+
+func TestLoadHAR(t *testing.T) {
+	har := `{"log": {"entries": [{
+		"startedDateTime": "2024-05-01T10:00:00.000Z",
+		"request": {
+			"method": "POST",
+			"url": "https://api.example.com/items?x=1",
+			"headers": [{"name": "content-type", "value": "application/json"}, {"name": "cookie", "value": "session=1"}],
+			"postData": {"mimeType": "application/json", "text": "{\"a\":1}"}
+		},
+		"response": {"status": 201, "content": {"text": "eyJpZCI6N30=", "encoding": "base64"}}
+	}]}}`
+
+	exchanges, err := infrastructure.LoadHAR(strings.NewReader(har))
+	if err != nil {
+		t.Fatalf("Expected HAR to load, got %v", err)
+	}
+	if len(exchanges) != 1 {
+		t.Fatalf("Expected 1 exchange, got %d", len(exchanges))
+	}
+
+	exchange := exchanges[0]
+	if exchange.Method != http.MethodPost || exchange.Status != http.StatusCreated || string(exchange.Body) != `{"a":1}` {
+		t.Errorf("Unexpected exchange: %+v", exchange)
+	}
+	if string(exchange.ResponseBody) != `{"id":7}` {
+		t.Errorf("Expected base64 response body to be decoded, got %q", exchange.ResponseBody)
+	}
+	if exchange.Headers["Content-Type"] != "application/json" || !models.IsRedacted(exchange.Headers["Cookie"]) {
+		t.Errorf("Expected canonical headers with cookies redacted, got %v", exchange.Headers)
+	}
+}
@@ -0,0 +1,72 @@
+package tests
+
+import (
+	"context"
+	"encoding/json"
+	stderrors "errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/fourth-ally/gofetch/domain/errors"
+	"github.com/fourth-ally/gofetch/infrastructure"
+)
+
+func TestRequestBuilder(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("X-Source") != "builder" {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		switch r.Method {
+		case http.MethodPost:
+			var user TestUser
+			json.NewDecoder(r.Body).Decode(&user)
+			user.ID = 9
+			json.NewEncoder(w).Encode(user)
+		case http.MethodGet:
+			if r.URL.Path != "/users/7" || r.URL.Query().Get("expand") != "teams" {
+				w.WriteHeader(http.StatusNotFound)
+				w.Write([]byte(`{"code": "not_found", "message": "no such user"}`))
+				return
+			}
+			w.Write([]byte(`{"id": 7, "name": "Ada"}`))
+		}
+	}))
+	defer server.Close()
+
+	client := infrastructure.NewClient().SetBaseURL(server.URL)
+
+	var created TestUser
+	resp, err := client.R().
+		SetHeader("X-Source", "builder").
+		SetBody(TestUser{Name: "Grace"}).
+		SetResult(&created).
+		Post(context.Background(), "/users")
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if created.ID != 9 || created.Name != "Grace" || resp.Data != &created {
+		t.Errorf("Expected created user, got %+v", created)
+	}
+
+	var user TestUser
+	request := client.R().
+		SetHeaders(map[string]string{"X-Source": "builder"}).
+		SetPathParam("id", 7).
+		SetQuery("expand", "teams").
+		SetResult(&user)
+	if _, err := request.Get(context.Background(), "/users/:id"); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if user.Name != "Ada" {
+		t.Errorf("Expected user 7, got %+v", user)
+	}
+
+	var apiErr apiError
+	_, err = request.SetPathParam("id", 8).SetError(&apiErr).Get(context.Background(), "/users/:id")
+	var httpErr *errors.HTTPError
+	if !stderrors.As(err, &httpErr) || apiErr.Code != "not_found" {
+		t.Errorf("Expected decoded error body, got %v, %+v", err, apiErr)
+	}
+}
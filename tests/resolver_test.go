@@ -0,0 +1,104 @@
+package tests
+
+import (
+	"context"
+	stderrors "errors"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/fourth-ally/gofetch"
+	"github.com/fourth-ally/gofetch/domain/errors"
+	"github.com/fourth-ally/gofetch/infrastructure"
+)
+
+// failingResolver fails every lookup.
+type failingResolver struct{}
+
+func (failingResolver) Name() string { return "broken" }
+
+func (failingResolver) LookupHost(context.Context, string) ([]string, error) {
+	return nil, stderrors.New("server misbehaving")
+}
+
+func TestResolversFallBackInOrder(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`{}`))
+	}))
+	defer server.Close()
+
+	doh := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Query().Get("name") != "doh.test" || r.URL.Query().Get("type") != "1" {
+			w.Write([]byte(`{"Status": 3}`))
+			return
+		}
+		w.Write([]byte(`{"Status": 0, "Answer": [{"name": "doh.test", "type": 1, "data": "127.0.0.1"}]}`))
+	}))
+	defer doh.Close()
+
+	_, port, _ := net.SplitHostPort(server.Listener.Addr().String())
+	client := infrastructure.NewClient().SetResolvers(
+		failingResolver{},
+		infrastructure.NewDoHResolver(doh.URL, nil),
+		infrastructure.StaticHosts(map[string][]string{"hosts.test": {"127.0.0.1"}}),
+	)
+
+	for host, resolver := range map[string]string{"doh.test": "doh", "hosts.test": "hosts"} {
+		resp, err := client.Get(context.Background(), "http://"+net.JoinHostPort(host, port)+"/", nil, nil)
+		if err != nil {
+			t.Fatalf("%s: %v", host, err)
+		}
+		if resp.Timings.Resolver != resolver || resp.Timings.DNSLookup == 0 {
+			t.Errorf("%s: expected resolution by %s to be timed, got %q after %v", host, resolver, resp.Timings.Resolver, resp.Timings.DNSLookup)
+		}
+	}
+
+	_, err := client.Get(context.Background(), "http://"+net.JoinHostPort("missing.test", port)+"/", nil, nil)
+	var dnsErr *net.DNSError
+	if !stderrors.As(err, &dnsErr) || gofetch.CodeOf(err) != errors.CodeDNS {
+		t.Errorf("expected a DNS error after every resolver failed, got %v", err)
+	}
+
+	// IP addresses are dialed without resolution
+	resp, err := client.Get(context.Background(), server.URL, nil, nil)
+	if err != nil || resp.Timings.Resolver != "" {
+		t.Errorf("expected an IP address to skip the resolvers, got %v", err)
+	}
+}
+
+func TestHostMappingRedirectsDials(t *testing.T) {
+	server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("X-Host", r.Host)
+		w.Header().Set("X-SNI", r.TLS.ServerName)
+	}))
+	defer server.Close()
+
+	_, port, _ := net.SplitHostPort(server.Listener.Addr().String())
+	client := infrastructure.NewClient().
+		SetTransport(server.Client().Transport).
+		SetHostMapping(map[string]string{
+			// The test certificate is valid for example.com
+			"Example.com":   server.Listener.Addr().String(),
+			"www.test:8443": "127.0.0.1",
+		})
+
+	resp, err := client.Get(context.Background(), "https://example.com/", nil, nil)
+	if err != nil {
+		t.Fatalf("Get failed: %v", err)
+	}
+	if resp.Headers.Get("X-Host") != "example.com" || resp.Headers.Get("X-SNI") != "example.com" {
+		t.Errorf("expected the original host and server name, got %q and %q", resp.Headers.Get("X-Host"), resp.Headers.Get("X-SNI"))
+	}
+
+	// A host:port entry only applies to that port
+	_, err = client.Get(context.Background(), "https://www.test:"+port+"/", nil, nil)
+	var dnsErr *net.DNSError
+	if !stderrors.As(err, &dnsErr) {
+		t.Errorf("expected www.test on another port to be resolved, got %v", err)
+	}
+	_, err = client.Get(context.Background(), "https://www.test:8443/", nil, nil)
+	if err == nil || stderrors.As(err, &dnsErr) {
+		t.Errorf("expected www.test:8443 to be dialed without DNS, got %v", err)
+	}
+}
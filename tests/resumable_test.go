@@ -0,0 +1,191 @@
+package tests
+
+import (
+	"bytes"
+	"compress/gzip"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/fourth-ally/gofetch/domain/models"
+	"github.com/fourth-ally/gofetch/infrastructure"
+)
+
+// newFlakyRangeServer serves payload but drops the connection halfway
+// through the first full response; Range requests are answered with 206.
+func newFlakyRangeServer(t *testing.T, payload string) (*httptest.Server, *int) {
+	rangeRequests := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("ETag", `"v1"`)
+		w.Header().Set("Accept-Ranges", "bytes")
+
+		if rangeHeader := r.Header.Get("Range"); rangeHeader != "" {
+			rangeRequests++
+			start, _ := strconv.Atoi(strings.TrimSuffix(strings.TrimPrefix(rangeHeader, "bytes="), "-"))
+			w.Header().Set("Content-Range", fmt.Sprintf("bytes %d-%d/%d", start, len(payload)-1, len(payload)))
+			w.WriteHeader(http.StatusPartialContent)
+			w.Write([]byte(payload[start:]))
+			return
+		}
+
+		w.Header().Set("Content-Length", strconv.Itoa(len(payload)))
+		w.WriteHeader(http.StatusOK)
+		w.Write([]byte(payload[:len(payload)/2]))
+		w.(http.Flusher).Flush()
+
+		conn, _, err := w.(http.Hijacker).Hijack()
+		if err != nil {
+			t.Errorf("Failed to hijack connection: %v", err)
+			return
+		}
+		conn.Close()
+	}))
+	return server, &rangeRequests
+}
+
+func TestBodyResumptionAfterConnectionDrop(t *testing.T) {
+	payload := `{"name": "` + strings.Repeat("x", 4096) + `"}`
+	server, rangeRequests := newFlakyRangeServer(t, payload)
+	defer server.Close()
+
+	client := infrastructure.NewClient().
+		SetBaseURL(server.URL).
+		SetBodyResumption(2)
+
+	var result map[string]string
+	resp, err := client.Get(context.Background(), "/file", nil, &result)
+	if err != nil {
+		t.Fatalf("Expected resumed download to succeed, got %v", err)
+	}
+
+	if string(resp.RawBody) != payload {
+		t.Errorf("Expected full payload of %d bytes, got %d", len(payload), len(resp.RawBody))
+	}
+
+	if *rangeRequests != 1 {
+		t.Errorf("Expected 1 range request, got %d", *rangeRequests)
+	}
+}
+
+func TestBodyResumptionDisabledByDefault(t *testing.T) {
+	server, _ := newFlakyRangeServer(t, strings.Repeat("y", 4096))
+	defer server.Close()
+
+	client := infrastructure.NewClient().SetBaseURL(server.URL)
+
+	if _, err := client.Get(context.Background(), "/file", nil, nil); err == nil {
+		t.Fatal("Expected truncated body to fail without resumption")
+	}
+}
+
+func TestBodyResumptionSkipsCompressedResponses(t *testing.T) {
+	var compressed bytes.Buffer
+	gz := gzip.NewWriter(&compressed)
+	for i := 0; i < 512; i++ {
+		fmt.Fprintf(gz, "line %d\n", i)
+	}
+	gz.Close()
+	encoded := compressed.Bytes()
+
+	rangeRequests := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("ETag", `"v1"`)
+		w.Header().Set("Content-Encoding", "gzip")
+		if r.Header.Get("Range") != "" {
+			rangeRequests++
+			w.WriteHeader(http.StatusPartialContent)
+			return
+		}
+
+		w.Header().Set("Content-Length", strconv.Itoa(len(encoded)))
+		w.Write(encoded[:len(encoded)/2])
+		w.(http.Flusher).Flush()
+		conn, _, _ := w.(http.Hijacker).Hijack()
+		conn.Close()
+	}))
+	defer server.Close()
+
+	client := infrastructure.NewClient().
+		SetBaseURL(server.URL).
+		SetBodyResumption(2)
+
+	if _, err := client.Get(context.Background(), "/file", nil, nil); err == nil {
+		t.Error("Expected the truncated compressed body to fail")
+	}
+	if rangeRequests != 0 {
+		t.Errorf("Expected no range requests for a compressed body, got %d", rangeRequests)
+	}
+}
+
+func TestDownloadFileResumesPartialFile(t *testing.T) {
+	payload := strings.Repeat("0123456789", 1000)
+	server, rangeRequests := newFlakyRangeServer(t, payload)
+	defer server.Close()
+
+	client := infrastructure.NewClient().SetBaseURL(server.URL)
+	dest := filepath.Join(t.TempDir(), "large.bin")
+	options := models.NewDownloadFileOptions()
+	options.Resume = true
+	options.Checksum = &models.Checksum{Algorithm: models.DigestSHA256, Value: sha256Hex(payload)}
+
+	if _, err := client.DownloadFile(context.Background(), "/large", nil, dest, options); err == nil {
+		t.Fatal("Expected the interrupted download to fail")
+	}
+	if info, err := os.Stat(dest + ".part"); err != nil || info.Size() != int64(len(payload)/2) {
+		t.Fatalf("Expected half the payload kept in the partial file, got %v, %v", info, err)
+	}
+
+	resp, err := client.DownloadFile(context.Background(), "/large", nil, dest, options)
+	if err != nil {
+		t.Fatalf("Expected the download to resume, got %v", err)
+	}
+	if data, _ := os.ReadFile(resp.File); string(data) != payload {
+		t.Errorf("Expected the complete payload, got %d bytes", len(data))
+	}
+	if *rangeRequests != 1 || resp.StatusCode != http.StatusPartialContent {
+		t.Errorf("Expected one Range request, got %d (status %d)", *rangeRequests, resp.StatusCode)
+	}
+	if _, err := os.Stat(dest + ".part"); !os.IsNotExist(err) {
+		t.Error("Expected the partial file to be gone")
+	}
+}
+
+func TestDownloadFileRestartsWhenResourceChanged(t *testing.T) {
+	var ifRange string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		ifRange = r.Header.Get("If-Range")
+		w.Header().Set("ETag", `"v2"`)
+		w.Write([]byte("new content"))
+	}))
+	defer server.Close()
+
+	dest := filepath.Join(t.TempDir(), "file.txt")
+	os.WriteFile(dest+".part", []byte("old con"), 0o600)
+	os.WriteFile(dest+".part.validator", []byte(`"v1"`), 0o600)
+
+	options := models.NewDownloadFileOptions()
+	options.Resume = true
+	client := infrastructure.NewClient().SetBaseURL(server.URL)
+	if _, err := client.DownloadFile(context.Background(), "/file", nil, dest, options); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if ifRange != `"v1"` {
+		t.Errorf("Expected the stored validator as If-Range, got %q", ifRange)
+	}
+	if data, _ := os.ReadFile(dest); string(data) != "new content" {
+		t.Errorf("Expected the partial file to be replaced, got %q", data)
+	}
+}
+
+func sha256Hex(s string) string {
+	sum := sha256.Sum256([]byte(s))
+	return hex.EncodeToString(sum[:])
+}
@@ -2,12 +2,14 @@ package tests
 
 import (
 	"context"
+	stderrors "errors"
 	"net/http"
 	"net/http/httptest"
 	"strings"
 	"testing"
 	"time"
 
+	"github.com/fourth-ally/gofetch/domain/errors"
 	"github.com/fourth-ally/gofetch/domain/models"
 	"github.com/fourth-ally/gofetch/infrastructure"
 )
@@ -385,3 +387,83 @@ func TestCircuitBreakerRecovery(t *testing.T) {
 		t.Errorf("Expected circuit to be closed after successful half-open request: %v", err)
 	}
 }
+
+func TestRetryAbortsWhenBackoffExceedsDeadline(t *testing.T) {
+	attempts := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		attempts++
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}))
+	defer server.Close()
+
+	client := infrastructure.NewClient().
+		SetBaseURL(server.URL).
+		SetRetryOptions(&models.RetryOptions{
+			MaxRetries:         3,
+			InitialDelay:       time.Second,
+			MaxDelay:           time.Second,
+			Backoff:            models.BackoffFixed,
+			RetryOnStatusCodes: []int{http.StatusServiceUnavailable},
+		})
+
+	ctx, cancel := context.WithTimeout(context.Background(), 200*time.Millisecond)
+	defer cancel()
+
+	start := time.Now()
+	_, err := client.Get(ctx, "/test", nil, nil)
+
+	var aborted *errors.RetryAbortedError
+	if !stderrors.As(err, &aborted) {
+		t.Fatalf("Expected RetryAbortedError, got %v", err)
+	}
+	if aborted.Reason != "deadline" || aborted.Attempts != 1 {
+		t.Errorf("Expected deadline abort after 1 attempt, got %+v", aborted)
+	}
+	if !strings.HasPrefix(err.Error(), "retry aborted: deadline") {
+		t.Errorf("Unexpected error message: %v", err)
+	}
+
+	var httpErr *errors.HTTPError
+	if !stderrors.As(err, &httpErr) || httpErr.StatusCode != http.StatusServiceUnavailable {
+		t.Errorf("Expected last HTTP error to be wrapped, got %v", err)
+	}
+	if attempts != 1 {
+		t.Errorf("Expected 1 attempt, got %d", attempts)
+	}
+	if elapsed := time.Since(start); elapsed > 150*time.Millisecond {
+		t.Errorf("Expected early abort, took %v", elapsed)
+	}
+}
+
+func TestRetryWithinDeadlineContinues(t *testing.T) {
+	attempts := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		attempts++
+		if attempts < 2 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		w.Write([]byte(`{}`))
+	}))
+	defer server.Close()
+
+	client := infrastructure.NewClient().
+		SetBaseURL(server.URL).
+		SetRetryOptions(&models.RetryOptions{
+			MaxRetries:         3,
+			InitialDelay:       10 * time.Millisecond,
+			MaxDelay:           10 * time.Millisecond,
+			Backoff:            models.BackoffFixed,
+			RetryOnStatusCodes: []int{http.StatusServiceUnavailable},
+		})
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	if _, err := client.Get(ctx, "/test", nil, nil); err != nil {
+		t.Fatalf("Expected retry to succeed within deadline, got %v", err)
+	}
+	if attempts != 2 {
+		t.Errorf("Expected 2 attempts, got %d", attempts)
+	}
+}
@@ -0,0 +1,95 @@
+package tests
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/fourth-ally/gofetch/infrastructure"
+)
+
+func TestScopeWaitsForAllRequests(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		json.NewEncoder(w).Encode(TestUser{ID: 1, Name: r.URL.Path})
+	}))
+	defer server.Close()
+
+	client := infrastructure.NewClient().SetBaseURL(server.URL)
+
+	var a, b TestUser
+	scope := client.Scope(context.Background())
+	scope.Get("/a", nil, &a)
+	scope.Get("/b", nil, &b)
+
+	if err := scope.Wait(); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	if a.Name != "/a" || b.Name != "/b" {
+		t.Errorf("Expected both targets to be filled, got %q and %q", a.Name, b.Name)
+	}
+}
+
+func TestScopeCancelsOnFirstError(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/fail" {
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+		select {
+		case <-r.Context().Done():
+		case <-time.After(2 * time.Second):
+		}
+	}))
+	defer server.Close()
+
+	client := infrastructure.NewClient().SetBaseURL(server.URL)
+
+	start := time.Now()
+	scope := client.Scope(context.Background())
+	scope.Get("/slow", nil, nil)
+	scope.Get("/fail", nil, nil)
+
+	if err := scope.Wait(); err == nil {
+		t.Fatal("Expected scope error")
+	}
+
+	if time.Since(start) > time.Second {
+		t.Errorf("Expected slow request to be cancelled, took %v", time.Since(start))
+	}
+}
+
+func TestScopeLimitsConcurrency(t *testing.T) {
+	var inFlight, peak int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		current := atomic.AddInt32(&inFlight, 1)
+		for {
+			old := atomic.LoadInt32(&peak)
+			if current <= old || atomic.CompareAndSwapInt32(&peak, old, current) {
+				break
+			}
+		}
+		time.Sleep(20 * time.Millisecond)
+		atomic.AddInt32(&inFlight, -1)
+	}))
+	defer server.Close()
+
+	client := infrastructure.NewClient().SetBaseURL(server.URL)
+
+	scope := client.Scope(context.Background()).SetLimit(2)
+	for i := 0; i < 6; i++ {
+		scope.Get("/", nil, nil)
+	}
+
+	if err := scope.Wait(); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	if peak > 2 {
+		t.Errorf("Expected at most 2 concurrent requests, got %d", peak)
+	}
+}
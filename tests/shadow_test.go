@@ -0,0 +1,294 @@
+package tests
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"net/http/httptrace"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/fourth-ally/gofetch/domain/models"
+	"github.com/fourth-ally/gofetch/infrastructure"
+)
+
+func TestShadowReportsMismatch(t *testing.T) {
+	primary := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`{"id": 1, "name": "primary"}`))
+	}))
+	defer primary.Close()
+
+	shadow := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`{"id": 1, "name": "shadow"}`))
+	}))
+	defer shadow.Close()
+
+	mismatches := make(chan *models.ShadowMismatch, 1)
+	client := infrastructure.NewClient().
+		SetBaseURL(primary.URL).
+		SetShadow(&models.ShadowOptions{
+			BaseURL:    shadow.URL,
+			Compare:    true,
+			OnMismatch: func(m *models.ShadowMismatch) { mismatches <- m },
+		})
+
+	var result map[string]interface{}
+	if _, err := client.Get(context.Background(), "/users/1", nil, &result); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	if result["name"] != "primary" {
+		t.Errorf("Expected primary response to be returned, got %v", result["name"])
+	}
+
+	select {
+	case m := <-mismatches:
+		if !m.BodyMismatch {
+			t.Error("Expected body mismatch to be reported")
+		}
+		if m.StatusMismatch {
+			t.Error("Expected status codes to match")
+		}
+	case <-time.After(time.Second):
+		t.Fatal("Expected mismatch to be reported")
+	}
+}
+
+func TestShadowIgnoresJSONKeyOrder(t *testing.T) {
+	primary := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`{"id": 1, "name": "same"}`))
+	}))
+	defer primary.Close()
+
+	shadowCalled := make(chan struct{}, 1)
+	shadow := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`{"name":"same","id":1}`))
+		shadowCalled <- struct{}{}
+	}))
+	defer shadow.Close()
+
+	mismatches := make(chan *models.ShadowMismatch, 1)
+	client := infrastructure.NewClient().
+		SetBaseURL(primary.URL).
+		SetShadow(&models.ShadowOptions{
+			BaseURL:    shadow.URL,
+			Compare:    true,
+			OnMismatch: func(m *models.ShadowMismatch) { mismatches <- m },
+		})
+
+	if _, err := client.Get(context.Background(), "/users/1", nil, nil); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	<-shadowCalled
+	select {
+	case m := <-mismatches:
+		t.Errorf("Expected no mismatch, got %+v", m)
+	case <-time.After(100 * time.Millisecond):
+	}
+}
+
+func TestShadowDoesNotWriteToBodyTee(t *testing.T) {
+	primary := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`{"from":"primary"}`))
+	}))
+	defer primary.Close()
+
+	shadow := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`{"from":"shadow"}`))
+	}))
+	defer shadow.Close()
+
+	mismatches := make(chan *models.ShadowMismatch, 1)
+	client := infrastructure.NewClient().
+		SetBaseURL(primary.URL).
+		SetShadow(&models.ShadowOptions{
+			BaseURL:    shadow.URL,
+			Compare:    true,
+			OnMismatch: func(m *models.ShadowMismatch) { mismatches <- m },
+		})
+
+	var tee bytes.Buffer
+	if _, err := client.Get(context.Background(), "/", nil, nil, infrastructure.WithBodyTee(&tee)); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	select {
+	case <-mismatches:
+	case <-time.After(time.Second):
+		t.Fatal("Expected the shadow request to complete")
+	}
+	if tee.String() != `{"from":"primary"}` {
+		t.Errorf("Expected the tee to receive only the primary body, got %q", tee.String())
+	}
+}
+
+func TestShadowReadsBodyOfRawRequests(t *testing.T) {
+	primary := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte("primary"))
+	}))
+	defer primary.Close()
+
+	shadow := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte("shadow"))
+	}))
+	defer shadow.Close()
+
+	mismatches := make(chan *models.ShadowMismatch, 1)
+	client := infrastructure.NewClient().
+		SetBaseURL(primary.URL).
+		SetShadow(&models.ShadowOptions{
+			BaseURL:    shadow.URL,
+			OnMismatch: func(m *models.ShadowMismatch) { mismatches <- m },
+			Compare:    true,
+		})
+
+	resp, err := client.Get(context.Background(), "/", nil, nil, infrastructure.WithRawResponse())
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	resp.Body.Close()
+
+	select {
+	case m := <-mismatches:
+		if string(m.ShadowBody) != "shadow" {
+			t.Errorf("Expected the shadow body to be read and closed, got %q", m.ShadowBody)
+		}
+	case <-time.After(time.Second):
+		t.Fatal("Expected the shadow request to complete")
+	}
+}
+
+func TestShadowDoesNotFireClientTrace(t *testing.T) {
+	primary := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte("primary"))
+	}))
+	defer primary.Close()
+
+	shadow := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte("shadow"))
+	}))
+	defer shadow.Close()
+
+	mismatches := make(chan *models.ShadowMismatch, 1)
+	client := infrastructure.NewClient().
+		SetBaseURL(primary.URL).
+		SetShadow(&models.ShadowOptions{
+			BaseURL:    shadow.URL,
+			Compare:    true,
+			OnMismatch: func(m *models.ShadowMismatch) { mismatches <- m },
+		})
+
+	var connections atomic.Int32
+	trace := &httptrace.ClientTrace{
+		GotConn: func(httptrace.GotConnInfo) { connections.Add(1) },
+	}
+	if _, err := client.Get(context.Background(), "/", nil, nil, infrastructure.WithClientTrace(trace)); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	select {
+	case <-mismatches:
+	case <-time.After(time.Second):
+		t.Fatal("Expected the shadow request to complete")
+	}
+	if got := connections.Load(); got != 1 {
+		t.Errorf("Expected the trace to see only the primary connection, got %d", got)
+	}
+}
+
+func TestShadowSkipsStreamedBodies(t *testing.T) {
+	var received string
+	primary := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		received = string(body)
+	}))
+	defer primary.Close()
+
+	var shadowCalls atomic.Int32
+	shadow := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		shadowCalls.Add(1)
+	}))
+	defer shadow.Close()
+
+	client := infrastructure.NewClient().
+		SetBaseURL(primary.URL).
+		SetShadow(&models.ShadowOptions{BaseURL: shadow.URL, MirrorUnsafe: true})
+
+	// Seekable readers that aren't in memory are streamed, and a mirrored
+	// request would seek them again after the call returned
+	stream := struct{ io.ReadSeeker }{strings.NewReader("streamed")}
+	if _, err := client.Post(context.Background(), "/upload", nil, stream, nil); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	time.Sleep(100 * time.Millisecond)
+	if received != "streamed" {
+		t.Errorf("Expected the primary to receive the stream, got %q", received)
+	}
+	if got := shadowCalls.Load(); got != 0 {
+		t.Errorf("Expected streamed bodies not to be mirrored, got %d shadow requests", got)
+	}
+}
+
+func TestShadowMirrorsOnlySafeMethodsByDefault(t *testing.T) {
+	primary := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+	defer primary.Close()
+
+	mirrored := make(chan string, 2)
+	shadow := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mirrored <- r.Method
+	}))
+	defer shadow.Close()
+
+	client := infrastructure.NewClient().
+		SetBaseURL(primary.URL).
+		SetShadow(&models.ShadowOptions{BaseURL: shadow.URL})
+
+	if _, err := client.Post(context.Background(), "/orders", nil, map[string]int{"id": 1}, nil); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if _, err := client.Get(context.Background(), "/orders", nil, nil); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	select {
+	case method := <-mirrored:
+		if method != http.MethodGet {
+			t.Errorf("Expected only the GET to be mirrored, got %s", method)
+		}
+	case <-time.After(time.Second):
+		t.Fatal("Expected the GET to be mirrored")
+	}
+}
+
+func TestShadowBypassesRateLimit(t *testing.T) {
+	primary := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+	defer primary.Close()
+
+	mirrored := make(chan struct{}, 1)
+	shadow := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mirrored <- struct{}{}
+	}))
+	defer shadow.Close()
+
+	// The primary request takes the only token for a minute
+	client := infrastructure.NewClient().
+		SetBaseURL(primary.URL).
+		SetRateLimit(&models.RateLimit{RequestsPerSecond: 1.0 / 60, Burst: 1}).
+		SetShadow(&models.ShadowOptions{BaseURL: shadow.URL})
+
+	if _, err := client.Get(context.Background(), "/", nil, nil); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	select {
+	case <-mirrored:
+	case <-time.After(time.Second):
+		t.Fatal("Expected the shadow request not to wait for the rate limiter")
+	}
+}
@@ -0,0 +1,78 @@
+package tests
+
+import (
+	"context"
+	"crypto"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"encoding/base64"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"regexp"
+	"strings"
+	"testing"
+
+	"github.com/fourth-ally/gofetch/domain/models"
+	"github.com/fourth-ally/gofetch/infrastructure"
+)
+
+func TestMastodonSignatureProfile(t *testing.T) {
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var req *http.Request
+	var body []byte
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		req = r
+		body, _ = io.ReadAll(r.Body)
+	}))
+	defer server.Close()
+
+	keyID := "https://example.social/users/alice#main-key"
+	client := infrastructure.NewClient().
+		SetBaseURL(server.URL).
+		SetRequestSigner(models.NewMastodonSignatureOptions(keyID, key))
+
+	if _, err := client.Post(context.Background(), "/inbox", nil, map[string]string{"type": "Follow"}, nil); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	bodyHash := sha256.Sum256(body)
+	digest := "SHA-256=" + base64.StdEncoding.EncodeToString(bodyHash[:])
+	if req.Header.Get("Digest") != digest {
+		t.Errorf("Expected Digest %q, got %q", digest, req.Header.Get("Digest"))
+	}
+
+	params := map[string]string{}
+	for _, match := range regexp.MustCompile(`(\w+)="([^"]*)"`).FindAllStringSubmatch(req.Header.Get("Signature"), -1) {
+		params[match[1]] = match[2]
+	}
+
+	if params["keyId"] != keyID || params["algorithm"] != "rsa-sha256" {
+		t.Errorf("Unexpected signature parameters %v", params)
+	}
+	if params["headers"] != "(request-target) host date digest" {
+		t.Errorf("Unexpected signed headers %q", params["headers"])
+	}
+
+	signingString := strings.Join([]string{
+		"(request-target): post /inbox",
+		"host: " + req.Host,
+		"date: " + req.Header.Get("Date"),
+		"digest: " + digest,
+	}, "\n")
+	hashed := sha256.Sum256([]byte(signingString))
+	signature, _ := base64.StdEncoding.DecodeString(params["signature"])
+	if err := rsa.VerifyPKCS1v15(&key.PublicKey, crypto.SHA256, hashed[:], signature); err != nil {
+		t.Errorf("Expected valid signature, got %v", err)
+	}
+
+	client.Get(context.Background(), "/users/alice/outbox", nil, nil)
+	if !strings.Contains(req.Header.Get("Signature"), `headers="(request-target) host date"`) {
+		t.Errorf("Expected digest to be skipped for GET, got %q", req.Header.Get("Signature"))
+	}
+}
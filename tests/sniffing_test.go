@@ -0,0 +1,58 @@
+package tests
+
+import (
+	"context"
+	stderrors "errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/fourth-ally/gofetch/domain/errors"
+	"github.com/fourth-ally/gofetch/infrastructure"
+)
+
+func TestContentSniffingRejectsHTMLAsJSON(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte("<html><body>502 Bad Gateway</body></html>"))
+	}))
+	defer server.Close()
+
+	client := infrastructure.NewClient().
+		SetBaseURL(server.URL).
+		SetContentSniffing(true)
+
+	_, err := client.Get(context.Background(), "/users", nil, nil)
+
+	var mismatch *errors.ContentMismatchError
+	if !stderrors.As(err, &mismatch) {
+		t.Fatalf("Expected ContentMismatchError, got %v", err)
+	}
+	if mismatch.Declared != "application/json" || mismatch.Detected != "text/html" {
+		t.Errorf("Unexpected types declared=%s detected=%s", mismatch.Declared, mismatch.Detected)
+	}
+	if !strings.Contains(err.Error(), "502 Bad Gateway") {
+		t.Errorf("Expected body preview in error, got %v", err)
+	}
+}
+
+func TestContentSniffingAcceptsMatchingBodies(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/problem+json; charset=utf-8")
+		w.Write([]byte(`  {"id":1}`))
+	}))
+	defer server.Close()
+
+	client := infrastructure.NewClient().
+		SetBaseURL(server.URL).
+		SetContentSniffing(true)
+
+	var user TestUser
+	if _, err := client.Get(context.Background(), "/users", nil, &user); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if user.ID != 1 {
+		t.Errorf("Expected ID 1, got %d", user.ID)
+	}
+}
@@ -0,0 +1,127 @@
+package tests
+
+import (
+	"context"
+	stderrors "errors"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/fourth-ally/gofetch/domain/errors"
+	"github.com/fourth-ally/gofetch/domain/models"
+	"github.com/fourth-ally/gofetch/infrastructure"
+)
+
+func TestSubscribeParsesEventsAndReconnects(t *testing.T) {
+	var connections int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Authorization") != "Bearer token" || r.Header.Get("Accept") != "text/event-stream" {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		w.Header().Set("Content-Type", "text/event-stream")
+
+		switch atomic.AddInt32(&connections, 1) {
+		case 1:
+			w.Write([]byte(": keep-alive\nretry: 10\n\nid: 1\nevent: update\ndata: first\ndata: line\n\n"))
+		default:
+			if r.Header.Get("Last-Event-ID") != "1" {
+				t.Errorf("Expected Last-Event-ID 1 on reconnect, got %q", r.Header.Get("Last-Event-ID"))
+			}
+			w.Write([]byte("id: 2\r\ndata: second\r\n\r\n"))
+		}
+	}))
+	defer server.Close()
+
+	client := infrastructure.NewClient().SetBaseURL(server.URL).SetHeader("Authorization", "Bearer token")
+
+	options := models.NewSSEOptions()
+	options.ReconnectDelay = time.Hour
+
+	var events []models.SSEEvent
+	done := stderrors.New("done")
+	err := client.Subscribe(context.Background(), "/events", nil, options, func(event models.SSEEvent) error {
+		events = append(events, event)
+		if len(events) == 2 {
+			return done
+		}
+		return nil
+	})
+	if err != done {
+		t.Fatalf("Expected handler error, got %v", err)
+	}
+
+	if events[0].ID != "1" || events[0].Event != "update" || events[0].Data != "first\nline" {
+		t.Errorf("Unexpected first event: %+v", events[0])
+	}
+	if events[1].ID != "2" || events[1].Data != "second" {
+		t.Errorf("Unexpected second event: %+v", events[1])
+	}
+	if atomic.LoadInt32(&connections) != 2 {
+		t.Errorf("Expected 2 connections, got %d", connections)
+	}
+}
+
+func TestSubscribeStopsOnClientError(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusForbidden)
+	}))
+	defer server.Close()
+
+	client := infrastructure.NewClient().SetBaseURL(server.URL)
+
+	err := client.Subscribe(context.Background(), "/events", nil, nil, func(models.SSEEvent) error {
+		return nil
+	})
+
+	var httpErr *errors.HTTPError
+	if !stderrors.As(err, &httpErr) || httpErr.StatusCode != http.StatusForbidden {
+		t.Fatalf("Expected 403 HTTPError, got %v", err)
+	}
+}
+
+func TestSubscribeMaxReconnects(t *testing.T) {
+	var connections int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&connections, 1)
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}))
+	defer server.Close()
+
+	client := infrastructure.NewClient().SetBaseURL(server.URL)
+
+	var reported int32
+	options := models.NewSSEOptions()
+	options.ReconnectDelay = time.Millisecond
+	options.MaxReconnects = 2
+	options.OnError = func(error) { atomic.AddInt32(&reported, 1) }
+
+	err := client.Subscribe(context.Background(), "/events", nil, options, func(models.SSEEvent) error {
+		return nil
+	})
+
+	var httpErr *errors.HTTPError
+	if !stderrors.As(err, &httpErr) || httpErr.StatusCode != http.StatusServiceUnavailable {
+		t.Fatalf("Expected 503 HTTPError, got %v", err)
+	}
+	if connections != 3 || reported != 2 {
+		t.Errorf("Expected 3 connections and 2 reported errors, got %d and %d", connections, reported)
+	}
+}
+
+func TestSubscribeNoContentEndsSubscription(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer server.Close()
+
+	client := infrastructure.NewClient().SetBaseURL(server.URL)
+
+	if err := client.Subscribe(context.Background(), "/events", nil, nil, func(models.SSEEvent) error {
+		return nil
+	}); err != nil {
+		t.Errorf("Expected nil error on 204, got %v", err)
+	}
+}
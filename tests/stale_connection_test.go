@@ -0,0 +1,83 @@
+package tests
+
+import (
+	"context"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/fourth-ally/gofetch/domain/models"
+	"github.com/fourth-ally/gofetch/infrastructure"
+)
+
+func TestStaleConnectionPingBeforeIdlePost(t *testing.T) {
+	var mu sync.Mutex
+	var methods []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		methods = append(methods, r.Method)
+		mu.Unlock()
+		w.Write([]byte(`{}`))
+	}))
+	defer server.Close()
+
+	client := infrastructure.NewClient().SetBaseURL(server.URL).SetStaleConnectionCheck(&models.StaleConnectionOptions{
+		IdleThreshold: 30 * time.Millisecond,
+	})
+
+	ctx := context.Background()
+	client.Post(ctx, "/", nil, map[string]int{"n": 1}, nil)
+	client.Post(ctx, "/", nil, map[string]int{"n": 2}, nil)
+	time.Sleep(50 * time.Millisecond)
+	client.Get(ctx, "/", nil, nil)
+	time.Sleep(50 * time.Millisecond)
+	client.Post(ctx, "/", nil, map[string]int{"n": 3}, nil)
+
+	mu.Lock()
+	defer mu.Unlock()
+	expected := []string{"POST", "POST", "GET", "OPTIONS", "POST"}
+	if len(methods) != len(expected) {
+		t.Fatalf("Expected %v, got %v", expected, methods)
+	}
+	for i := range expected {
+		if methods[i] != expected[i] {
+			t.Fatalf("Expected %v, got %v", expected, methods)
+		}
+	}
+}
+
+func TestStaleConnectionRedial(t *testing.T) {
+	var connections int32
+	server := httptest.NewUnstartedServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`{}`))
+	}))
+	server.Config.ConnState = func(conn net.Conn, state http.ConnState) {
+		if state == http.StateNew {
+			atomic.AddInt32(&connections, 1)
+		}
+	}
+	server.Start()
+	defer server.Close()
+
+	client := infrastructure.NewClient().SetBaseURL(server.URL).SetStaleConnectionCheck(&models.StaleConnectionOptions{
+		IdleThreshold: 30 * time.Millisecond,
+		Redial:        true,
+	})
+
+	ctx := context.Background()
+	client.Post(ctx, "/", nil, map[string]int{"n": 1}, nil)
+	client.Post(ctx, "/", nil, map[string]int{"n": 2}, nil)
+	if n := atomic.LoadInt32(&connections); n != 1 {
+		t.Fatalf("Expected the connection to be reused while active, got %d connections", n)
+	}
+
+	time.Sleep(50 * time.Millisecond)
+	client.Post(ctx, "/", nil, map[string]int{"n": 3}, nil)
+	if n := atomic.LoadInt32(&connections); n != 2 {
+		t.Errorf("Expected a fresh connection after idling, got %d connections", n)
+	}
+}
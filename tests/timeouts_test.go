@@ -0,0 +1,66 @@
+package tests
+
+import (
+	"context"
+	stderrors "errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/fourth-ally/gofetch/infrastructure"
+)
+
+func TestIdleReadTimeoutAbortsStalledBody(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`{"id":`))
+		w.(http.Flusher).Flush()
+		select {
+		case <-r.Context().Done():
+		case <-time.After(2 * time.Second):
+		}
+	}))
+	defer server.Close()
+
+	client := infrastructure.NewClient().
+		SetBaseURL(server.URL).
+		SetIdleReadTimeout(50 * time.Millisecond)
+
+	start := time.Now()
+	_, err := client.Get(context.Background(), "/stream", nil, nil)
+	if !stderrors.Is(err, infrastructure.ErrIdleReadTimeout) {
+		t.Fatalf("Expected idle read timeout, got %v", err)
+	}
+	if elapsed := time.Since(start); elapsed > time.Second {
+		t.Errorf("Expected stalled body to be aborted quickly, took %v", elapsed)
+	}
+}
+
+func TestResponseHeaderTimeout(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		select {
+		case <-r.Context().Done():
+		case <-time.After(time.Second):
+		}
+	}))
+	defer server.Close()
+
+	client := infrastructure.NewClient().
+		SetBaseURL(server.URL).
+		SetResponseHeaderTimeout(50 * time.Millisecond)
+
+	if _, err := client.Get(context.Background(), "/slow", nil, nil); err == nil {
+		t.Fatal("Expected response header timeout")
+	}
+}
+
+func TestValidateReportsDialTimeoutAboveTimeout(t *testing.T) {
+	client := infrastructure.NewClient().
+		SetTimeout(time.Second).
+		SetTLSHandshakeTimeout(500 * time.Millisecond).
+		SetDialTimeout(5 * time.Second)
+
+	if err := client.Validate(); !containsField(err, "DialTimeout") {
+		t.Errorf("Expected DialTimeout problem, got %v", err)
+	}
+}
@@ -0,0 +1,153 @@
+package tests
+
+import (
+	"context"
+	"crypto/tls"
+	stderrors "errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/fourth-ally/gofetch/domain/errors"
+	"github.com/fourth-ally/gofetch/domain/models"
+	"github.com/fourth-ally/gofetch/infrastructure"
+)
+
+func TestTLSSessionResumption(t *testing.T) {
+	server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`{}`))
+	}))
+	defer server.Close()
+
+	// Force a new connection per request so every request performs a handshake
+	transport := server.Client().Transport.(*http.Transport).Clone()
+	transport.DisableKeepAlives = true
+
+	client := infrastructure.NewClient().
+		SetBaseURL(server.URL).
+		SetTransport(transport).
+		SetTLSSessionCache(16)
+
+	var last bool
+	for i := 0; i < 3; i++ {
+		resp, err := client.Get(context.Background(), "/", nil, nil)
+		if err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+		if resp.Timings == nil || resp.Timings.TLSVersion == 0 {
+			t.Fatal("Expected TLS timings to be recorded")
+		}
+		last = resp.Timings.TLSResumed
+	}
+
+	if !last {
+		t.Error("Expected later handshakes to resume the TLS session")
+	}
+
+	stats := client.TLSStats()
+	if stats.Handshakes != 3 {
+		t.Errorf("Expected 3 handshakes, got %d", stats.Handshakes)
+	}
+	if stats.Resumed == 0 {
+		t.Errorf("Expected resumed handshakes to be counted, got %+v", stats)
+	}
+}
+
+func TestTimingsRecorded(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`{}`))
+	}))
+	defer server.Close()
+
+	client := infrastructure.NewClient().SetBaseURL(server.URL)
+
+	resp, err := client.Get(context.Background(), "/", nil, nil)
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	if resp.Timings.Total <= 0 || resp.Timings.TimeToFirstByte <= 0 {
+		t.Errorf("Expected total and first-byte timings, got %+v", resp.Timings)
+	}
+}
+
+func TestSetTLSPolicy(t *testing.T) {
+	server := httptest.NewUnstartedServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`{}`))
+	}))
+	server.TLS = &tls.Config{MaxVersion: tls.VersionTLS12}
+	server.StartTLS()
+	defer server.Close()
+
+	newClient := func(policy *models.TLSPolicy) *infrastructure.Client {
+		return infrastructure.NewClient().
+			SetBaseURL(server.URL).
+			SetTransport(server.Client().Transport).
+			SetTLSPolicy(policy)
+	}
+
+	if _, err := newClient(models.TLSPolicyModern()).Get(context.Background(), "/", nil, nil); err == nil {
+		t.Error("expected the modern policy to reject a TLS 1.2 server")
+	}
+
+	resp, err := newClient(models.TLSPolicyIntermediate()).Get(context.Background(), "/", nil, nil)
+	if err != nil {
+		t.Fatalf("expected the intermediate policy to connect, got %v", err)
+	}
+	if resp.Timings.TLSVersion != tls.VersionTLS12 {
+		t.Errorf("expected TLS 1.2, got %x", resp.Timings.TLSVersion)
+	}
+
+	custom := newClient(&models.TLSPolicy{
+		MinVersion:   tls.VersionTLS12,
+		CipherSuites: []uint16{tls.TLS_ECDHE_RSA_WITH_RC4_128_SHA},
+	})
+	var configErr *errors.ConfigError
+	if err := custom.Validate(); !stderrors.As(err, &configErr) || configErr.Field != "TLSPolicy.CipherSuites" {
+		t.Errorf("expected an insecure cipher suite to be reported, got %v", err)
+	}
+}
+
+func TestHostAndSNIOverrides(t *testing.T) {
+	server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("X-Host", r.Host)
+		w.Header().Set("X-SNI", r.TLS.ServerName)
+	}))
+	defer server.Close()
+
+	client := infrastructure.NewClient().
+		SetBaseURL(server.URL).
+		SetTransport(server.Client().Transport)
+
+	// The test certificate is valid for example.com and 127.0.0.1
+	resp, err := client.Get(context.Background(), "/", nil, nil,
+		infrastructure.WithHostOverride("api.internal"),
+		infrastructure.WithSNI("example.com"),
+	)
+	if err != nil {
+		t.Fatalf("Get failed: %v", err)
+	}
+	if resp.Headers.Get("X-Host") != "api.internal" || resp.Headers.Get("X-SNI") != "example.com" {
+		t.Errorf("unexpected host %q and server name %q", resp.Headers.Get("X-Host"), resp.Headers.Get("X-SNI"))
+	}
+
+	resp, err = client.Get(context.Background(), "/", nil, nil)
+	if err != nil {
+		t.Fatalf("Get failed: %v", err)
+	}
+	if resp.Headers.Get("X-SNI") != "" || resp.Headers.Get("X-Host") == "api.internal" {
+		t.Error("expected per-request overrides not to leak into other requests")
+	}
+
+	if _, err := client.Get(context.Background(), "/", nil, nil, infrastructure.WithSNI("other.test")); err == nil {
+		t.Error("expected a server name outside the certificate to fail verification")
+	}
+
+	resp, err = client.NewInstance().SetSNI("example.com").SetHostOverride("www.example.com").Get(context.Background(), "/", nil, nil)
+	if err != nil {
+		t.Fatalf("Get failed: %v", err)
+	}
+	if resp.Headers.Get("X-Host") != "www.example.com" || resp.Headers.Get("X-SNI") != "example.com" {
+		t.Errorf("unexpected host %q and server name %q", resp.Headers.Get("X-Host"), resp.Headers.Get("X-SNI"))
+	}
+}
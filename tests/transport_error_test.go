@@ -0,0 +1,86 @@
+package tests
+
+import (
+	"context"
+	stderrors "errors"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/fourth-ally/gofetch/domain/errors"
+	"github.com/fourth-ally/gofetch/domain/models"
+	"github.com/fourth-ally/gofetch/infrastructure"
+)
+
+func TestTransportErrorsAreClassified(t *testing.T) {
+	closed := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+	closedURL := closed.URL
+	closed.Close()
+
+	slow := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		select {
+		case <-r.Context().Done():
+		case <-time.After(time.Second):
+		}
+	}))
+	defer slow.Close()
+
+	tests := []struct {
+		name    string
+		baseURL string
+		timeout time.Duration
+		want    errors.TransportErrorKind
+	}{
+		{"connection refused", closedURL, 0, errors.TransportConnectionRefused},
+		{"timeout", slow.URL, 20 * time.Millisecond, errors.TransportTimeout},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			client := infrastructure.NewClient().SetBaseURL(tt.baseURL)
+			if tt.timeout > 0 {
+				client.SetTimeout(tt.timeout)
+			}
+
+			_, err := client.Get(context.Background(), "/", nil, nil)
+
+			var transportErr *errors.TransportError
+			if !stderrors.As(err, &transportErr) {
+				t.Fatalf("Expected TransportError, got %v", err)
+			}
+			if transportErr.Kind != tt.want {
+				t.Errorf("Expected kind %s, got %s (%v)", tt.want, transportErr.Kind, err)
+			}
+		})
+	}
+}
+
+func TestRetryPolicyByTransportErrorKind(t *testing.T) {
+	var hits int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&hits, 1)
+		conn, _, _ := w.(http.Hijacker).Hijack()
+		conn.Close()
+	}))
+	defer server.Close()
+
+	newClient := func(kinds ...errors.TransportErrorKind) *infrastructure.Client {
+		retry := models.NewRetryOptions()
+		retry.MaxRetries = 2
+		retry.InitialDelay = time.Millisecond
+		retry.RetryOnTransportErrors = kinds
+		return infrastructure.NewClient().SetBaseURL(server.URL).SetRetryOptions(retry)
+	}
+
+	newClient(errors.TransportTimeout).Get(context.Background(), "/", nil, nil)
+	if got := atomic.SwapInt32(&hits, 0); got != 1 {
+		t.Errorf("Expected connection reset not to be retried, got %d attempts", got)
+	}
+
+	newClient(errors.TransportConnectionReset).Get(context.Background(), "/", nil, nil)
+	if got := atomic.LoadInt32(&hits); got != 3 {
+		t.Errorf("Expected connection reset to be retried, got %d attempts", got)
+	}
+}
@@ -0,0 +1,74 @@
+package tests
+
+import (
+	"context"
+	stderrors "errors"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/fourth-ally/gofetch/domain/errors"
+	"github.com/fourth-ally/gofetch/domain/models"
+	"github.com/fourth-ally/gofetch/infrastructure"
+)
+
+func TestValidateDefaultConfiguration(t *testing.T) {
+	if err := infrastructure.NewClient().Validate(); err != nil {
+		t.Errorf("Expected default configuration to be valid, got %v", err)
+	}
+}
+
+func TestValidateDetectsInconsistentTimeouts(t *testing.T) {
+	client := infrastructure.NewClient().
+		SetTimeout(5 * time.Second).
+		SetTransport(&http.Transport{TLSHandshakeTimeout: time.Minute}).
+		SetHedging(&models.HedgingOptions{Delay: 10 * time.Second, MaxHedges: 1})
+
+	err := client.Validate()
+
+	var configErr *errors.ConfigError
+	if !stderrors.As(err, &configErr) {
+		t.Fatalf("Expected ConfigError, got %v", err)
+	}
+
+	for _, field := range []string{"TLSHandshakeTimeout", "HedgingOptions.Delay"} {
+		if !containsField(err, field) {
+			t.Errorf("Expected a problem for %s, got %v", field, err)
+		}
+	}
+}
+
+func TestValidateContextChecksRetryBudget(t *testing.T) {
+	retry := models.NewRetryOptions()
+	retry.MaxRetries = 3
+
+	client := infrastructure.NewClient().
+		SetTimeout(15 * time.Second).
+		SetRetryOptions(retry)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Second)
+	defer cancel()
+
+	if err := client.ValidateContext(ctx); !containsField(err, "RetryOptions") {
+		t.Errorf("Expected retry budget problem, got %v", err)
+	}
+
+	if err := client.ValidateContext(context.Background()); err != nil {
+		t.Errorf("Expected no problem without a deadline, got %v", err)
+	}
+}
+
+func containsField(err error, field string) bool {
+	joined, ok := err.(interface{ Unwrap() []error })
+	if !ok {
+		return false
+	}
+
+	for _, e := range joined.Unwrap() {
+		var configErr *errors.ConfigError
+		if stderrors.As(e, &configErr) && configErr.Field == field {
+			return true
+		}
+	}
+	return false
+}
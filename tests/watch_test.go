@@ -0,0 +1,109 @@
+package tests
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/fourth-ally/gofetch/domain/models"
+	"github.com/fourth-ally/gofetch/infrastructure"
+)
+
+func TestWatchReportsChangesAndRevalidates(t *testing.T) {
+	var mu sync.Mutex
+	polls, notModified := 0, 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		defer mu.Unlock()
+		polls++
+
+		version := `"v1"`
+		if polls >= 4 {
+			version = `"v2"`
+		}
+		if r.Header.Get("If-None-Match") == version {
+			notModified++
+			w.WriteHeader(http.StatusNotModified)
+			return
+		}
+
+		w.Header().Set("ETag", version)
+		w.Write([]byte(`{"version":` + version + `}`))
+	}))
+	defer server.Close()
+
+	client := infrastructure.NewClient().SetBaseURL(server.URL)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 2*time.Second)
+	defer cancel()
+
+	var versions []string
+	var state struct {
+		Version string `json:"version"`
+	}
+	err := client.Watch(ctx, "/config", nil, &state, &models.WatchOptions{
+		MinInterval: 5 * time.Millisecond,
+		MaxInterval: 20 * time.Millisecond,
+		Jitter:      0.2,
+	}, func(resp *models.Response) error {
+		versions = append(versions, state.Version)
+		if len(versions) == 2 {
+			cancel()
+		}
+		return nil
+	})
+
+	if err != context.Canceled {
+		t.Fatalf("Expected context.Canceled, got %v", err)
+	}
+	if len(versions) != 2 || versions[0] != "v1" || versions[1] != "v2" {
+		t.Errorf("Expected changes [v1 v2], got %v", versions)
+	}
+
+	mu.Lock()
+	defer mu.Unlock()
+	if notModified != 2 {
+		t.Errorf("Expected 2 conditional polls answered with 304, got %d", notModified)
+	}
+}
+
+func TestWatchBacksOffWhileUnchanged(t *testing.T) {
+	var mu sync.Mutex
+	var times []time.Time
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		times = append(times, time.Now())
+		mu.Unlock()
+		w.Write([]byte(`{}`))
+	}))
+	defer server.Close()
+
+	client := infrastructure.NewClient().SetBaseURL(server.URL)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 300*time.Millisecond)
+	defer cancel()
+
+	client.Watch(ctx, "/static", nil, nil, &models.WatchOptions{
+		MinInterval: 10 * time.Millisecond,
+		MaxInterval: 80 * time.Millisecond,
+		Growth:      2,
+	}, func(*models.Response) error { return nil })
+
+	mu.Lock()
+	defer mu.Unlock()
+	if len(times) < 4 {
+		t.Fatalf("Expected several polls, got %d", len(times))
+	}
+
+	first := times[1].Sub(times[0])
+	last := times[len(times)-1].Sub(times[len(times)-2])
+	if last <= first {
+		t.Errorf("Expected interval to grow while unchanged, first %v last %v", first, last)
+	}
+	if len(times) > 10 {
+		t.Errorf("Expected backoff to limit polls, got %d", len(times))
+	}
+}
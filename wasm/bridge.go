@@ -8,6 +8,7 @@ import (
 	"errors"
 	"syscall/js"
 
+	"github.com/fourth-ally/gofetch/domain/models"
 	"github.com/fourth-ally/gofetch/infrastructure"
 )
 
@@ -30,6 +31,8 @@ func ExposeFunctions() {
 		"setTimeout":      js.FuncOf(setTimeout),
 		"setHeader":       js.FuncOf(setHeader),
 		"setRetryOptions": js.FuncOf(setRetryOptions),
+		"setFetchOptions": js.FuncOf(setFetchOptions),
+		"subscribe":       js.FuncOf(subscribe),
 	}))
 }
 
@@ -48,6 +51,8 @@ func newClient(this js.Value, args []js.Value) interface{} {
 		"setTimeout":      js.FuncOf(makeSetTimeoutFunc(client)),
 		"setHeader":       js.FuncOf(makeSetHeaderFunc(client)),
 		"setRetryOptions": js.FuncOf(makeSetRetryOptionsFunc(client)),
+		"setFetchOptions": js.FuncOf(makeSetFetchOptionsFunc(client)),
+		"subscribe":       js.FuncOf(makeSubscribeFunc(client)),
 		"newInstance":     js.FuncOf(makeNewInstanceFunc(client)),
 	}
 }
@@ -97,6 +102,16 @@ func setRetryOptions(this js.Value, args []js.Value) interface{} {
 	return makeSetRetryOptionsFunc(defaultClient)(this, args)
 }
 
+// setFetchOptions sets fetch options on the default client.
+func setFetchOptions(this js.Value, args []js.Value) interface{} {
+	return makeSetFetchOptionsFunc(defaultClient)(this, args)
+}
+
+// subscribe opens a server-sent events subscription on the default client.
+func subscribe(this js.Value, args []js.Value) interface{} {
+	return makeSubscribeFunc(defaultClient)(this, args)
+}
+
 // Helper functions to create closures for specific client instances
 
 func makeGetFunc(client *infrastructure.Client) func(js.Value, []js.Value) interface{} {
@@ -298,6 +313,17 @@ func makeSetRetryOptionsFunc(client *infrastructure.Client) func(js.Value, []js.
 	}
 }
 
+func makeSetFetchOptionsFunc(client *infrastructure.Client) func(js.Value, []js.Value) interface{} {
+	return func(this js.Value, args []js.Value) interface{} {
+		if len(args) < 1 {
+			return this
+		}
+
+		client.SetFetchOptions(jsToFetchOptions(args[0]))
+		return this
+	}
+}
+
 func makeNewInstanceFunc(client *infrastructure.Client) func(js.Value, []js.Value) interface{} {
 	return func(this js.Value, args []js.Value) interface{} {
 		newClient := client.NewInstance()
@@ -311,7 +337,73 @@ func makeNewInstanceFunc(client *infrastructure.Client) func(js.Value, []js.Valu
 			"setTimeout":      js.FuncOf(makeSetTimeoutFunc(newClient)),
 			"setHeader":       js.FuncOf(makeSetHeaderFunc(newClient)),
 			"setRetryOptions": js.FuncOf(makeSetRetryOptionsFunc(newClient)),
+			"setFetchOptions": js.FuncOf(makeSetFetchOptionsFunc(newClient)),
 			"newInstance":     js.FuncOf(makeNewInstanceFunc(newClient)),
 		}
 	}
 }
+
+// makeSubscribeFunc returns subscribe(path, onEvent, onError, options) for
+// client. onEvent receives {id, event, data, retry} objects and onError
+// receives the error message and whether the subscription will reconnect.
+// It returns a function that closes the subscription.
+func makeSubscribeFunc(client *infrastructure.Client) func(js.Value, []js.Value) interface{} {
+	return func(this js.Value, args []js.Value) interface{} {
+		if len(args) < 2 || args[1].Type() != js.TypeFunction {
+			return nil
+		}
+
+		path := args[0].String()
+		onEvent := args[1]
+		onError := js.Undefined()
+		if len(args) >= 3 && args[2].Type() == js.TypeFunction {
+			onError = args[2]
+		}
+
+		var params map[string]interface{}
+		options := models.NewSSEOptions()
+		if len(args) >= 4 && args[3].Type() == js.TypeObject {
+			params = jsToSSEOptions(args[3], options)
+		}
+
+		reportError := func(err error, reconnecting bool) {
+			if onError.Type() == js.TypeFunction {
+				onError.Invoke(err.Error(), reconnecting)
+			}
+		}
+		options.OnError = func(err error) {
+			reportError(err, true)
+		}
+
+		// The returned close function aborts a JS AbortController, so JS
+		// holds no Go function and the abort listener can be released
+		// once the subscription ends, whether or not it was closed
+		controller := js.Global().Get("AbortController").New()
+		signal := controller.Get("signal")
+
+		ctx, cancel := context.WithCancel(context.Background())
+		onAbort := js.FuncOf(func(this js.Value, args []js.Value) interface{} {
+			cancel()
+			return nil
+		})
+		signal.Call("addEventListener", "abort", onAbort)
+
+		go func() {
+			defer func() {
+				signal.Call("removeEventListener", "abort", onAbort)
+				onAbort.Release()
+				cancel()
+			}()
+
+			err := client.Subscribe(ctx, path, params, options, func(event models.SSEEvent) error {
+				onEvent.Invoke(sseEventToJS(event))
+				return nil
+			})
+			if err != nil && ctx.Err() == nil {
+				reportError(err, false)
+			}
+		}()
+
+		return controller.Get("abort").Call("bind", controller)
+	}
+}
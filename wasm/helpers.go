@@ -179,3 +179,68 @@ func jsToRetryOptions(jsOpts js.Value) *models.RetryOptions {
 
 	return opts
 }
+
+// jsToFetchOptions converts JavaScript fetch options to Go FetchOptions.
+func jsToFetchOptions(jsOpts js.Value) *models.FetchOptions {
+	if jsOpts.Type() != js.TypeObject {
+		return nil
+	}
+
+	opts := &models.FetchOptions{}
+
+	if mode := jsOpts.Get("mode"); mode.Type() == js.TypeString {
+		opts.Mode = mode.String()
+	}
+
+	if credentials := jsOpts.Get("credentials"); credentials.Type() == js.TypeString {
+		opts.Credentials = credentials.String()
+	}
+
+	if cache := jsOpts.Get("cache"); cache.Type() == js.TypeString {
+		opts.Cache = cache.String()
+	}
+
+	if redirect := jsOpts.Get("redirect"); redirect.Type() == js.TypeString {
+		opts.Redirect = redirect.String()
+	}
+
+	if simple := jsOpts.Get("simpleHeadersOnly"); simple.Type() == js.TypeBoolean {
+		opts.SimpleHeadersOnly = simple.Bool()
+	}
+
+	return opts
+}
+
+// sseEventToJS converts an SSE event to a JavaScript object.
+func sseEventToJS(event models.SSEEvent) interface{} {
+	return map[string]interface{}{
+		"id":    event.ID,
+		"event": event.Event,
+		"data":  event.Data,
+		"retry": int(event.Retry / time.Millisecond),
+	}
+}
+
+// jsToSSEOptions applies JavaScript subscribe options to opts and returns
+// the query parameters they carry.
+func jsToSSEOptions(jsOpts js.Value, opts *models.SSEOptions) map[string]interface{} {
+	var params map[string]interface{}
+	if p := jsOpts.Get("params"); p.Type() == js.TypeObject {
+		params = jsObjectToMap(p)
+	}
+
+	if lastEventID := jsOpts.Get("lastEventId"); lastEventID.Type() == js.TypeString {
+		opts.LastEventID = lastEventID.String()
+	}
+
+	// ReconnectDelay (milliseconds)
+	if delay := jsOpts.Get("reconnectDelay"); delay.Type() == js.TypeNumber {
+		opts.ReconnectDelay = durationFromMillis(delay.Int())
+	}
+
+	if maxReconnects := jsOpts.Get("maxReconnects"); maxReconnects.Type() == js.TypeNumber {
+		opts.MaxReconnects = maxReconnects.Int()
+	}
+
+	return params
+}